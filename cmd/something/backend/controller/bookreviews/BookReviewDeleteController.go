@@ -3,12 +3,13 @@ package bookreviews
 import (
 	"net/http"
 	"something/internal/bookreviews/application/delete"
+	"something/internal/bookreviews/application/find"
 
 	"github.com/gin-gonic/gin"
 )
 
 // DeleteBookReviewController ...
-func DeleteBookReviewController(delete delete.Service) func(c *gin.Context) {
+func DeleteBookReviewController(finder find.Service, delete delete.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var param urlParameter
 		if err := c.ShouldBindUri(&param); err != nil {
@@ -16,6 +17,10 @@ func DeleteBookReviewController(delete delete.Service) func(c *gin.Context) {
 			return
 		}
 
+		if !authorizeReview(c, finder, param.ID, true) {
+			return
+		}
+
 		err := delete.DeleteBookReviewByID(param.ID)
 		if err != nil {
 			if err.Error() == "book review not found" {
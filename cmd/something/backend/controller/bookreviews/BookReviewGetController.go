@@ -2,7 +2,9 @@ package bookreviews
 
 import (
 	"net/http"
+	"something/internal/bookreviews/application"
 	"something/internal/bookreviews/application/find"
+	commentFind "something/internal/comments/application/find"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,7 +15,7 @@ type urlParameter struct {
 }
 
 // GetBookReviewController ...
-func GetBookReviewController(finder find.Service) func(c *gin.Context) {
+func GetBookReviewController(finder find.Service, commentFinder commentFind.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var param urlParameter
 		if err := c.ShouldBindUri(&param); err != nil {
@@ -34,6 +36,7 @@ func GetBookReviewController(finder find.Service) func(c *gin.Context) {
 			})
 			return
 		}
+		addCommentCounts([]*application.BookReviewResponse{bookReview}, commentFinder)
 
 		c.JSON(http.StatusOK, gin.H{
 			"data": bookReview,
@@ -2,13 +2,14 @@ package bookreviews
 
 import (
 	"net/http"
+	"something/internal/bookreviews/application/find"
 	"something/internal/bookreviews/application/update"
 
 	"github.com/gin-gonic/gin"
 )
 
 // PatchController ...
-func PatchController(us update.Service) func(c *gin.Context) {
+func PatchController(finder find.Service, us update.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var param urlParameter
 		if err := c.ShouldBindUri(&param); err != nil {
@@ -16,26 +17,24 @@ func PatchController(us update.Service) func(c *gin.Context) {
 			return
 		}
 
-		userID, ok := c.Get("user_id")
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Something wrong happened, try again later ...",
-			})
-			return
-		}
-
 		var request update.BookReviewCommand
 		if err := c.ShouldBindJSON(&request); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorizeReview(c, finder, param.ID, false) {
+			return
+		}
 		request.ID = param.ID
-		request.UserID = userID.(string)
 
 		err := us.UpdateBookReviewByID(&request)
 		if err != nil {
-			if err.Error() == "unauthorized" {
-				c.JSON(http.StatusUnauthorized, gin.H{
+			if err.Error() == "book review not found" {
+				c.JSON(http.StatusNotFound, gin.H{
 					"error": err.Error(),
 				})
 				return
@@ -46,6 +46,18 @@ func PutController(creator create.Service) func(c *gin.Context) {
 
 		err := creator.CreateBookReview(&request)
 		if err != nil {
+			if err.Error() == "book not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			if err.Error() == "book already reviewed" || err.Error() == "book review id already exists" {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Something wrong happened, try again later ...",
 			})
@@ -0,0 +1,58 @@
+package bookreviews
+
+import (
+	"net/http"
+	"something/internal/bookreviews/application/vote"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VoteController ...
+func VoteController(voter vote.Service) func(c *gin.Context) {
+	return voteController(voter.Vote)
+}
+
+// UnvoteController ...
+func UnvoteController(voter vote.Service) func(c *gin.Context) {
+	return voteController(voter.Unvote)
+}
+
+func voteController(change func(reviewID, userID string) error) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		err := change(param.ID, userID.(string))
+		if err != nil {
+			switch err.Error() {
+			case "book review not found", "vote not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			case "review already voted", "can't vote your own review":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,15 +14,21 @@ import (
 	"something/internal/bookreviews/application/create"
 	"something/internal/bookreviews/application/delete"
 	"something/internal/bookreviews/application/find"
+	"something/internal/bookreviews/application/rating"
 	"something/internal/bookreviews/application/update"
+	"something/internal/bookreviews/application/vote"
 	"something/internal/bookreviews/domain"
 	"something/internal/bookreviews/infraestructure/persistence"
 	bookFind "something/internal/books/application/find"
 	bookDomain "something/internal/books/domain"
 	bookPersistance "something/internal/books/infraestructure/persistence"
+	commentFind "something/internal/comments/application/find"
+	commentDomain "something/internal/comments/domain"
+	commentPersistence "something/internal/comments/infraestructure/persistence"
 	userFind "something/internal/users/application/find"
 	userDomain "something/internal/users/domain"
 	userPersistance "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
 	jwt "something/pkg/redisjwt"
 	"testing"
 	"time"
@@ -32,12 +39,18 @@ import (
 )
 
 var tokenParams *jwt.TokenParams = &jwt.TokenParams{
-	AccessSecret:  "secure-access-token",
-	RefreshSecret: "secure-refresh-token",
-	AccessTime:    time.Minute * 1,
-	RefreshTime:   time.Minute * 1,
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
 }
 
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+var bus eventbus.Bus
+
+var commentRepo commentDomain.CommentRepository
+
 const bookID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
 const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
 
@@ -50,13 +63,25 @@ func setupServer(
 	finder := find.NewService(bookReviewRepo)
 	bookFinder := bookFind.NewService(bookRepo)
 	userFinder := userFind.NewService(userRepo)
-	updater := update.NewService(bookReviewRepo)
-	creator := create.NewService(bookReviewRepo)
-	deletor := delete.NewService(bookReviewRepo)
-	RegisterRoutes(finder, bookFinder, userFinder, creator, updater, deletor, tokenParams.AccessSecret, router)
+	ratings := rating.NewService(bookReviewRepo, bookRepo)
+	updater := update.NewService(bookReviewRepo, ratings, bus)
+	creator := create.NewService(bookReviewRepo, bookRepo, ratings, bus)
+	deletor := delete.NewService(bookReviewRepo, ratings, bus)
+	commentFinder := commentFind.NewService(commentRepo)
+	voter := vote.NewService(bookReviewRepo)
+	RegisterRoutes(finder, bookFinder, userFinder, commentFinder, creator, updater, deletor, voter, tokenParams.AccessKeys, auth, router)
 	return router
 }
 
+// unratedRepository fails to update the rating aggregates of the books
+type unratedRepository struct {
+	bookDomain.BookRepository
+}
+
+func (r *unratedRepository) SetRating(id string, sum float64, count int) error {
+	return errors.New("connection lost")
+}
+
 func TestBookReviewCheck(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Book Review Suite")
@@ -82,6 +107,8 @@ var _ = Describe("Server", func() {
 		defaultBook, _ := bookDomain.NewBook(bookID, "title", "description", "author", "genre", 1)
 		bookRepo.Save(defaultBook)
 		bookReviewRepo = persistence.NewMongoBookReviewRepository(dbClient)
+		bus = eventbus.NewInMemoryBus()
+		commentRepo = commentPersistence.NewInMemoryCommentRepository()
 		server = httptest.NewServer(setupServer(bookReviewRepo, bookRepo, userRepo))
 	})
 
@@ -129,12 +156,135 @@ var _ = Describe("Server", func() {
 									"name":"",
 									"username":""
 							},
+							"comments":0,
+							"helpful":0,
 							"created_on":"` + newBookReview.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
 						}
 					]
 			}`))
 		})
 	})
+	listReviewIDs := func(path string) []string {
+		resp, err := http.Get(server.URL + path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		defer resp.Body.Close()
+		reviews := &struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}{}
+		Expect(json.NewDecoder(resp.Body).Decode(reviews)).Should(Succeed())
+		ids := []string{}
+		for _, review := range reviews.Data {
+			ids = append(ids, review.ID)
+		}
+		return ids
+	}
+
+	Context("When GET request with sort is sent to /books/:id/reviews", func() {
+		getReviewIDs := func(sort string) []string {
+			return listReviewIDs("/books/" + bookID + "/reviews?sort=" + sort)
+		}
+
+		BeforeEach(func() {
+			for i, rating := range []float64{3, 5, 1} {
+				review, _ := domain.NewBookReview(fmt.Sprintf("%d", i+1), "abc", rating, bookID, fmt.Sprintf("user-%d", i))
+				review.CreatedOn = review.CreatedOn.Add(time.Duration(i) * time.Minute)
+				bookReviewRepo.Save(review)
+			}
+			bookReviewRepo.AddVote("1", "a")
+			bookReviewRepo.AddVote("1", "b")
+			bookReviewRepo.AddVote("3", "a")
+		})
+		It("Returns the most recent reviews first by default", func() {
+			Expect(getReviewIDs("")).Should(Equal([]string{"3", "2", "1"}))
+			Expect(getReviewIDs("recent")).Should(Equal([]string{"3", "2", "1"}))
+		})
+		It("Returns the most helpful reviews first", func() {
+			Expect(getReviewIDs("helpful")).Should(Equal([]string{"1", "3", "2"}))
+		})
+		It("Returns the best rated reviews first", func() {
+			Expect(getReviewIDs("rating")).Should(Equal([]string{"2", "1", "3"}))
+		})
+		It("Returns an 400 status code with an unknown sort", func() {
+			resp, err := http.Get(server.URL + "/books/" + bookID + "/reviews?sort=oldest")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+		})
+	})
+	Context("When GET request with paging and filters is sent to /books/:id/reviews", func() {
+		BeforeEach(func() {
+			createdOn := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+			for i, rating := range []float64{1, 2, 3, 4, 5} {
+				review, _ := domain.NewBookReview(fmt.Sprintf("%d", i+1), "abc", rating, bookID, fmt.Sprintf("user-%d", i))
+				review.CreatedOn = createdOn.AddDate(0, 0, i)
+				bookReviewRepo.Save(review)
+			}
+		})
+		It("Returns the requested page", func() {
+			path := "/books/" + bookID + "/reviews?per_page=2"
+			Expect(listReviewIDs(path)).Should(Equal([]string{"5", "4"}))
+			Expect(listReviewIDs(path + "&page=2")).Should(Equal([]string{"3", "2"}))
+			Expect(listReviewIDs(path + "&page=3")).Should(Equal([]string{"1"}))
+			Expect(listReviewIDs(path + "&page=4")).Should(BeEmpty())
+		})
+		It("Filters by rating range", func() {
+			path := "/books/" + bookID + "/reviews?min_rating=2&max_rating=3.5"
+			Expect(listReviewIDs(path)).Should(Equal([]string{"3", "2"}))
+		})
+		It("Filters by date range including the whole last day", func() {
+			path := "/books/" + bookID + "/reviews?from=2020-03-02&to=2020-03-04"
+			Expect(listReviewIDs(path)).Should(Equal([]string{"4", "3", "2"}))
+			path = "/books/" + bookID + "/reviews?from=2020-03-04T00:00:00Z"
+			Expect(listReviewIDs(path)).Should(Equal([]string{"5", "4"}))
+		})
+		It("Returns an 400 status code with invalid filters", func() {
+			for _, query := range []string{
+				"min_rating=6", "max_rating=abc", "min_rating=4&max_rating=2",
+				"from=yesterday", "from=2020-03-05&to=2020-03-01",
+			} {
+				resp, err := http.Get(server.URL + "/books/" + bookID + "/reviews?" + query)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			}
+		})
+	})
+	Context("When GET request is sent to /users/:id/reviews", func() {
+		const otherBookID = "0a3c6d6e-9bd5-4cde-8d4b-4a2a58f1c0a1"
+
+		BeforeEach(func() {
+			user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			for i, book := range []string{bookID, otherBookID, bookID} {
+				reviewUser := userID
+				if i == 2 {
+					reviewUser = "a8e4ad36-8bc5-4bd4-8e4c-7c4e0f4d31a2"
+				}
+				review, _ := domain.NewBookReview(fmt.Sprintf("%d", i+1), "abc", 4, book, reviewUser)
+				review.CreatedOn = review.CreatedOn.Add(time.Duration(i) * time.Minute)
+				bookReviewRepo.Save(review)
+			}
+		})
+		It("Returns the reviews of the user, most recent first", func() {
+			Expect(listReviewIDs("/users/" + userID + "/reviews")).Should(Equal([]string{"2", "1"}))
+			Expect(listReviewIDs("/users/" + userID + "/reviews?per_page=1&page=2")).Should(Equal([]string{"1"}))
+		})
+		It("Returns the user info of the reviews", func() {
+			resp, err := http.Get(server.URL + "/users/" + userID + "/reviews?per_page=1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).Should(ContainSubstring(`"username":"madison1"`))
+		})
+		It("Returns an 404 status code with a non existing user", func() {
+			resp, err := http.Get(server.URL + "/users/a8e4ad36-8bc5-4bd4-8e4c-7c4e0f4d31a2/reviews")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+		})
+	})
 	Context("When GET request by ID is sent to /book/reviews/:review_id", func() {
 		It("Returns an existing book review by id", func() {
 			newBookReview, _ := domain.NewBookReview("c0b369a0-8de4-417d-a905-c33644c2907d", "abc", 1, bookID, userID)
@@ -162,10 +312,34 @@ var _ = Describe("Server", func() {
 							"name":"",
 							"username":""
 						},
+						"comments":0,
+						"helpful":0,
 						"created_on":"` + newBookReview.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
 					}
 			}`))
 		})
+		It("Returns the number of comments of the review", func() {
+			reviewID := "c0b369a0-8de4-417d-a905-c33644c2907d"
+			newBookReview, _ := domain.NewBookReview(reviewID, "abc", 1, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+			for _, id := range []string{"1", "2"} {
+				comment, _ := commentDomain.NewComment(id, bookID, reviewID, "", userID, "nice")
+				commentRepo.Save(comment)
+			}
+			reply, _ := commentDomain.NewComment("3", bookID, reviewID, "1", userID, "thanks")
+			commentRepo.Save(reply)
+
+			resp, err := http.Get(server.URL + "/book/reviews/" + reviewID)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			review := &struct {
+				Data struct {
+					Comments int `json:"comments"`
+				} `json:"data"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(review)).Should(Succeed())
+			Expect(review.Data.Comments).Should(Equal(3))
+		})
 		It("Returns an 404 status code in non existing id", func() {
 			resp, err := http.Get(
 				server.URL + "/book/reviews/c0b369a0-8de4-417d-a905-c33644c2907d")
@@ -180,6 +354,11 @@ var _ = Describe("Server", func() {
 		})
 	})
 	Context("When PUT request by ID is sent to /books/:id/reviews/:review_id", func() {
+		BeforeEach(func() {
+			reviewer, _ := userDomain.NewUser(userID, "reviewer", "reviewer1", "reviewer@example.com", "secret-pass-1")
+			reviewer.Verified = true
+			userRepo.Save(reviewer)
+		})
 		It("Create a new books review", func() {
 			reviewID := "c0b369a0-8de4-417d-a905-c33644c2907d"
 			bookReview := map[string]interface{}{
@@ -190,6 +369,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodPut,
@@ -205,10 +385,190 @@ var _ = Describe("Server", func() {
 
 			createdReview, _ := bookReviewRepo.FindByID(reviewID)
 			Expect(createdReview).ShouldNot(BeNil())
+
+			book, _ := bookRepo.FindByID(bookID)
+			Expect(book.RatingCount).Should(Equal(1))
+			Expect(book.RatingSum).Should(Equal(float64(1)))
+		})
+		It("Recomputes the book aggregates that drifted from the reviews", func() {
+			previousReview, _ := domain.NewBookReview("5e2f7a1c-8b3d-4c6e-9f0a-2d4b6c8e1f37", "abc", 2, bookID, "0d9c8b7a-6f5e-4d3c-8b2a-1f0e9d8c7b6a")
+			bookReviewRepo.Save(previousReview)
+			bookRepo.SetRating(bookID, 10, 3)
+
+			jsonReq, err := json.Marshal(map[string]interface{}{"text": "abc", "rating": 4})
+			Expect(err).ShouldNot(HaveOccurred())
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/"+bookID+"/reviews/c0b369a0-8de4-417d-a905-c33644c2907d",
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusCreated))
+
+			book, _ := bookRepo.FindByID(bookID)
+			Expect(book.RatingCount).Should(Equal(2))
+			Expect(book.RatingSum).Should(Equal(float64(6)))
+			Expect(book.Rating).Should(Equal(float64(3)))
+		})
+		It("Returns an 500 status code when the book aggregates can't be updated", func() {
+			server.Close()
+			server = httptest.NewServer(setupServer(bookReviewRepo, &unratedRepository{bookRepo}, userRepo))
+
+			jsonReq, err := json.Marshal(map[string]interface{}{"text": "abc", "rating": 4})
+			Expect(err).ShouldNot(HaveOccurred())
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/"+bookID+"/reviews/c0b369a0-8de4-417d-a905-c33644c2907d",
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusInternalServerError))
+		})
+		It("Backfills the aggregates of the books reviewed before they existed", func() {
+			for i, reviewID := range []string{"5e2f7a1c-8b3d-4c6e-9f0a-2d4b6c8e1f37", "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"} {
+				bookReview, _ := domain.NewBookReview(reviewID, "abc", float64(i+3), bookID, reviewID)
+				bookReviewRepo.Save(bookReview)
+			}
+			Expect(rating.NewService(bookReviewRepo, bookRepo).Backfill()).Should(Succeed())
+
+			book, _ := bookRepo.FindByID(bookID)
+			Expect(book.RatingCount).Should(Equal(2))
+			Expect(book.Rating).Should(Equal(3.5))
+		})
+		It("Publishes a review created event", func() {
+			reviewID := "c0b369a0-8de4-417d-a905-c33644c2907d"
+			var published []*domain.ReviewCreated
+			bus.Subscribe(domain.ReviewCreatedEvent, func(event eventbus.Event) error {
+				published = append(published, event.(*domain.ReviewCreated))
+				return nil
+			})
+			jsonReq, err := json.Marshal(map[string]interface{}{"text": "abc", "rating": 4})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/"+bookID+"/reviews/"+reviewID,
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusCreated))
+
+			Expect(published).Should(HaveLen(1))
+			Expect(published[0].ReviewID).Should(Equal(reviewID))
+			Expect(published[0].BookID).Should(Equal(bookID))
+			Expect(published[0].UserID).Should(Equal(userID))
+			Expect(published[0].Rating).Should(Equal(float64(4)))
+		})
+		It("Returns an 404 status code in non existing book", func() {
+			reviewID := "0b8f3e2a-6c1d-4f7e-a9b5-3d2c1e0f8a76"
+			bookReview := map[string]interface{}{
+				"text":   "abc",
+				"rating": 4,
+			}
+			jsonReq, err := json.Marshal(bookReview)
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/7d4e2b1c-9a8f-4c3e-b6d5-0e1f2a3b4c5d/reviews/"+reviewID,
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+
+			createdReview, _ := bookReviewRepo.FindByID(reviewID)
+			Expect(createdReview).Should(BeNil())
+		})
+		It("Returns an 400 status code when the user already reviewed the book", func() {
+			existingReview, _ := domain.NewBookReview("5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9", "abc", 3, bookID, userID)
+			bookReviewRepo.Save(existingReview)
+
+			reviewID := "3f2e1d0c-b9a8-4c7d-8e6f-5a4b3c2d1e0f"
+			jsonReq, err := json.Marshal(map[string]interface{}{"text": "again", "rating": 5})
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/"+bookID+"/reviews/"+reviewID,
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"book already reviewed"}`))
+
+			createdReview, _ := bookReviewRepo.FindByID(reviewID)
+			Expect(createdReview).Should(BeNil())
+		})
+		It("Returns an 403 status code when the email isn't verified", func() {
+			unverifiedID := "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+			unverified, _ := userDomain.NewUser(unverifiedID, "new", "new1", "new@example.com", "secret-pass-1")
+			userRepo.Save(unverified)
+
+			reviewID := "2a3b4c5d-6e7f-4a8b-9c0d-1e2f3a4b5c6d"
+			jsonReq, err := json.Marshal(map[string]interface{}{"text": "abc", "rating": 4})
+
+			generateAuth, err := jwt.CreateToken(unverifiedID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(unverifiedID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/books/"+bookID+"/reviews/"+reviewID,
+				bytes.NewBuffer(jsonReq))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"email not verified"}`))
+
+			createdReview, _ := bookReviewRepo.FindByID(reviewID)
+			Expect(createdReview).Should(BeNil())
 		})
 		It("Returns an 400 status code with an invalid uuid", func() {
 			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodPut,
@@ -233,6 +593,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodPatch,
@@ -259,7 +620,99 @@ var _ = Describe("Server", func() {
 			updatedBookReview.CreatedOn = bookReview.CreatedOn
 			Expect(bookReview).Should(BeEquivalentTo(updatedBookReview))
 		})
-		It("Returns an 401 status code with not review owner", func() {
+		It("modify the rating of an existing review and the book aggregates", func() {
+			newBookReview, _ := domain.NewBookReview("47bb4bed-e1ee-413a-85ed-2cc4c598e562", "abc", 2, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+			bookReviewRepo.AddVote(newBookReview.ID, "55a5cd53-6d6d-46f1-9eb0-689435c269f0")
+			bookRepo.SetRating(bookID, 2, 1)
+
+			var published []*domain.ReviewUpdated
+			bus.Subscribe(domain.ReviewUpdatedEvent, func(event eventbus.Event) error {
+				published = append(published, event.(*domain.ReviewUpdated))
+				return nil
+			})
+
+			jsonReq, err := json.Marshal(map[string]interface{}{"rating": 4.5})
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPatch,
+				server.URL+"/book/reviews/"+newBookReview.ID,
+				bytes.NewBuffer(jsonReq),
+			)
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
+			Expect(bookReview.Rating).Should(Equal(4.5))
+			Expect(bookReview.Text).Should(Equal("abc"))
+			Expect(bookReview.Helpful).Should(Equal(1))
+
+			book, _ := bookRepo.FindByID(bookID)
+			Expect(book.RatingCount).Should(Equal(1))
+			Expect(book.RatingSum).Should(Equal(4.5))
+
+			Expect(published).Should(HaveLen(1))
+			Expect(published[0].PreviousRating).Should(Equal(float64(2)))
+		})
+		It("Returns an 400 status code with an invalid rating or no fields", func() {
+			newBookReview, _ := domain.NewBookReview("47bb4bed-e1ee-413a-85ed-2cc4c598e562", "abc", 2, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			for _, body := range []string{`{"rating": 7}`, `{}`} {
+				req, err := http.NewRequest(
+					http.MethodPatch,
+					server.URL+"/book/reviews/"+newBookReview.ID,
+					bytes.NewBufferString(body),
+				)
+				req.Header.Set("Content-Type", "application/json; charset=utf-8")
+				req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+				client := &http.Client{}
+
+				resp, err := client.Do(req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			}
+
+			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
+			Expect(bookReview.Rating).Should(Equal(float64(2)))
+		})
+		It("Returns an 403 status code to moderators modifying other users reviews", func() {
+			newBookReview, _ := domain.NewBookReview("47bb4bed-e1ee-413a-85ed-2cc4c598e562", "abc", 1, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+
+			moderatorID := "55a5cd53-6d6d-46f1-9eb0-689435c269f0"
+			generateAuth, err := jwt.CreateToken(moderatorID, "moderator", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(moderatorID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodPatch,
+				server.URL+"/book/reviews/"+newBookReview.ID,
+				bytes.NewBufferString(`{"text": "edited by a moderator"}`),
+			)
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
+
+			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
+			Expect(bookReview.Text).Should(Equal("abc"))
+		})
+		It("Returns an 403 status code with not review owner", func() {
 			newBookReview, _ := domain.NewBookReview("47bb4bed-e1ee-413a-85ed-2cc4c598e562", "abc", 1, bookID, userID)
 			bookReviewRepo.Save(newBookReview)
 
@@ -270,6 +723,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken("55a5cd53-6d6d-46f1-9eb0-689435c269f0", "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth("55a5cd53-6d6d-46f1-9eb0-689435c269f0", generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodPatch,
@@ -282,21 +736,23 @@ var _ = Describe("Server", func() {
 
 			resp, err := client.Do(req)
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
 
 			body, err := ioutil.ReadAll(resp.Body)
 			defer resp.Body.Close()
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(string(body)).To(MatchJSON(`{"error":"unauthorized"}`))
+			Expect(string(body)).To(MatchJSON(`{"error":"forbidden"}`))
 		})
 	})
 	Context("When DELETE request by ID is sent to /book/reviews/:review_id", func() {
 		It("delete an existing book review", func() {
 			newBookReview, _ := domain.NewBookReview("f73cbfc4-1971-49d6-8964-d696b4e2e220", "abc", 1, bookID, userID)
 			bookReviewRepo.Save(newBookReview)
+			bookRepo.SetRating(bookID, newBookReview.Rating, 1)
 
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodDelete,
 				server.URL+"/book/reviews/f73cbfc4-1971-49d6-8964-d696b4e2e220",
@@ -309,10 +765,50 @@ var _ = Describe("Server", func() {
 
 			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
 			Expect(bookReview).Should(BeNil())
+
+			book, _ := bookRepo.FindByID(bookID)
+			Expect(book.RatingCount).Should(Equal(0))
+			Expect(book.Rating).Should(Equal(float64(0)))
+		})
+		It("lets the owner delete the own review", func() {
+			newBookReview, _ := domain.NewBookReview("f73cbfc4-1971-49d6-8964-d696b4e2e220", "abc", 1, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+			bookRepo.SetRating(bookID, newBookReview.Rating, 1)
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			req, err := http.NewRequest(http.MethodDelete, server.URL+"/book/reviews/"+newBookReview.ID, nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+
+			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
+			Expect(bookReview).Should(BeNil())
+		})
+		It("lets moderators delete other users reviews", func() {
+			newBookReview, _ := domain.NewBookReview("f73cbfc4-1971-49d6-8964-d696b4e2e220", "abc", 1, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+			bookRepo.SetRating(bookID, newBookReview.Rating, 1)
+
+			moderatorID := "55a5cd53-6d6d-46f1-9eb0-689435c269f0"
+			generateAuth, err := jwt.CreateToken(moderatorID, "moderator", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(moderatorID, generateAuth)
+			req, err := http.NewRequest(http.MethodDelete, server.URL+"/book/reviews/"+newBookReview.ID, nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+
+			bookReview, _ := bookReviewRepo.FindByID(newBookReview.ID)
+			Expect(bookReview).Should(BeNil())
 		})
 		It("return an 404 status code in non existing bookReview", func() {
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodDelete,
@@ -325,13 +821,15 @@ var _ = Describe("Server", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
 		})
-		It("return an 401 status code in not admin user", func() {
+		It("return an 403 status code in not owner nor moderator user", func() {
 			bookReviewID := "f73cbfc4-1971-49d6-8964-d696b4e2e220"
 			newBookReview, _ := domain.NewBookReview(bookReviewID, "abc", 1, bookID, userID)
 			bookReviewRepo.Save(newBookReview)
 
-			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			otherID := "55a5cd53-6d6d-46f1-9eb0-689435c269f0"
+			generateAuth, err := jwt.CreateToken(otherID, "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(otherID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodDelete,
@@ -342,6 +840,66 @@ var _ = Describe("Server", func() {
 			client := &http.Client{}
 			resp, err := client.Do(req)
 			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
+		})
+	})
+	Context("When PUT and DELETE requests are sent to /book/reviews/:review_id/helpful", func() {
+		const reviewID = "f73cbfc4-1971-49d6-8964-d696b4e2e220"
+		const voterID = "55a5cd53-6d6d-46f1-9eb0-689435c269f0"
+
+		vote := func(method, as string) int {
+			generateAuth, err := jwt.CreateToken(as, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(as, generateAuth)
+			req, err := http.NewRequest(method, server.URL+"/book/reviews/"+reviewID+"/helpful", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp.StatusCode
+		}
+
+		helpful := func() int {
+			review, err := bookReviewRepo.FindByID(reviewID)
+			Expect(err).ShouldNot(HaveOccurred())
+			return review.Helpful
+		}
+
+		BeforeEach(func() {
+			newBookReview, _ := domain.NewBookReview(reviewID, "abc", 4, bookID, userID)
+			bookReviewRepo.Save(newBookReview)
+		})
+		It("Counts one vote per user", func() {
+			Expect(vote(http.MethodPut, voterID)).Should(Equal(http.StatusOK))
+			Expect(vote(http.MethodPut, voterID)).Should(Equal(http.StatusBadRequest))
+			Expect(helpful()).Should(Equal(1))
+
+			resp, err := http.Get(server.URL + "/book/reviews/" + reviewID)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			review := &struct {
+				Data struct {
+					Helpful int `json:"helpful"`
+				} `json:"data"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(review)).Should(Succeed())
+			Expect(review.Data.Helpful).Should(Equal(1))
+		})
+		It("Undoes a vote", func() {
+			Expect(vote(http.MethodDelete, voterID)).Should(Equal(http.StatusNotFound))
+			vote(http.MethodPut, voterID)
+			Expect(vote(http.MethodDelete, voterID)).Should(Equal(http.StatusOK))
+			Expect(helpful()).Should(Equal(0))
+		})
+		It("Does not count votes on the own review", func() {
+			Expect(vote(http.MethodPut, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(helpful()).Should(Equal(0))
+		})
+		It("Returns an 401 status code without token", func() {
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/book/reviews/"+reviewID+"/helpful", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
 			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
 		})
 	})
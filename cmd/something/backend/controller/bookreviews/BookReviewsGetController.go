@@ -1,11 +1,16 @@
 package bookreviews
 
 import (
+	"errors"
 	"net/http"
 	"something/internal/bookreviews/application"
 	"something/internal/bookreviews/application/find"
+	"something/internal/bookreviews/domain"
 	bookFind "something/internal/books/application/find"
+	commentFind "something/internal/comments/application/find"
 	userFind "something/internal/users/application/find"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +25,7 @@ func GetBookReviewsController(
 	finder find.Service,
 	bookFinder bookFind.Service,
 	userFinder userFind.Service,
+	commentFinder commentFind.Service,
 ) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var param bookURLParameter
@@ -42,7 +48,16 @@ func GetBookReviewsController(
 			return
 		}
 
-		bookReviews, err := finder.FindBookReviews(param.ID)
+		criteria, err := getReviewListCriteria(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		criteria.BookID = param.ID
+
+		bookReviews, err := finder.FindBookReviews(criteria)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Something wrong happened, try again later ...",
@@ -50,6 +65,7 @@ func GetBookReviewsController(
 			return
 		}
 		getUserInfoReview(bookReviews, userFinder)
+		addCommentCounts(bookReviews, commentFinder)
 		c.JSON(http.StatusOK, gin.H{
 			"data": bookReviews,
 		})
@@ -57,6 +73,82 @@ func GetBookReviewsController(
 	}
 }
 
+// getReviewListCriteria reads the paging, sort and filter query parameters
+// shared by the book and user review lists. Dates are either RFC 3339 or
+// plain days, a plain "to" day includes the whole day
+func getReviewListCriteria(c *gin.Context) (*find.ReviewListCriteria, error) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	perPage, _ := strconv.Atoi(c.Query("per_page"))
+
+	sort := c.Query("sort")
+	if sort != "" && sort != domain.SortRecent && sort != domain.SortHelpful && sort != domain.SortRating {
+		return nil, errors.New("sort must be one of helpful, recent or rating")
+	}
+
+	minRating, err := parseRating(c.Query("min_rating"))
+	if err != nil {
+		return nil, errors.New("min_rating must be a number between 0.5 and 5")
+	}
+	maxRating, err := parseRating(c.Query("max_rating"))
+	if err != nil {
+		return nil, errors.New("max_rating must be a number between 0.5 and 5")
+	}
+	if minRating > 0 && maxRating > 0 && minRating > maxRating {
+		return nil, errors.New("min_rating can't be greater than max_rating")
+	}
+
+	from, err := parseDate(c.Query("from"), false)
+	if err != nil {
+		return nil, errors.New("from must be a date (2006-01-02) or a RFC 3339 time")
+	}
+	to, err := parseDate(c.Query("to"), true)
+	if err != nil {
+		return nil, errors.New("to must be a date (2006-01-02) or a RFC 3339 time")
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, errors.New("from can't be after to")
+	}
+
+	return &find.ReviewListCriteria{
+		MinRating: minRating,
+		MaxRating: maxRating,
+		From:      from,
+		To:        to,
+		Sort:      sort,
+		Page:      page,
+		PerPage:   perPage,
+	}, nil
+}
+
+func parseRating(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rating, err := strconv.ParseFloat(value, 64)
+	if err != nil || rating < 0.5 || rating > 5 {
+		return 0, errors.New("invalid rating")
+	}
+	return rating, nil
+}
+
+func parseDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		date = date.UTC()
+		return &date, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &date, nil
+}
+
 func getUserInfoReview(reviews []*application.BookReviewResponse, userFinder userFind.Service) []*application.BookReviewResponse {
 	for _, review := range reviews {
 		user, err := userFinder.FindUserByID(review.User.ID)
@@ -67,3 +159,17 @@ func getUserInfoReview(reviews []*application.BookReviewResponse, userFinder use
 	}
 	return reviews
 }
+
+func addCommentCounts(reviews []*application.BookReviewResponse, commentFinder commentFind.Service) {
+	reviewIDs := []string{}
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+	counts, err := commentFinder.CountComments(reviewIDs)
+	if err != nil {
+		return
+	}
+	for _, review := range reviews {
+		review.Comments = counts[review.ID]
+	}
+}
@@ -0,0 +1,67 @@
+package bookreviews
+
+import (
+	"net/http"
+	"something/internal/bookreviews/application/find"
+	commentFind "something/internal/comments/application/find"
+	userFind "something/internal/users/application/find"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userURLParameter ...
+type userURLParameter struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// GetUserReviewsController ...
+func GetUserReviewsController(
+	finder find.Service,
+	userFinder userFind.Service,
+	commentFinder commentFind.Service,
+) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param userURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		_, err := userFinder.FindUserByID(param.ID)
+		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		criteria, err := getReviewListCriteria(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		criteria.UserID = param.ID
+
+		bookReviews, err := finder.FindBookReviews(criteria)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		getUserInfoReview(bookReviews, userFinder)
+		addCommentCounts(bookReviews, commentFinder)
+		c.JSON(http.StatusOK, gin.H{
+			"data": bookReviews,
+		})
+		return
+	}
+}
@@ -0,0 +1,40 @@
+package bookreviews
+
+import (
+	"net/http"
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/bookreviews/application/find"
+	userDomain "something/internal/users/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeReview lets the owner of the review go on, and the moderators too
+// when moderated is set, writing the error response when the request can't
+// go on. Moderators may remove reviews but never write in someone's name.
+func authorizeReview(c *gin.Context, finder find.Service, reviewID string, moderated bool) bool {
+	bookReview, err := finder.FindBookReviewByID(reviewID)
+	if err != nil {
+		if err.Error() == "book review not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Something wrong happened, try again later ...",
+		})
+		return false
+	}
+	allowed := m.IsOwner(c, bookReview.User.ID)
+	if moderated {
+		allowed = m.IsOwnerOrHasPermission(c, bookReview.User.ID, userDomain.PermissionReviewsModerate)
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+		})
+		return false
+	}
+	return true
+}
@@ -6,8 +6,11 @@ import (
 	"something/internal/bookreviews/application/delete"
 	"something/internal/bookreviews/application/find"
 	"something/internal/bookreviews/application/update"
+	"something/internal/bookreviews/application/vote"
 	bookFind "something/internal/books/application/find"
+	commentFind "something/internal/comments/application/find"
 	userFind "something/internal/users/application/find"
+	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,13 +20,18 @@ func RegisterRoutes(
 	finder find.Service,
 	bookFinder bookFind.Service,
 	userFinder userFind.Service,
+	commentFinder commentFind.Service,
 	creator create.Service,
 	updater update.Service,
 	delete delete.Service,
-	accessSecret string, router *gin.Engine) {
-	router.GET("/books/:id/reviews", GetBookReviewsController(finder, bookFinder, userFinder))
-	router.GET("/book/reviews/:review_id", GetBookReviewController(finder))
-	router.PATCH("/book/reviews/:review_id", m.TokenAuthMiddleware(accessSecret), PatchController(updater))
-	router.PUT("/books/:id/reviews/:review_id", m.TokenAuthMiddleware(accessSecret), PutController(creator))
-	router.DELETE("/book/reviews/:review_id", m.TokenAuthStaffMiddleware(accessSecret), DeleteBookReviewController(delete))
+	voter vote.Service,
+	accessKeys jwt.KeySet, auth jwt.AuthRepository, router *gin.Engine) {
+	router.GET("/books/:id/reviews", GetBookReviewsController(finder, bookFinder, userFinder, commentFinder))
+	router.GET("/users/:id/reviews", GetUserReviewsController(finder, userFinder, commentFinder))
+	router.GET("/book/reviews/:review_id", GetBookReviewController(finder, commentFinder))
+	router.PATCH("/book/reviews/:review_id", m.TokenAuthMiddleware(accessKeys, auth), PatchController(finder, updater))
+	router.PUT("/books/:id/reviews/:review_id", m.TokenAuthMiddleware(accessKeys, auth), m.VerifiedUserMiddleware(userFinder), PutController(creator))
+	router.DELETE("/book/reviews/:review_id", m.TokenAuthMiddleware(accessKeys, auth), DeleteBookReviewController(finder, delete))
+	router.PUT("/book/reviews/:review_id/helpful", m.TokenAuthMiddleware(accessKeys, auth), VoteController(voter))
+	router.DELETE("/book/reviews/:review_id/helpful", m.TokenAuthMiddleware(accessKeys, auth), UnvoteController(voter))
 }
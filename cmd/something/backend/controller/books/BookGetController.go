@@ -2,9 +2,10 @@ package books
 
 import (
 	"net/http"
+	bookReview "something/internal/bookreviews/application"
 	bookReviewFinder "something/internal/bookreviews/application/find"
+	"something/internal/books/application"
 	"something/internal/books/application/find"
-	"something/internal/helpers"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +14,12 @@ type urlParameter struct {
 	ID string `uri:"id" binding:"required,uuid"`
 }
 
+// bookDetailResponse is a book with the distribution of its ratings
+type bookDetailResponse struct {
+	*application.BookResponse
+	Ratings *bookReview.RatingStatsResponse `json:"ratings"`
+}
+
 // GetBookController ...
 func GetBookController(finder find.Service, reviewFinder bookReviewFinder.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
@@ -34,12 +41,15 @@ func GetBookController(finder find.Service, reviewFinder bookReviewFinder.Servic
 			})
 			return
 		}
-		bookReviews, err := reviewFinder.FindBookReviews(book.ID)
-		if err == nil {
-			book.Rating = helpers.GetBookRating(bookReviews)
+		ratings, err := reviewFinder.FindRatingStats(book.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
 		}
 		c.JSON(http.StatusOK, gin.H{
-			"data": book,
+			"data": &bookDetailResponse{BookResponse: book, Ratings: ratings},
 		})
 		return
 	}
@@ -2,22 +2,31 @@ package books
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"something/config"
+	"net/url"
+	m "something/cmd/something/backend/controller/middlewares"
+	bookReviewCascade "something/internal/bookreviews/application/cascade"
+	bookReviewDelete "something/internal/bookreviews/application/delete"
 	bookReviewFinder "something/internal/bookreviews/application/find"
+	"something/internal/bookreviews/application/ranking"
+	bookReviewRating "something/internal/bookreviews/application/rating"
 	bookReviewDomain "something/internal/bookreviews/domain"
 	bookReviewPersistence "something/internal/bookreviews/infraestructure/persistence"
 	"something/internal/books/application/create"
 	"something/internal/books/application/delete"
 	"something/internal/books/application/find"
+	"something/internal/books/application/search"
 	"something/internal/books/application/update"
 	"something/internal/books/domain"
 	"something/internal/books/infraestructure/persistence"
+	userCascade "something/internal/users/application/cascade"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
+	"something/pkg/ratelimit"
 	jwt "something/pkg/redisjwt"
 	"strconv"
 	"testing"
@@ -29,25 +38,52 @@ import (
 )
 
 var tokenParams *jwt.TokenParams = &jwt.TokenParams{
-	AccessSecret:  "secure-access-token",
-	RefreshSecret: "secure-refresh-token",
-	AccessTime:    time.Minute * 1,
-	RefreshTime:   time.Minute * 1,
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
 }
 
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
 const userID = "c6facd8d-17f4-43bd-9d90-f4fb024fa2f9"
 
-func setupServer(bookRepo domain.BookRepository, bookReviewRepo bookReviewDomain.BookReviewRepository) *gin.Engine {
+var bus eventbus.Bus
+
+func setupServer(
+	bookRepo domain.BookRepository,
+	bookReviewRepo bookReviewDomain.BookReviewRepository,
+	userRepo userDomain.UserRepository,
+	middlewares ...gin.HandlerFunc,
+) *gin.Engine {
 	router := gin.Default()
+	router.Use(middlewares...)
+	bus = eventbus.NewInMemoryBus()
 	finder := find.NewService(bookRepo)
+	searcher := search.NewService(bookRepo)
 	reviewFinder := bookReviewFinder.NewService(bookReviewRepo)
-	creator := create.NewService(bookRepo)
-	updater := update.NewService(bookRepo)
-	deletor := delete.NewService(bookRepo)
-	RegisterRoutes(finder, reviewFinder, creator, updater, deletor, tokenParams.AccessSecret, router)
+	ranker := ranking.NewService(bookReviewRepo, bookRepo, ranking.DefaultPrior)
+	creator := create.NewService(bookRepo, bus)
+	updater := update.NewService(bookRepo, bus)
+	deletor := delete.NewService(bookRepo, bus)
+	bookReviewCascade.Subscribe(bus, bookReviewRepo, bookReviewDelete.NewService(
+		bookReviewRepo, bookReviewRating.NewService(bookReviewRepo, bookRepo), bus))
+	userCascade.Subscribe(bus, userRepo)
+	RegisterRoutes(finder, searcher, reviewFinder, ranker, creator, updater, deletor, tokenParams.AccessKeys, auth, router)
 	return router
 }
 
+// stemmedRepository returns the matches of a text index that stems the
+// words, which the search service can't match itself
+type stemmedRepository struct {
+	domain.BookRepository
+	matches []*domain.BookMatch
+}
+
+func (r *stemmedRepository) Search(*domain.BookSearchCriteria) ([]*domain.BookMatch, error) {
+	return r.matches, nil
+}
+
 func TestBookCheck(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Book Suite")
@@ -57,25 +93,16 @@ var _ = Describe("Server", func() {
 	var server *httptest.Server
 	var bookRepo domain.BookRepository
 	var bookReviewRepo bookReviewDomain.BookReviewRepository
-
-	dbHost := os.Getenv("DB_HOST")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	client := config.ConnectBD(dbUser, dbPass, dbHost)
-
-	database := os.Getenv("TEST_DB_NAME")
-	dbClient := client.Database(database)
+	var userRepo userDomain.UserRepository
 
 	BeforeEach(func() {
-		bookRepo = persistence.NewMongoBookRepository(dbClient)
-		bookReviewRepo = bookReviewPersistence.NewMongoBookReviewRepository(dbClient)
-		server = httptest.NewServer(setupServer(bookRepo, bookReviewRepo))
+		bookRepo = persistence.NewInMemoryBookRepository()
+		bookReviewRepo = bookReviewPersistence.NewInMemoryBookReviewsRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		server = httptest.NewServer(setupServer(bookRepo, bookReviewRepo, userRepo))
 	})
 
 	AfterEach(func() {
-		if err := dbClient.Collection("books").Drop(context.TODO()); err != nil {
-			Expect(err).ShouldNot(HaveOccurred())
-		}
 		server.Close()
 	})
 
@@ -115,12 +142,220 @@ var _ = Describe("Server", func() {
 								"genre":"` + newBook.Genre + `",
 								"pages":` + strconv.Itoa(newBook.Pages) + ` ,
 								"rating": 0,
-								"created_on":"` + newBook.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
+								"total_reviews": 0,
+								"created_on":"` + newBook.CreatedOn.Format(time.RFC3339Nano) + `"
 							}
 						]
 				}`))
 		})
 	})
+	Context("When GET request with rating filters is sent to /books", func() {
+		It("Returns books with the minimum rating sorted by rating", func() {
+			lowBook, _ := domain.NewBook("a3f2d7c1-5b8e-4e0f-9c6d-2b1a7e8f9d04", "low", "desc", "author", "genre", 1)
+			lowBook.AddRating(2, 1)
+			bookRepo.Save(lowBook)
+			goodBook, _ := domain.NewBook("b7e4c2a9-1d3f-4a6b-8e5c-9f0d2c4b6a18", "good", "desc", "author", "genre", 1)
+			goodBook.AddRating(7, 2)
+			bookRepo.Save(goodBook)
+			bestBook, _ := domain.NewBook("c9d1e3f5-7a2b-4c4d-9e6f-1a3b5c7d9e20", "best", "desc", "author", "genre", 1)
+			bestBook.AddRating(5, 1)
+			bookRepo.Save(bestBook)
+
+			resp, err := http.Get(server.URL + "/books?min_rating=3&sort=-rating")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var response struct {
+				Data []struct {
+					ID           string  `json:"id"`
+					Rating       float64 `json:"rating"`
+					TotalReviews int     `json:"total_reviews"`
+				} `json:"data"`
+			}
+			Expect(json.Unmarshal(body, &response)).To(Succeed())
+			Expect(response.Data).Should(HaveLen(2))
+			Expect(response.Data[0].ID).Should(Equal(bestBook.ID))
+			Expect(response.Data[1].ID).Should(Equal(goodBook.ID))
+			Expect(response.Data[1].Rating).Should(Equal(3.5))
+			Expect(response.Data[1].TotalReviews).Should(Equal(2))
+		})
+	})
+	Context("When GET request with rating is sent to /books", func() {
+		const popularID = "2f4a6c8e-0b1d-4e3f-9a5b-7c9d1e3f5a7b"
+		const singleID = "8e6c4a2f-0d1b-4f3e-8a5c-6b7d9e1f3a5c"
+		const classicID = "5a7c9e1f-3b5d-4f7a-9c1e-3f5a7c9e1b3d"
+
+		rank := func(query string) []string {
+			resp, err := http.Get(server.URL + "/books?" + query)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+			ranking := &struct {
+				Data []struct {
+					BookID string `json:"book_id"`
+				} `json:"data"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(ranking)).Should(Succeed())
+			ids := []string{}
+			for _, entry := range ranking.Data {
+				ids = append(ids, entry.BookID)
+			}
+			return ids
+		}
+
+		BeforeEach(func() {
+			for _, book := range []struct{ id, genre string }{
+				{popularID, "fantasy"}, {singleID, "fantasy"}, {classicID, "classics"},
+			} {
+				newBook, _ := domain.NewBook(book.id, "title", "desc", "author", book.genre, 1)
+				bookRepo.Save(newBook)
+			}
+			saveReview := func(id string, bookID string, rating float64, createdOn time.Time) {
+				newReview, _ := bookReviewDomain.NewBookReview(id, "abc", rating, bookID, "user-"+id)
+				newReview.CreatedOn = createdOn
+				bookReviewRepo.Save(newReview)
+			}
+			now := time.Now().UTC()
+			for i := 0; i < 20; i++ {
+				saveReview("popular-"+strconv.Itoa(i), popularID, 4.5, now.AddDate(0, -2, 0))
+			}
+			saveReview("single", singleID, 5, now)
+			for i := 0; i < 3; i++ {
+				saveReview("classic-"+strconv.Itoa(i), classicID, 4, now)
+			}
+		})
+		It("Ranks many good reviews above a single perfect one", func() {
+			Expect(rank("rating=-1")).Should(Equal([]string{popularID, classicID, singleID}))
+		})
+		It("Ranks the worst books first", func() {
+			Expect(rank("rating=1")).Should(Equal([]string{singleID, classicID, popularID}))
+		})
+		It("Returns the requested page", func() {
+			Expect(rank("rating=-1&per_page=2&page=2")).Should(Equal([]string{singleID}))
+		})
+		It("Filters by genre", func() {
+			Expect(rank("rating=-1&genre=fantasy")).Should(Equal([]string{popularID, singleID}))
+			Expect(rank("rating=-1&genre=poetry")).Should(BeEmpty())
+		})
+		It("Counts only the reviews of the period", func() {
+			Expect(rank("rating=-1&period=month")).Should(Equal([]string{classicID, singleID}))
+		})
+		It("Returns the mean, score and total of every book", func() {
+			resp, err := http.Get(server.URL + "/books?rating=-1&per_page=1")
+			Expect(err).ShouldNot(HaveOccurred())
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"data":[{
+				"book_id":"` + popularID + `",
+				"title":"title",
+				"author":"author",
+				"rating":4.5,
+				"score":4,
+				"total":20
+			}]}`))
+		})
+		It("Returns an 400 status code with an unknown period", func() {
+			resp, err := http.Get(server.URL + "/books?rating=-1&period=decade")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+		})
+	})
+	Context("When GET request with a search query is sent to /books", func() {
+		type searchResponse struct {
+			Data []struct {
+				ID         string            `json:"id"`
+				Title      string            `json:"title"`
+				Score      float64           `json:"score"`
+				Highlights map[string]string `json:"highlights"`
+			} `json:"data"`
+		}
+		searchBooks := func(query string) *searchResponse {
+			resp, err := http.Get(server.URL + "/books?q=" + url.QueryEscape(query))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			response := &searchResponse{}
+			Expect(json.Unmarshal(body, response)).To(Succeed())
+			return response
+		}
+
+		BeforeEach(func() {
+			hobbit, _ := domain.NewBook("a3f2d7c1-5b8e-4e0f-9c6d-2b1a7e8f9d04", "The Hobbit",
+				"Bilbo Baggins joins a company of dwarves", "J. R. R. Tolkien", "fantasy", 310)
+			bookRepo.Save(hobbit)
+			silmarillion, _ := domain.NewBook("b7e4c2a9-1d3f-4a6b-8e5c-9f0d2c4b6a18", "The Silmarillion",
+				"Myths of the elder days, before the hobbit stories", "J. R. R. Tolkien", "fantasy", 365)
+			bookRepo.Save(silmarillion)
+			dune, _ := domain.NewBook("c9d1e3f5-7a2b-4c4d-9e6f-1a3b5c7d9e20", "Dune",
+				"Politics and <b>spice</b> on Arrakis", "Frank Herbert", "science fiction", 412)
+			bookRepo.Save(dune)
+		})
+
+		It("Ranks title matches above description matches", func() {
+			response := searchBooks("hobbit")
+			Expect(response.Data).Should(HaveLen(2))
+			Expect(response.Data[0].ID).Should(Equal("a3f2d7c1-5b8e-4e0f-9c6d-2b1a7e8f9d04"))
+			Expect(response.Data[0].Highlights["title"]).Should(Equal("The <em>Hobbit</em>"))
+			Expect(response.Data[1].ID).Should(Equal("b7e4c2a9-1d3f-4a6b-8e5c-9f0d2c4b6a18"))
+			Expect(response.Data[1].Highlights["description"]).Should(ContainSubstring("the <em>hobbit</em> stories"))
+			Expect(response.Data[0].Score).Should(BeNumerically(">", response.Data[1].Score))
+		})
+		It("Tolerates typos", func() {
+			response := searchBooks("tolkein silmarilion")
+			Expect(response.Data).Should(HaveLen(2))
+			Expect(response.Data[0].ID).Should(Equal("b7e4c2a9-1d3f-4a6b-8e5c-9f0d2c4b6a18"))
+			Expect(response.Data[0].Highlights["author"]).Should(Equal("J. R. R. <em>Tolkien</em>"))
+		})
+		It("Escapes the matched fragments", func() {
+			response := searchBooks("spice")
+			Expect(response.Data).Should(HaveLen(1))
+			Expect(response.Data[0].Highlights["description"]).Should(
+				Equal("Politics and &lt;b&gt;<em>spice</em>&lt;/b&gt; on Arrakis"))
+		})
+		It("Adds the typo matches to the text matches", func() {
+			response := searchBooks("hobbit arakis")
+			Expect(response.Data).Should(HaveLen(3))
+			Expect(response.Data[2].ID).Should(Equal("c9d1e3f5-7a2b-4c4d-9e6f-1a3b5c7d9e20"))
+			Expect(response.Data[2].Title).Should(Equal("Dune"))
+		})
+		It("Keeps the most relevant candidates within the limit", func() {
+			books, err := bookRepo.Search(domain.NewBookSearchCriteria([]string{"elder", "hobbit"}, "", "", 0, 1))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(books).Should(HaveLen(1))
+			Expect(books[0].ID).Should(Equal("b7e4c2a9-1d3f-4a6b-8e5c-9f0d2c4b6a18"))
+		})
+		It("Keeps the repository matches the service can't score", func() {
+			study, _ := domain.NewBook("d2f4a6c8-3e5b-4d7f-a1c3-5e7f9b1d3f52", "A Study in Scarlet",
+				"The first Sherlock Holmes novel", "Arthur Conan Doyle", "mystery", 180)
+			unscored, _ := domain.NewBook("e3a5b7d9-4f6c-4e8a-b2d4-6f8a0c2e4a63", "Walking",
+				"A long walk", "Bill Bryson", "travel", 270)
+			searcher := search.NewService(&stemmedRepository{matches: []*domain.BookMatch{
+				{Book: *study, Score: 0.75},
+				{Book: *unscored},
+			}})
+
+			results, err := searcher.SearchBooks(&search.Criteria{Query: "studies"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(results).Should(HaveLen(1))
+			Expect(results[0].ID).Should(Equal(study.ID))
+			Expect(results[0].Score).Should(Equal(0.75))
+			Expect(results[0].Highlights).Should(BeEmpty())
+		})
+		It("Does not interpret the query as a pattern", func() {
+			Expect(searchBooks(".*").Data).Should(BeEmpty())
+			Expect(searchBooks("(").Data).Should(BeEmpty())
+			Expect(searchBooks("dune(").Data).Should(HaveLen(1))
+		})
+	})
 	Context("When GET request by ID is sent to /books/:id", func() {
 		It("Returns an existing book by id", func() {
 			newBook, _ := domain.NewBook("90cbf21e-f1db-473d-b7b2-6ad77a4ea359", "title", "desc", "author", "genre", 1)
@@ -145,11 +380,74 @@ var _ = Describe("Server", func() {
 							"genre":"` + newBook.Genre + `",
 							"pages":` + strconv.Itoa(newBook.Pages) + ` ,
 							"rating": 0,
-							"created_on":"` + newBook.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
+							"total_reviews": 0,
+							"created_on":"` + newBook.CreatedOn.Format(time.RFC3339Nano) + `",
+							"ratings": {
+								"total": 0,
+								"mean": 0,
+								"median": 0,
+								"histogram": [
+									{"rating": 0.5, "count": 0}, {"rating": 1, "count": 0},
+									{"rating": 1.5, "count": 0}, {"rating": 2, "count": 0},
+									{"rating": 2.5, "count": 0}, {"rating": 3, "count": 0},
+									{"rating": 3.5, "count": 0}, {"rating": 4, "count": 0},
+									{"rating": 4.5, "count": 0}, {"rating": 5, "count": 0}
+								]
+							}
 						}
 				}`))
 		})
 
+		It("Returns the rating distribution of the book", func() {
+			newBook, _ := domain.NewBook("90cbf21e-f1db-473d-b7b2-6ad77a4ea359", "title", "desc", "author", "genre", 1)
+			bookRepo.Save(newBook)
+			for i, rating := range []float64{5, 4, 4, 2.5, 1} {
+				review, _ := bookReviewDomain.NewBookReview(
+					strconv.Itoa(i), "abc", rating, newBook.ID, "user-"+strconv.Itoa(i))
+				bookReviewRepo.Save(review)
+			}
+
+			resp, err := http.Get(server.URL + "/books/" + newBook.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+
+			book := &struct {
+				Data struct {
+					Ratings struct {
+						Total     int     `json:"total"`
+						Mean      float64 `json:"mean"`
+						Median    float64 `json:"median"`
+						Histogram []struct {
+							Rating float64 `json:"rating"`
+							Count  int     `json:"count"`
+						} `json:"histogram"`
+					} `json:"ratings"`
+				} `json:"data"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(book)).Should(Succeed())
+			ratings := book.Data.Ratings
+			Expect(ratings.Total).Should(Equal(5))
+			Expect(ratings.Mean).Should(Equal(3.3))
+			Expect(ratings.Median).Should(Equal(float64(4)))
+			Expect(ratings.Histogram).Should(HaveLen(10))
+			counts := map[float64]int{}
+			for _, bucket := range ratings.Histogram {
+				counts[bucket.Rating] = bucket.Count
+			}
+			Expect(counts).Should(Equal(map[float64]int{
+				0.5: 0, 1: 1, 1.5: 0, 2: 0, 2.5: 1, 3: 0, 3.5: 0, 4: 2, 4.5: 0, 5: 1,
+			}))
+
+			review, _ := bookReviewDomain.NewBookReview("5", "abc", 3, newBook.ID, "user-5")
+			bookReviewRepo.Save(review)
+			resp, err = http.Get(server.URL + "/books/" + newBook.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(json.NewDecoder(resp.Body).Decode(book)).Should(Succeed())
+			Expect(book.Data.Ratings.Median).Should(Equal(3.5))
+		})
+
 		It("Returns an 404 status code in non existing id", func() {
 			resp, err := http.Get(server.URL + "/books/c0b369a0-8de4-417d-a905-c33644c2907d")
 			Expect(err).ShouldNot(HaveOccurred())
@@ -175,6 +473,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPut,
 				server.URL+"/books/"+bookID,
@@ -193,6 +492,7 @@ var _ = Describe("Server", func() {
 		It("Returns an 400 status code with an invalid uuid", func() {
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 			req, err := http.NewRequest(http.MethodPut, server.URL+"/books/1", nil)
 			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
 			client := &http.Client{}
@@ -214,6 +514,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodPatch,
@@ -249,6 +550,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(http.MethodDelete, server.URL+"/books/567fb602-5533-42a3-8b47-68b474b53e45", nil)
 			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
@@ -260,9 +562,51 @@ var _ = Describe("Server", func() {
 			book, _ := bookRepo.FindByID(newBook.ID)
 			Expect(book).Should(BeNil())
 		})
+		It("delete the book reviews and interests", func() {
+			newBook, _ := domain.NewBook("567fb602-5533-42a3-8b47-68b474b53e45", "title", "desc", "author", "genre", 1)
+			bookRepo.Save(newBook)
+			otherBook, _ := domain.NewBook("2a1c3a0e-8f2b-4a8e-9a43-3a5b0c7a1f11", "title", "desc", "author", "genre", 1)
+			bookRepo.Save(otherBook)
+			review, _ := bookReviewDomain.NewBookReview("1", "abc", 4, newBook.ID, userID)
+			bookReviewRepo.Save(review)
+			secondReview, _ := bookReviewDomain.NewBookReview("3", "abc", 2, newBook.ID, "8e2b4c6d-1a3f-4e5b-9c7d-0f2e4a6b8c1d")
+			bookReviewRepo.Save(secondReview)
+			otherReview, _ := bookReviewDomain.NewBookReview("2", "abc", 4, otherBook.ID, userID)
+			bookReviewRepo.Save(otherReview)
+			var deleted []string
+			bus.Subscribe(bookReviewDomain.ReviewDeletedEvent, func(event eventbus.Event) error {
+				deleted = append(deleted, event.(*bookReviewDomain.ReviewDeleted).ReviewID)
+				return nil
+			})
+			user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			userRepo.UpdateInterests(userID, newBook.ID, "reading")
+			userRepo.UpdateInterests(userID, otherBook.ID, "done")
+
+			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			req, err := http.NewRequest(http.MethodDelete, server.URL+"/books/"+newBook.ID, nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+
+			reviews, _ := bookReviewRepo.Find(bookReviewDomain.NewReviewListCriteria(newBook.ID, ""))
+			Expect(reviews).Should(BeEmpty())
+			reviews, _ = bookReviewRepo.Find(bookReviewDomain.NewReviewListCriteria(otherBook.ID, ""))
+			Expect(reviews).Should(HaveLen(1))
+			Expect(deleted).Should(ConsistOf(review.ID, secondReview.ID))
+
+			user, _ = userRepo.FindByID(userID)
+			Expect(user.Interests).Should(Equal(map[string]string{otherBook.ID: "done"}))
+		})
 		It("return an 404 status code in non existing book", func() {
 			generateAuth, err := jwt.CreateToken(userID, "staff", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodDelete,
@@ -281,4 +625,55 @@ var _ = Describe("Server", func() {
 			Expect(string(body)).To(MatchJSON(`{"error":"book not found"}`))
 		})
 	})
+	Context("When the rate limit of /books runs out", func() {
+		BeforeEach(func() {
+			server.Close()
+			rules := []m.RateLimitRule{{
+				Name:     "books",
+				Prefixes: []string{"/books"},
+				Quota:    ratelimit.Quota{Limit: 2, Period: time.Minute},
+			}}
+			server = httptest.NewServer(setupServer(bookRepo, bookReviewRepo, userRepo,
+				m.RateLimitMiddleware(ratelimit.NewMemoryStore(), rules, tokenParams.AccessKeys)))
+		})
+		get := func(token string) *http.Response {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/books", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp
+		}
+
+		It("answers 429 with the RateLimit headers", func() {
+			resp := get("")
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			Expect(resp.Header.Get("RateLimit-Limit")).Should(Equal("2"))
+			Expect(resp.Header.Get("RateLimit-Remaining")).Should(Equal("1"))
+			Expect(resp.Header.Get("RateLimit-Reset")).Should(Equal("30"))
+
+			Expect(get("").Header.Get("RateLimit-Remaining")).Should(Equal("0"))
+
+			resp = get("")
+			Expect(resp.StatusCode).Should(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header.Get("Retry-After")).Should(Equal("30"))
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"rate limit exceeded"}`))
+		})
+		It("keeps a bucket per user for authenticated requests", func() {
+			get("")
+			get("")
+			Expect(get("").StatusCode).Should(Equal(http.StatusTooManyRequests))
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			resp := get(generateAuth.AccessToken)
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			Expect(resp.Header.Get("RateLimit-Remaining")).Should(Equal("1"))
+		})
+	})
 })
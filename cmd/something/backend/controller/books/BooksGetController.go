@@ -3,10 +3,9 @@ package books
 import (
 	"net/http"
 	bookReview "something/internal/bookreviews/application"
-	bookReviewFinder "something/internal/bookreviews/application/find"
-	"something/internal/books/application"
+	"something/internal/bookreviews/application/ranking"
 	"something/internal/books/application/find"
-	"something/internal/helpers"
+	"something/internal/books/application/search"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -18,15 +17,31 @@ const (
 )
 
 // GetBooksController ...
-func GetBooksController(finder find.Service, reviewFinder bookReviewFinder.Service) func(c *gin.Context) {
+func GetBooksController(
+	finder find.Service,
+	searcher search.Service,
+	ranker ranking.Service,
+) func(c *gin.Context) {
 	return func(c *gin.Context) {
 
 		criteria := getQueryParameters(c)
 
 		rating, _ := strconv.Atoi(c.Query("rating"))
 		if rating == ratingAsc || rating == ratingDesc {
-			bookRatings, err := reviewFinder.FindReviews(&bookReviewFinder.Criteria{Sort: rating})
+			bookRatings, err := ranker.RankBooks(&ranking.Criteria{
+				Sort:    rating,
+				Page:    criteria.Page,
+				PerPage: criteria.PerPage,
+				Genre:   criteria.Genre,
+				Period:  c.Query("period"),
+			})
 			if err != nil {
+				if err.Error() == "period must be one of week, month, year or all" {
+					c.JSON(http.StatusBadRequest, gin.H{
+						"error": err.Error(),
+					})
+					return
+				}
 				c.JSON(http.StatusInternalServerError, gin.H{
 					"error": "Something wrong happened, try again later ...",
 				})
@@ -39,6 +54,27 @@ func GetBooksController(finder find.Service, reviewFinder bookReviewFinder.Servi
 			return
 		}
 
+		if criteria.Query != "" {
+			books, err := searcher.SearchBooks(&search.Criteria{
+				Query:     criteria.Query,
+				Page:      criteria.Page,
+				PerPage:   criteria.PerPage,
+				Genre:     criteria.Genre,
+				Author:    criteria.Author,
+				MinRating: criteria.MinRating,
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"data": books,
+			})
+			return
+		}
+
 		books, err := finder.FindBooks(criteria)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -46,7 +82,6 @@ func GetBooksController(finder find.Service, reviewFinder bookReviewFinder.Servi
 			})
 			return
 		}
-		addRatingToBooks(books, reviewFinder)
 		c.JSON(http.StatusOK, gin.H{
 			"data": books,
 		})
@@ -57,23 +92,24 @@ func GetBooksController(finder find.Service, reviewFinder bookReviewFinder.Servi
 func getQueryParameters(c *gin.Context) *find.Criteria {
 	page, _ := strconv.Atoi(c.Query("page"))
 	perPage, _ := strconv.Atoi(c.Query("per_page"))
+	minRating, _ := strconv.ParseFloat(c.Query("min_rating"), 64)
 
-	return &find.Criteria{
-		Page:    page,
-		PerPage: perPage,
-		Query:   c.Query("q"),
-		Genre:   c.Query("genre"),
-		Author:  c.Query("author"),
+	sort := 0
+	switch c.Query("sort") {
+	case "rating":
+		sort = ratingAsc
+	case "-rating":
+		sort = ratingDesc
 	}
-}
 
-// TODO Refactor
-func addRatingToBooks(books []*application.BookResponse, reviewFinder bookReviewFinder.Service) {
-	for _, book := range books {
-		bookReviews, err := reviewFinder.FindBookReviews(book.ID)
-		if err == nil {
-			book.Rating = helpers.GetBookRating(bookReviews)
-		}
+	return &find.Criteria{
+		Page:      page,
+		PerPage:   perPage,
+		Query:     c.Query("q"),
+		Genre:     c.Query("genre"),
+		Author:    c.Query("author"),
+		MinRating: minRating,
+		Sort:      sort,
 	}
 }
 
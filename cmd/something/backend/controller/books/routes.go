@@ -2,12 +2,16 @@ package books
 
 import (
 	bookReviewFinder "something/internal/bookreviews/application/find"
+	"something/internal/bookreviews/application/ranking"
 	"something/internal/books/application/create"
 	"something/internal/books/application/delete"
 	"something/internal/books/application/find"
+	"something/internal/books/application/search"
 	"something/internal/books/application/update"
 
 	m "something/cmd/something/backend/controller/middlewares"
+	userDomain "something/internal/users/domain"
+	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,18 +19,21 @@ import (
 // RegisterRoutes ...
 func RegisterRoutes(
 	finder find.Service,
+	searcher search.Service,
 	reviewFinder bookReviewFinder.Service,
+	ranker ranking.Service,
 	creator create.Service,
 	update update.Service,
 	deletor delete.Service,
-	accessSecret string,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
 	router *gin.Engine) {
 	booksRouter := router.Group("/books")
 	{
-		booksRouter.GET("", GetBooksController(finder, reviewFinder))
+		booksRouter.GET("", GetBooksController(finder, searcher, ranker))
 		booksRouter.GET("/:id", GetBookController(finder, reviewFinder))
-		booksRouter.PUT("/:id", m.TokenAuthStaffMiddleware(accessSecret), PutController(creator))
-		booksRouter.PATCH("/:id", m.TokenAuthStaffMiddleware(accessSecret), PatchController(update))
-		booksRouter.DELETE("/:id", m.TokenAuthStaffMiddleware(accessSecret), DeleteBookController(deletor))
+		booksRouter.PUT("/:id", m.TokenAuthPermissionMiddleware(userDomain.PermissionBooksWrite, accessKeys, auth), PutController(creator))
+		booksRouter.PATCH("/:id", m.TokenAuthPermissionMiddleware(userDomain.PermissionBooksWrite, accessKeys, auth), PatchController(update))
+		booksRouter.DELETE("/:id", m.TokenAuthPermissionMiddleware(userDomain.PermissionBooksWrite, accessKeys, auth), DeleteBookController(deletor))
 	}
 }
@@ -0,0 +1,43 @@
+package challenges
+
+import (
+	"net/http"
+	"something/internal/challenges/application/set"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteChallengeController ...
+func DeleteChallengeController(setter set.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlYearParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		err := setter.DeleteChallenge(userID.(string), param.Year)
+		if err != nil {
+			if err.Error() == "challenge not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
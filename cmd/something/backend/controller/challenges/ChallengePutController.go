@@ -0,0 +1,52 @@
+package challenges
+
+import (
+	"net/http"
+	"something/internal/challenges/application/set"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlYearParameter struct {
+	Year int `uri:"year" binding:"required"`
+}
+
+// PutChallengeController ...
+func PutChallengeController(setter set.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlYearParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request set.ChallengeCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.UserID = userID.(string)
+		request.Year = param.Year
+
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := setter.SetChallenge(&request); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusCreated)
+		return
+	}
+}
@@ -0,0 +1,264 @@
+package challenges
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookReviewPersistence "something/internal/bookreviews/infraestructure/persistence"
+	bookDomain "something/internal/books/domain"
+	bookPersistence "something/internal/books/infraestructure/persistence"
+	"something/internal/challenges/application"
+	"something/internal/challenges/application/set"
+	"something/internal/challenges/application/stats"
+	"something/internal/challenges/domain"
+	"something/internal/challenges/infraestructure/persistence"
+	readingLogDomain "something/internal/readinglog/domain"
+	readingLogPersistence "something/internal/readinglog/infraestructure/persistence"
+	shelfDomain "something/internal/shelves/domain"
+	shelfPersistence "something/internal/shelves/infraestructure/persistence"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const missingUserID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+const duneID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
+const messiahID = "4d1a5a2e-3c59-4f3b-9b57-0e2f7c6b8a11"
+const emmaID = "a3b6f2c1-7d8e-4f90-a1b2-c3d4e5f60718"
+
+func setupServer(
+	challengeRepo domain.ChallengeRepository,
+	readingLogRepo readingLogDomain.ReadingLogRepository,
+	userRepo userDomain.UserRepository,
+	bookRepo bookDomain.BookRepository,
+	bookReviewRepo bookReviewDomain.BookReviewRepository,
+	shelfRepo shelfDomain.ShelfRepository,
+) *gin.Engine {
+	router := gin.Default()
+	statsFinder := stats.NewService(challengeRepo, readingLogRepo, userRepo, bookRepo, bookReviewRepo, shelfRepo)
+	setter := set.NewService(challengeRepo)
+	RegisterRoutes(statsFinder, setter, tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+func TestChallengeCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Challenge Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var challengeRepo domain.ChallengeRepository
+	var readingLogRepo readingLogDomain.ReadingLogRepository
+	var userRepo userDomain.UserRepository
+	var bookReviewRepo bookReviewDomain.BookReviewRepository
+	var shelfRepo shelfDomain.ShelfRepository
+	var token string
+
+	getStats := func(id, query string) (int, *application.StatsResponse) {
+		resp, err := http.Get(server.URL + "/users/" + id + "/stats" + query)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		response := &struct {
+			Data *application.StatsResponse `json:"data"`
+		}{}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, response)
+		return resp.StatusCode, response.Data
+	}
+
+	sendChallenge := func(method, year, payload string) int {
+		req, err := http.NewRequest(method, server.URL+"/user/challenges/"+year, bytes.NewBufferString(payload))
+		Expect(err).ShouldNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	finish := func(bookID string, pages int, dates ...time.Time) {
+		readingLog := readingLogDomain.NewReadingLog(userID, bookID, pages)
+		for _, date := range dates {
+			Expect(readingLog.Start(date.Add(-24 * time.Hour))).Should(Succeed())
+			Expect(readingLog.Finish(date)).Should(Succeed())
+		}
+		readingLogRepo.Save(readingLog)
+		userRepo.UpdateInterests(userID, bookID, readingLog.Status())
+	}
+
+	BeforeEach(func() {
+		challengeRepo = persistence.NewInMemoryChallengeRepository()
+		readingLogRepo = readingLogPersistence.NewInMemoryReadingLogRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		bookRepo := bookPersistence.NewInMemoryBookRepository()
+		bookReviewRepo = bookReviewPersistence.NewInMemoryBookReviewsRepository()
+		shelfRepo = shelfPersistence.NewInMemoryShelfRepository()
+
+		user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+		userRepo.Save(user)
+		dune, _ := bookDomain.NewBook(duneID, "Dune", "Spice", "Frank Herbert", "scifi", 400)
+		messiah, _ := bookDomain.NewBook(messiahID, "Dune Messiah", "Spice", "Frank Herbert", "scifi", 250)
+		emma, _ := bookDomain.NewBook(emmaID, "Emma", "Matchmaking", "Jane Austen", "classics", 300)
+		bookRepo.Save(dune)
+		bookRepo.Save(messiah)
+		bookRepo.Save(emma)
+
+		generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+		Expect(err).ShouldNot(HaveOccurred())
+		auth.CreateAuth(userID, generateAuth)
+		token = generateAuth.AccessToken
+
+		server = httptest.NewServer(setupServer(challengeRepo, readingLogRepo, userRepo, bookRepo, bookReviewRepo, shelfRepo))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /users/:id/stats", func() {
+		It("Returns 404 if the user does not exist", func() {
+			status, _ := getStats(missingUserID, "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+		It("Returns 400 with an invalid year", func() {
+			status, _ := getStats(userID, "?year=abc")
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+		It("Returns empty stats for the current year", func() {
+			status, userStats := getStats(userID, "")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(userStats.Year).Should(Equal(time.Now().UTC().Year()))
+			Expect(userStats.Months).Should(HaveLen(12))
+			Expect(userStats.BooksFinished).Should(Equal(0))
+			Expect(userStats.FavouriteGenres).Should(BeEmpty())
+			Expect(userStats.Challenge).Should(BeNil())
+		})
+		It("Counts the books and pages finished per month", func() {
+			finish(duneID, 400, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC))
+			finish(messiahID, 250,
+				time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.July, 5, 0, 0, 0, 0, time.UTC))
+			finish(emmaID, 300, time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC))
+
+			status, userStats := getStats(userID, "?year=2025")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(userStats.BooksFinished).Should(Equal(3))
+			Expect(userStats.PagesFinished).Should(Equal(900))
+			Expect(userStats.Months[2].Books).Should(Equal(2))
+			Expect(userStats.Months[2].Pages).Should(Equal(650))
+			Expect(userStats.Months[6].Books).Should(Equal(1))
+			Expect(userStats.FavouriteGenres).Should(HaveLen(1))
+			Expect(userStats.FavouriteGenres[0].Name).Should(Equal("scifi"))
+			Expect(userStats.FavouriteGenres[0].Books).Should(Equal(2))
+			Expect(userStats.FavouriteAuthors[0].Name).Should(Equal("Frank Herbert"))
+
+			_, userStats = getStats(userID, "?year=2024")
+			Expect(userStats.BooksFinished).Should(Equal(1))
+			Expect(userStats.FavouriteGenres[0].Name).Should(Equal("classics"))
+		})
+		It("Returns the average rating given in the year", func() {
+			for i, bookID := range []string{duneID, messiahID, emmaID} {
+				rating := []float64{4, 5, 2}[i]
+				review, _ := bookReviewDomain.NewBookReview(string(rune('a'+i)), "", rating, bookID, userID)
+				bookReviewRepo.Save(review)
+			}
+			old, _ := bookReviewDomain.NewBookReview("d", "", 1, "e0c2a4b6-1f3d-4e5a-8b7c-9d0e1f2a3b4c", userID)
+			old.CreatedOn = old.CreatedOn.AddDate(-1, 0, 0)
+			bookReviewRepo.Save(old)
+
+			_, userStats := getStats(userID, "")
+			Expect(userStats.ReviewsWritten).Should(Equal(3))
+			Expect(userStats.AverageRating).Should(Equal(3.67))
+		})
+		It("Dates the books marked as done without a reading log by their review", func() {
+			userRepo.UpdateInterests(userID, emmaID, "done")
+			review, _ := bookReviewDomain.NewBookReview("a", "", 4, emmaID, userID)
+			bookReviewRepo.Save(review)
+			userRepo.UpdateInterests(userID, messiahID, "done")
+
+			_, userStats := getStats(userID, "")
+			Expect(userStats.BooksFinished).Should(Equal(1))
+			Expect(userStats.PagesFinished).Should(Equal(300))
+			Expect(userStats.Months[review.CreatedOn.Month()-1].Books).Should(Equal(1))
+
+			_, userStats = getStats(userID, "?year=2020")
+			Expect(userStats.BooksFinished).Should(Equal(0))
+		})
+		It("Hides the stats of users with a private done shelf", func() {
+			shelf := shelfDomain.NewDefaultShelf(userID, shelfDomain.StatusDone)
+			shelf.SetPublic(false)
+			shelfRepo.Save(shelf)
+
+			status, _ := getStats(userID, "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/users/"+userID+"/stats", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		})
+	})
+
+	Context("When a challenge is set", func() {
+		It("Returns 401 without token", func() {
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/user/challenges/2025", bytes.NewBufferString(`{"goal": 10}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Validates the goal and year", func() {
+			Expect(sendChallenge(http.MethodPut, "2025", `{"goal": 0}`)).Should(Equal(http.StatusBadRequest))
+			Expect(sendChallenge(http.MethodPut, "3000", `{"goal": 10}`)).Should(Equal(http.StatusBadRequest))
+			Expect(sendChallenge(http.MethodPut, "abc", `{"goal": 10}`)).Should(Equal(http.StatusBadRequest))
+		})
+		It("Returns the challenge progress in the stats", func() {
+			Expect(sendChallenge(http.MethodPut, "2025", `{"goal": 4}`)).Should(Equal(http.StatusCreated))
+			finish(duneID, 400, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC))
+
+			_, userStats := getStats(userID, "?year=2025")
+			Expect(userStats.Challenge).ShouldNot(BeNil())
+			Expect(userStats.Challenge.Goal).Should(Equal(4))
+			Expect(userStats.Challenge.Completed).Should(Equal(1))
+			Expect(userStats.Challenge.Percentage).Should(Equal(25.0))
+			Expect(userStats.Challenge.Expected).Should(Equal(4))
+			Expect(userStats.Challenge.Status).Should(Equal("behind"))
+
+			Expect(sendChallenge(http.MethodPut, "2025", `{"goal": 1}`)).Should(Equal(http.StatusCreated))
+			_, userStats = getStats(userID, "?year=2025")
+			Expect(userStats.Challenge.Status).Should(Equal("completed"))
+		})
+		It("Deletes the challenge", func() {
+			Expect(sendChallenge(http.MethodDelete, "2025", "")).Should(Equal(http.StatusNotFound))
+			sendChallenge(http.MethodPut, "2025", `{"goal": 4}`)
+			Expect(sendChallenge(http.MethodDelete, "2025", "")).Should(Equal(http.StatusOK))
+			_, userStats := getStats(userID, "?year=2025")
+			Expect(userStats.Challenge).Should(BeNil())
+		})
+	})
+})
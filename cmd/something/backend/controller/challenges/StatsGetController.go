@@ -0,0 +1,54 @@
+package challenges
+
+import (
+	"net/http"
+	"something/internal/challenges/application/stats"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlParameter struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type statsQuery struct {
+	Year int `form:"year" binding:"omitempty,min=1900"`
+}
+
+// GetStatsController ...
+func GetStatsController(statsFinder stats.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var query statsQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userStats, err := statsFinder.UserStats(&stats.Criteria{
+			UserID:   param.ID,
+			ViewerID: c.GetString("user_id"),
+			Year:     query.Year,
+		})
+		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": userStats,
+		})
+		return
+	}
+}
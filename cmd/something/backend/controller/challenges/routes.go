@@ -0,0 +1,22 @@
+package challenges
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/challenges/application/set"
+	"something/internal/challenges/application/stats"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	statsFinder stats.Service,
+	setter set.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	router.GET("/users/:id/stats", m.TokenAuthOptionalMiddleware(accessKeys, auth), GetStatsController(statsFinder))
+	router.PUT("/user/challenges/:year", m.TokenAuthMiddleware(accessKeys, auth), PutChallengeController(setter))
+	router.DELETE("/user/challenges/:year", m.TokenAuthMiddleware(accessKeys, auth), DeleteChallengeController(setter))
+}
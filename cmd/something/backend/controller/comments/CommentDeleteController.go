@@ -0,0 +1,36 @@
+package comments
+
+import (
+	"net/http"
+	"something/internal/comments/application/delete"
+	userDomain "something/internal/users/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteController ...
+func DeleteController(deleter delete.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param commentURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		err := deleter.DeleteComment(param.ID, userID.(string), userDomain.HasPermission(c.GetString("role"), userDomain.PermissionReviewsModerate))
+		if err != nil {
+			commentError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
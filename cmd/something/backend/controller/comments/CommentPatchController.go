@@ -0,0 +1,101 @@
+package comments
+
+import (
+	"net/http"
+	"something/internal/comments/application/update"
+
+	"github.com/gin-gonic/gin"
+)
+
+type commentURLParameter struct {
+	ID string `uri:"comment_id" binding:"required,uuid"`
+}
+
+// PatchController ...
+func PatchController(updater update.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param commentURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request update.CommentCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.ID = param.ID
+		request.UserID = userID.(string)
+
+		err := updater.UpdateComment(&request)
+		if err != nil {
+			commentError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
+
+type moderationRequest struct {
+	Hidden *bool `json:"hidden" binding:"required"`
+}
+
+// ModerationController ...
+func ModerationController(updater update.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param commentURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request moderationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err := updater.HideComment(param.ID, *request.Hidden)
+		if err != nil {
+			commentError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
+
+func commentError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "comment not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case "forbidden":
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case "comment text is required":
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "Something wrong happened, try again later ...",
+	})
+}
@@ -0,0 +1,66 @@
+package comments
+
+import (
+	"net/http"
+	"something/internal/comments/application/create"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlParameters struct {
+	ReviewID  string `uri:"review_id" binding:"required,uuid"`
+	CommentID string `uri:"comment_id" binding:"required,uuid"`
+}
+
+// PutController ...
+func PutController(creator create.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlParameters
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request create.CommentCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.ID = param.CommentID
+		request.ReviewID = param.ReviewID
+		request.UserID = userID.(string)
+
+		err := creator.CreateComment(&request)
+		if err != nil {
+			switch err.Error() {
+			case "book review not found", "parent comment not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			case "comment already exists", "replies can't be nested", "comment text is required":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusCreated)
+		return
+	}
+}
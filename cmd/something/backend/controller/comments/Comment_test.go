@@ -0,0 +1,246 @@
+package comments
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookReviewFind "something/internal/bookreviews/application/find"
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookReviewPersistence "something/internal/bookreviews/infraestructure/persistence"
+	"something/internal/comments/application"
+	"something/internal/comments/application/cascade"
+	"something/internal/comments/application/create"
+	"something/internal/comments/application/delete"
+	"something/internal/comments/application/find"
+	"something/internal/comments/application/update"
+	"something/internal/comments/domain"
+	"something/internal/comments/infraestructure/persistence"
+	userFind "something/internal/users/application/find"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const otherID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+const staffID = "4d1a5a2e-3c59-4f3b-9b57-0e2f7c6b8a11"
+const bookID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
+const reviewID = "c0b369a0-8de4-417d-a905-c33644c2907d"
+const commentID = "0f2b4a6e-1c3d-4e5f-8a9b-0c1d2e3f4a5b"
+const replyID = "a3b6f2c1-7d8e-4f90-a1b2-c3d4e5f60718"
+
+func setupServer(
+	commentRepo domain.CommentRepository,
+	bookReviewRepo bookReviewDomain.BookReviewRepository,
+	userRepo userDomain.UserRepository,
+) *gin.Engine {
+	router := gin.Default()
+	RegisterRoutes(
+		find.NewService(commentRepo),
+		bookReviewFind.NewService(bookReviewRepo),
+		userFind.NewService(userRepo),
+		create.NewService(commentRepo, bookReviewRepo),
+		update.NewService(commentRepo),
+		delete.NewService(commentRepo),
+		tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+func TestCommentCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Comment Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var commentRepo domain.CommentRepository
+	var tokens map[string]string
+
+	send := func(method, path, payload, as string) int {
+		req, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString(payload))
+		Expect(err).ShouldNot(HaveOccurred())
+		if as != "" {
+			req.Header.Set("Authorization", "Bearer "+tokens[as])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	getComments := func(query string) (int, []*application.CommentResponse) {
+		resp, err := http.Get(server.URL + "/book/reviews/" + reviewID + "/comments" + query)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		response := &struct {
+			Data []*application.CommentResponse `json:"data"`
+		}{}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, response)
+		return resp.StatusCode, response.Data
+	}
+
+	comment := func(id, parentID, as string) int {
+		return send(http.MethodPut, "/book/reviews/"+reviewID+"/comments/"+id,
+			`{"text": "I loved it too", "parent_id": "`+parentID+`"}`, as)
+	}
+
+	BeforeEach(func() {
+		commentRepo = persistence.NewInMemoryCommentRepository()
+		bookReviewRepo := bookReviewPersistence.NewInMemoryBookReviewsRepository()
+		userRepo := userPersistence.NewInMemoryUserRepository()
+
+		review, _ := bookReviewDomain.NewBookReview(reviewID, "Great", 5, bookID, otherID)
+		bookReviewRepo.Save(review)
+
+		tokens = map[string]string{}
+		roles := map[string]string{userID: "default", otherID: "default", staffID: "staff"}
+		for id, role := range roles {
+			user, _ := userDomain.NewUser(id, "reader", "reader"+id[:4], id[:4]+"@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			generateAuth, err := jwt.CreateToken(id, role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(id, generateAuth)
+			tokens[id] = generateAuth.AccessToken
+		}
+
+		server = httptest.NewServer(setupServer(commentRepo, bookReviewRepo, userRepo))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /book/reviews/:review_id/comments", func() {
+		It("Returns 404 if the review does not exist", func() {
+			resp, err := http.Get(server.URL + "/book/reviews/" + commentID + "/comments")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+		})
+		It("Returns empty array data without comments", func() {
+			resp, err := http.Get(server.URL + "/book/reviews/" + reviewID + "/comments")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"data":[]}`))
+		})
+		It("Returns the comments with their replies and paginated", func() {
+			for i := 0; i < 3; i++ {
+				c, _ := domain.NewComment(string(rune('a'+i)), bookID, reviewID, "", userID, "comment")
+				c.CreatedOn = c.CreatedOn.Add(time.Duration(i) * time.Second)
+				commentRepo.Save(c)
+			}
+			reply, _ := domain.NewComment("r", bookID, reviewID, "a", otherID, "reply")
+			commentRepo.Save(reply)
+
+			status, comments := getComments("?per_page=2")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(comments).Should(HaveLen(2))
+			Expect(comments[0].ID).Should(Equal("a"))
+			Expect(comments[0].User.Username).Should(Equal("readerc015"))
+			Expect(comments[0].Replies).Should(HaveLen(1))
+			Expect(comments[0].Replies[0].User.ID).Should(Equal(otherID))
+
+			_, comments = getComments("?per_page=2&page=2")
+			Expect(comments).Should(HaveLen(1))
+			Expect(comments[0].ID).Should(Equal("c"))
+		})
+	})
+
+	Context("When PUT request is sent to /book/reviews/:review_id/comments/:comment_id", func() {
+		It("Returns 401 without token", func() {
+			Expect(comment(commentID, "", "")).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Creates comments and replies one level deep", func() {
+			Expect(comment(commentID, "", userID)).Should(Equal(http.StatusCreated))
+			Expect(comment(commentID, "", userID)).Should(Equal(http.StatusBadRequest))
+			Expect(comment(replyID, commentID, otherID)).Should(Equal(http.StatusCreated))
+			Expect(comment("5c2d1e0f-9a8b-4c7d-8e6f-5a4b3c2d1e0f", replyID, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(comment("5c2d1e0f-9a8b-4c7d-8e6f-5a4b3c2d1e0f", staffID, userID)).Should(Equal(http.StatusNotFound))
+
+			_, comments := getComments("")
+			Expect(comments).Should(HaveLen(1))
+			Expect(comments[0].Replies[0].ID).Should(Equal(replyID))
+		})
+		It("Validates the text", func() {
+			status := send(http.MethodPut, "/book/reviews/"+reviewID+"/comments/"+commentID, `{"text": ""}`, userID)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("When comments are edited or deleted", func() {
+		BeforeEach(func() {
+			Expect(comment(commentID, "", userID)).Should(Equal(http.StatusCreated))
+			Expect(comment(replyID, commentID, otherID)).Should(Equal(http.StatusCreated))
+		})
+		It("Lets authors edit their own comments", func() {
+			Expect(send(http.MethodPatch, "/book/comments/"+commentID, `{"text": "changed"}`, otherID)).Should(Equal(http.StatusForbidden))
+			Expect(send(http.MethodPatch, "/book/comments/"+commentID, `{"text": "changed"}`, userID)).Should(Equal(http.StatusOK))
+			_, comments := getComments("")
+			Expect(comments[0].Text).Should(Equal("changed"))
+			Expect(comments[0].EditedOn).ShouldNot(BeNil())
+		})
+		It("Lets authors and staff delete comments", func() {
+			Expect(send(http.MethodDelete, "/book/comments/"+replyID, "", userID)).Should(Equal(http.StatusForbidden))
+			Expect(send(http.MethodDelete, "/book/comments/"+replyID, "", staffID)).Should(Equal(http.StatusOK))
+			Expect(send(http.MethodDelete, "/book/comments/"+replyID, "", staffID)).Should(Equal(http.StatusNotFound))
+
+			comment(replyID, commentID, otherID)
+			Expect(send(http.MethodDelete, "/book/comments/"+commentID, "", userID)).Should(Equal(http.StatusOK))
+			_, comments := getComments("")
+			Expect(comments).Should(BeEmpty())
+			_, err := commentRepo.FindByID(replyID)
+			Expect(err).Should(HaveOccurred())
+		})
+		It("Lets staff hide comments", func() {
+			Expect(send(http.MethodPatch, "/book/comments/"+commentID+"/moderation", `{"hidden": true}`, userID)).Should(Equal(http.StatusForbidden))
+			Expect(send(http.MethodPatch, "/book/comments/"+commentID+"/moderation", `{}`, staffID)).Should(Equal(http.StatusBadRequest))
+			Expect(send(http.MethodPatch, "/book/comments/"+commentID+"/moderation", `{"hidden": true}`, staffID)).Should(Equal(http.StatusOK))
+			_, comments := getComments("")
+			Expect(comments[0].Hidden).Should(BeTrue())
+			Expect(comments[0].Text).Should(BeEmpty())
+		})
+	})
+
+	Context("When a review or its author is deleted", func() {
+		It("Deletes the comments", func() {
+			bus := eventbus.NewInMemoryBus()
+			cascade.Subscribe(bus, commentRepo)
+			comment(commentID, "", userID)
+			comment(replyID, commentID, otherID)
+
+			bus.Publish(userDomain.NewUserDeleted(&userDomain.User{ID: userID}))
+			_, err := commentRepo.FindByID(replyID)
+			Expect(err).Should(HaveOccurred())
+
+			comment(commentID, "", otherID)
+			review, _ := bookReviewDomain.NewBookReview(reviewID, "Great", 5, bookID, otherID)
+			bus.Publish(bookReviewDomain.NewReviewDeleted(review))
+			_, err = commentRepo.FindByID(commentID)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package comments
+
+import (
+	"net/http"
+	bookReviewFind "something/internal/bookreviews/application/find"
+	"something/internal/comments/application"
+	"something/internal/comments/application/find"
+	userFind "something/internal/users/application/find"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type reviewURLParameter struct {
+	ID string `uri:"review_id" binding:"required,uuid"`
+}
+
+// GetCommentsController ...
+func GetCommentsController(
+	finder find.Service,
+	reviewFinder bookReviewFind.Service,
+	userFinder userFind.Service,
+) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param reviewURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		_, err := reviewFinder.FindBookReviewByID(param.ID)
+		if err != nil {
+			if err.Error() == "book review not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		page, _ := strconv.Atoi(c.Query("page"))
+		perPage, _ := strconv.Atoi(c.Query("per_page"))
+		comments, err := finder.FindReviewComments(&find.Criteria{
+			ReviewID: param.ID,
+			Page:     page,
+			PerPage:  perPage,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		getUserInfoComment(comments, userFinder, map[string]*application.User{})
+		c.JSON(http.StatusOK, gin.H{
+			"data": comments,
+		})
+		return
+	}
+}
+
+func getUserInfoComment(
+	comments []*application.CommentResponse,
+	userFinder userFind.Service,
+	users map[string]*application.User,
+) {
+	for _, comment := range comments {
+		user, ok := users[comment.User.ID]
+		if !ok {
+			found, err := userFinder.FindUserByID(comment.User.ID)
+			if err != nil {
+				continue
+			}
+			user = &application.User{ID: found.ID, Name: found.Name, Username: found.Username}
+			users[comment.User.ID] = user
+		}
+		comment.User = *user
+		getUserInfoComment(comment.Replies, userFinder, users)
+	}
+}
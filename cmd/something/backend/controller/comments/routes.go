@@ -0,0 +1,33 @@
+package comments
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	bookReviewFind "something/internal/bookreviews/application/find"
+	"something/internal/comments/application/create"
+	"something/internal/comments/application/delete"
+	"something/internal/comments/application/find"
+	"something/internal/comments/application/update"
+	userFind "something/internal/users/application/find"
+	userDomain "something/internal/users/domain"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	finder find.Service,
+	reviewFinder bookReviewFind.Service,
+	userFinder userFind.Service,
+	creator create.Service,
+	updater update.Service,
+	deleter delete.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	router.GET("/book/reviews/:review_id/comments", GetCommentsController(finder, reviewFinder, userFinder))
+	router.PUT("/book/reviews/:review_id/comments/:comment_id", m.TokenAuthMiddleware(accessKeys, auth), PutController(creator))
+	router.PATCH("/book/comments/:comment_id", m.TokenAuthMiddleware(accessKeys, auth), PatchController(updater))
+	router.DELETE("/book/comments/:comment_id", m.TokenAuthMiddleware(accessKeys, auth), DeleteController(deleter))
+	router.PATCH("/book/comments/:comment_id/moderation", m.TokenAuthPermissionMiddleware(userDomain.PermissionReviewsModerate, accessKeys, auth), ModerationController(updater))
+}
@@ -0,0 +1,62 @@
+package feed
+
+import (
+	"net/http"
+	"something/internal/feed/application"
+	"something/internal/feed/application/find"
+	userFind "something/internal/users/application/find"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFeedController ...
+func GetFeedController(finder find.Service, userFinder userFind.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		feed, err := finder.Feed(&find.Criteria{
+			UserID: userID.(string),
+			Cursor: c.Query("cursor"),
+			Limit:  limit,
+		})
+		if err != nil {
+			if err.Error() == "invalid cursor" {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		getUserInfoActivity(feed.Data, userFinder)
+		c.JSON(http.StatusOK, feed)
+		return
+	}
+}
+
+func getUserInfoActivity(activities []*application.ActivityResponse, userFinder userFind.Service) {
+	users := map[string]*application.User{}
+	for _, activity := range activities {
+		user, ok := users[activity.User.ID]
+		if !ok {
+			found, err := userFinder.FindUserByID(activity.User.ID)
+			if err != nil {
+				continue
+			}
+			user = &application.User{ID: found.ID, Name: found.Name, Username: found.Username}
+			users[activity.User.ID] = user
+		}
+		activity.User = *user
+	}
+}
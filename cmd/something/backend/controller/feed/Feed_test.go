@@ -0,0 +1,222 @@
+package feed
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/internal/feed/application/find"
+	"something/internal/feed/application/record"
+	"something/internal/feed/domain"
+	"something/internal/feed/infraestructure/persistence"
+	userFollowDomain "something/internal/userfollow/domain"
+	userFollowPersistence "something/internal/userfollow/infraestructure/persistence"
+	userFind "something/internal/users/application/find"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const followedID = "4d1a5a2e-3c59-4f3b-9b57-0e2f7c6b8a11"
+const otherID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+const bookID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
+
+func setupServer(
+	activityRepo domain.ActivityRepository,
+	userFollowRepo userFollowDomain.UserFollowRepository,
+	userRepo userDomain.UserRepository,
+	bus eventbus.Bus,
+) *gin.Engine {
+	router := gin.Default()
+	finder := find.NewService(activityRepo, userFollowRepo)
+	userFinder := userFind.NewService(userRepo)
+	record.Subscribe(bus, activityRepo)
+	RegisterRoutes(finder, userFinder, tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+type feedResponse struct {
+	Data []struct {
+		ID     string `json:"id"`
+		Type   string `json:"type"`
+		BookID string `json:"book_id"`
+		User   struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		} `json:"user"`
+		ReviewID string  `json:"review_id"`
+		Rating   float64 `json:"rating"`
+		Status   string  `json:"status"`
+	} `json:"data"`
+	NextCursor string `json:"next_cursor"`
+}
+
+func TestFeedCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Feed Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var activityRepo domain.ActivityRepository
+	var userFollowRepo userFollowDomain.UserFollowRepository
+	var userRepo userDomain.UserRepository
+	var bus eventbus.Bus
+	var token string
+
+	getFeed := func(query string) (int, *feedResponse) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/feed"+query, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		feed := &feedResponse{}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, feed)
+		return resp.StatusCode, feed
+	}
+
+	BeforeEach(func() {
+		activityRepo = persistence.NewInMemoryActivityRepository()
+		userFollowRepo = userFollowPersistence.NewInMemoryUserFollowRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		bus = eventbus.NewInMemoryBus()
+
+		followed, _ := userDomain.NewUser(followedID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+		userRepo.Save(followed)
+		follow, _ := userFollowDomain.NewUserFollow(userID, followedID)
+		userFollowRepo.Follow(follow)
+
+		generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+		Expect(err).ShouldNot(HaveOccurred())
+		auth.CreateAuth(userID, generateAuth)
+		token = generateAuth.AccessToken
+
+		server = httptest.NewServer(setupServer(activityRepo, userFollowRepo, userRepo, bus))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /feed", func() {
+		It("Returns an 401 status code without token", func() {
+			resp, err := http.Get(server.URL + "/feed")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Returns empty array data if followed users have no activity", func() {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/feed", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"data":[],"next_cursor":""}`))
+		})
+		It("Returns the activity of followed users newest first", func() {
+			now := time.Now().UTC()
+			activityRepo.Save(domain.NewStatusActivity(followedID, bookID, "reading", now.Add(-time.Hour)))
+			activityRepo.Save(domain.NewReviewActivity(followedID, bookID, "1", 4, now))
+			activityRepo.Save(domain.NewReviewActivity(otherID, bookID, "2", 1, now))
+
+			status, feed := getFeed("")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(feed.Data).Should(HaveLen(2))
+			Expect(feed.Data[0].Type).Should(Equal(domain.ReviewPosted))
+			Expect(feed.Data[0].ReviewID).Should(Equal("1"))
+			Expect(feed.Data[0].Rating).Should(Equal(float64(4)))
+			Expect(feed.Data[0].User.Username).Should(Equal("madison1"))
+			Expect(feed.Data[1].Type).Should(Equal(domain.StatusChanged))
+			Expect(feed.Data[1].Status).Should(Equal("reading"))
+			Expect(feed.NextCursor).Should(BeEmpty())
+		})
+		It("Paginates with the returned cursor", func() {
+			now := time.Now().UTC()
+			for i := 0; i < 3; i++ {
+				activityRepo.Save(domain.NewStatusActivity(followedID, bookID, "reading", now.Add(-time.Duration(i)*time.Minute)))
+			}
+			activityRepo.Save(domain.NewStatusActivity(followedID, bookID, "done", now.Add(-time.Minute)))
+
+			status, firstPage := getFeed("?limit=2")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(firstPage.Data).Should(HaveLen(2))
+			Expect(firstPage.NextCursor).ShouldNot(BeEmpty())
+
+			status, secondPage := getFeed("?limit=2&cursor=" + firstPage.NextCursor)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(secondPage.Data).Should(HaveLen(2))
+			Expect(secondPage.NextCursor).Should(BeEmpty())
+
+			seen := map[string]bool{}
+			for _, activity := range append(firstPage.Data, secondPage.Data...) {
+				Expect(seen[activity.ID]).Should(BeFalse())
+				seen[activity.ID] = true
+			}
+		})
+		It("Returns an 400 status code with an invalid cursor", func() {
+			status, _ := getFeed("?cursor=not-a-cursor")
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+		It("Records reviews and reading status changes", func() {
+			review, _ := bookReviewDomain.NewBookReview("1", "abc", 5, bookID, followedID)
+			bus.Publish(
+				bookReviewDomain.NewReviewCreated(review),
+				userDomain.NewInterestChanged(followedID, bookID, "done", "reading"),
+			)
+
+			_, feed := getFeed("")
+			Expect(feed.Data).Should(HaveLen(2))
+
+			bus.Publish(bookReviewDomain.NewReviewDeleted(review))
+
+			_, feed = getFeed("")
+			Expect(feed.Data).Should(HaveLen(1))
+			Expect(feed.Data[0].Status).Should(Equal("done"))
+		})
+		It("Doesn't record books added to the pending list", func() {
+			bus.Publish(userDomain.NewInterestChanged(followedID, bookID, "pending", ""))
+
+			_, feed := getFeed("")
+			Expect(feed.Data).Should(BeEmpty())
+		})
+		It("Removes the activity of deleted books", func() {
+			review, _ := bookReviewDomain.NewBookReview("1", "abc", 5, bookID, followedID)
+			bus.Publish(
+				bookReviewDomain.NewReviewCreated(review),
+				userDomain.NewInterestChanged(followedID, "other-book", "reading", ""),
+			)
+			book := &bookDomain.Book{ID: bookID}
+			bus.Publish(bookDomain.NewBookDeleted(book))
+
+			_, feed := getFeed("")
+			Expect(feed.Data).Should(HaveLen(1))
+			Expect(feed.Data[0].BookID).Should(Equal("other-book"))
+		})
+	})
+})
@@ -0,0 +1,20 @@
+package feed
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/feed/application/find"
+	userFind "something/internal/users/application/find"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	finder find.Service,
+	userFinder userFind.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	router.GET("/feed", m.TokenAuthMiddleware(accessKeys, auth), GetFeedController(finder, userFinder))
+}
@@ -0,0 +1,17 @@
+package jwks
+
+import (
+	"net/http"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetController publishes the public keys verifying the access tokens,
+// clients may cache them for a few minutes
+func GetController(accessKeys jwt.KeySet) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, accessKeys.JWKS())
+	}
+}
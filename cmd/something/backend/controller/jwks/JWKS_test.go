@@ -0,0 +1,151 @@
+package jwks
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func setupServer(accessKeys jwt.KeySet) *gin.Engine {
+	router := gin.Default()
+	RegisterRoutes(accessKeys, router)
+	return router
+}
+
+func TestJWKS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "JWKS Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var rsaKey *rsa.PrivateKey
+	var edKey ed25519.PrivateKey
+	var keys jwt.KeySet
+
+	BeforeEach(func() {
+		var err error
+		rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, edKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		previous, err := jwt.NewKey("2026-01", rsaKey, time.Now().Add(-time.Hour*24*90))
+		Expect(err).ShouldNot(HaveOccurred())
+		current, err := jwt.NewKey("2026-04", edKey, time.Now().Add(-time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		next, err := jwt.NewKey("2026-07", edKey, time.Now().Add(time.Hour*24*90))
+		Expect(err).ShouldNot(HaveOccurred())
+		keys, err = jwt.NewKeySet(previous, current, next)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		server = httptest.NewServer(setupServer(keys))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	getJWKS := func() *jwt.JWKS {
+		resp, err := http.Get(server.URL + "/.well-known/jwks.json")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		defer resp.Body.Close()
+		jwks := &jwt.JWKS{}
+		Expect(json.NewDecoder(resp.Body).Decode(jwks)).Should(Succeed())
+		return jwks
+	}
+
+	Context("When GET request is sent to /.well-known/jwks.json", func() {
+		It("publishes every key, newest first", func() {
+			jwks := getJWKS()
+			Expect(jwks.Keys).Should(HaveLen(3))
+			Expect(jwks.Keys[0].Kid).Should(Equal("2026-07"))
+			Expect(jwks.Keys[1].Kid).Should(Equal("2026-04"))
+			Expect(jwks.Keys[1].Kty).Should(Equal("OKP"))
+			Expect(jwks.Keys[1].Crv).Should(Equal("Ed25519"))
+			Expect(jwks.Keys[1].Alg).Should(Equal("EdDSA"))
+			Expect(jwks.Keys[1].X).Should(Equal(
+				base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))))
+
+			Expect(jwks.Keys[2].Kid).Should(Equal("2026-01"))
+			Expect(jwks.Keys[2].Kty).Should(Equal("RSA"))
+			Expect(jwks.Keys[2].Alg).Should(Equal("RS256"))
+			n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[2].N)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(new(big.Int).SetBytes(n)).Should(Equal(rsaKey.N))
+			Expect(jwks.Keys[2].E).Should(Equal("AQAB"))
+		})
+		It("publishes no keys for shared secrets", func() {
+			server.Close()
+			server = httptest.NewServer(setupServer(jwt.NewHMACKeySet("secret")))
+			Expect(getJWKS().Keys).Should(BeEmpty())
+		})
+	})
+
+	Context("When tokens are signed with the key set", func() {
+		params := func(accessKeys jwt.KeySet) *jwt.TokenParams {
+			return &jwt.TokenParams{
+				AccessKeys:  accessKeys,
+				RefreshKeys: jwt.NewHMACKeySet("refresh-secret"),
+				AccessTime:  time.Minute,
+				RefreshTime: time.Minute,
+			}
+		}
+		request := func(token string) *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			return req
+		}
+
+		It("signs with the newest active key and verifies with the kid", func() {
+			td, err := jwt.CreateToken("user-1", "default", params(keys))
+			Expect(err).ShouldNot(HaveOccurred())
+			token, err := jwt.VerifyToken(request(td.AccessToken), keys)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token.Header["kid"]).Should(Equal("2026-04"))
+			Expect(token.Header["alg"]).Should(Equal("EdDSA"))
+
+			details, err := jwt.ExtractTokenMetadata(request(td.AccessToken), keys)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.UserID).Should(Equal("user-1"))
+		})
+		It("keeps verifying the tokens of the previous key", func() {
+			previous, _ := jwt.NewKey("2026-01", rsaKey, time.Now().Add(-time.Hour*24*90))
+			oldKeys, _ := jwt.NewKeySet(previous)
+			td, err := jwt.CreateToken("user-1", "default", params(oldKeys))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			details, err := jwt.ExtractTokenMetadata(request(td.AccessToken), keys)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.UserID).Should(Equal("user-1"))
+		})
+		It("rejects unknown keys and shared secret tokens", func() {
+			_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+			other, _ := jwt.NewKey("2026-04", otherKey, time.Now().Add(-time.Hour))
+			otherKeys, _ := jwt.NewKeySet(other)
+			td, err := jwt.CreateToken("user-1", "default", params(otherKeys))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = jwt.ExtractTokenMetadata(request(td.AccessToken), keys)
+			Expect(err).Should(HaveOccurred())
+
+			td, err = jwt.CreateToken("user-1", "default", params(jwt.NewHMACKeySet("secret")))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = jwt.ExtractTokenMetadata(request(td.AccessToken), keys)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package jwks
+
+import (
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(accessKeys jwt.KeySet, router *gin.Engine) {
+	router.GET("/.well-known/jwks.json", GetController(accessKeys))
+}
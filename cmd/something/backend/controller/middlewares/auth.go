@@ -1,16 +1,18 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
+	userDomain "something/internal/users/domain"
 	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
 )
 
 // TokenAuthMiddleware ...
-func TokenAuthMiddleware(accessSecret string) gin.HandlerFunc {
+func TokenAuthMiddleware(accessKeys jwt.KeySet, auth jwt.AuthRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		au, err := jwt.ExtractTokenMetadata(c.Request, accessSecret)
+		au, err := authenticate(c, accessKeys, auth)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "unauthorized",
@@ -19,24 +21,65 @@ func TokenAuthMiddleware(accessSecret string) gin.HandlerFunc {
 			return
 		}
 		c.Set("user_id", au.UserID)
+		c.Set("access_uuid", au.AccessUUID)
+		c.Set("role", au.Role)
 		c.Next()
 	}
 }
 
-const authorizedRole = "staff"
+// TokenAuthOptionalMiddleware sets the user of a valid token and lets
+// anonymous requests through
+func TokenAuthOptionalMiddleware(accessKeys jwt.KeySet, auth jwt.AuthRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if au, err := authenticate(c, accessKeys, auth); err == nil {
+			c.Set("user_id", au.UserID)
+			c.Set("access_uuid", au.AccessUUID)
+			c.Set("role", au.Role)
+		}
+		c.Next()
+	}
+}
 
-// TokenAuthStaffMiddleware ...
-func TokenAuthStaffMiddleware(accessSecret string) gin.HandlerFunc {
+// TokenAuthPermissionMiddleware lets through the users whose token role
+// grants the permission. Requests without a valid token get a 401 and
+// users without the permission a 403.
+func TokenAuthPermissionMiddleware(permission string, accessKeys jwt.KeySet, auth jwt.AuthRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		au, err := jwt.ExtractTokenMetadata(c.Request, accessSecret)
-		if err != nil || au.Role != authorizedRole {
+		au, err := authenticate(c, accessKeys, auth)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "unauthorized",
 			})
 			c.Abort()
 			return
 		}
+		if !userDomain.HasPermission(au.Role, permission) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "forbidden",
+			})
+			c.Abort()
+			return
+		}
 		c.Set("user_id", au.UserID)
+		c.Set("access_uuid", au.AccessUUID)
+		c.Set("role", au.Role)
 		c.Next()
 	}
 }
+
+// authenticate verifies the token signature and checks that the session
+// has not been revoked (logout, refresh rotation or expiration)
+func authenticate(c *gin.Context, accessKeys jwt.KeySet, auth jwt.AuthRepository) (*jwt.AccessDetails, error) {
+	au, err := jwt.ExtractTokenMetadata(c.Request, accessKeys)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := auth.FetchAuth(au)
+	if err != nil {
+		return nil, err
+	}
+	if userID != au.UserID {
+		return nil, errors.New("unauthorized")
+	}
+	return au, nil
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+	userDomain "something/internal/users/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IsOwner tells if the authenticated user owns the resource. Needs one of
+// the token middlewares to run before.
+func IsOwner(c *gin.Context, ownerID string) bool {
+	userID := c.GetString("user_id")
+	return userID != "" && userID == ownerID
+}
+
+// IsOwnerOrHasPermission is the owner-or-admin policy: the authenticated
+// user owns the resource or its role grants the permission. Needs one of
+// the token middlewares to run before.
+func IsOwnerOrHasPermission(c *gin.Context, ownerID, permission string) bool {
+	if IsOwner(c, ownerID) {
+		return true
+	}
+	return userDomain.HasPermission(c.GetString("role"), permission)
+}
+
+// OwnerOrPermissionMiddleware applies the owner-or-admin policy to the
+// resources owned by the user in the :id path parameter, anyone else gets
+// a 403
+func OwnerOrPermissionMiddleware(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsOwnerOrHasPermission(c, c.Param("id"), permission) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "forbidden",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"something/pkg/ratelimit"
+	jwt "something/pkg/redisjwt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitRule applies a quota to the routes whose path starts with one
+// of the prefixes ("/" matches every route), every rule has its own buckets
+type RateLimitRule struct {
+	Name     string
+	Prefixes []string
+	Quota    ratelimit.Quota
+}
+
+// RateLimitMiddleware limits the requests of each client with the first
+// rule matching the route, routes without rule aren't limited. Clients
+// are the user of the access token or the IP for anonymous requests.
+// Responses carry the RateLimit-* headers and a 429 once the quota runs
+// out. Registered on the router, so it runs before the token middlewares.
+func RateLimitMiddleware(store ratelimit.Store, rules []RateLimitRule, accessKeys jwt.KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule := matchRateLimitRule(rules, c.FullPath())
+		if rule == nil {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(rule.Name+":"+rateLimitClient(c, accessKeys), rule.Quota)
+		if err != nil {
+			// An unavailable store must not take the API down
+			log.Println(err)
+			c.Next()
+			return
+		}
+		c.Header("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+		c.Header("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+		c.Header("RateLimit-Reset", seconds(result.Reset))
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "rate limit exceeded",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func matchRateLimitRule(rules []RateLimitRule, path string) *RateLimitRule {
+	if path == "" {
+		return nil
+	}
+	for i := range rules {
+		for _, prefix := range rules[i].Prefixes {
+			if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+				return &rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// rateLimitClient only checks the token signature, a revoked token still
+// identifies who sends the request
+func rateLimitClient(c *gin.Context, accessKeys jwt.KeySet) string {
+	if au, err := jwt.ExtractTokenMetadata(c.Request, accessKeys); err == nil {
+		return "user:" + au.UserID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"net/http"
+	userFind "something/internal/users/application/find"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerifiedUserMiddleware lets through the users that confirmed their email,
+// the rest get a 403. Needs one of the token middlewares to run before.
+func VerifiedUserMiddleware(finder userFind.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := finder.FindUserByID(c.GetString("user_id"))
+		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "unauthorized",
+				})
+				c.Abort()
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			c.Abort()
+			return
+		}
+		if !user.Verified {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "email not verified",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
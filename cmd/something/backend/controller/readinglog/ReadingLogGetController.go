@@ -0,0 +1,49 @@
+package readinglog
+
+import (
+	"net/http"
+	"something/internal/readinglog/application/find"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlBookParameter struct {
+	ID string `uri:"book_id" binding:"required,uuid"`
+}
+
+// GetReadingLogController ...
+func GetReadingLogController(finder find.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlBookParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		readingLog, err := finder.FindReadingLog(userID.(string), param.ID)
+		if err != nil {
+			if err.Error() == "reading log not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": readingLog,
+		})
+		return
+	}
+}
@@ -0,0 +1,128 @@
+package readinglog
+
+import (
+	"net/http"
+	"something/internal/readinglog/application"
+	"something/internal/readinglog/application/track"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartController ...
+func StartController(tracker track.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request track.ReadCommand
+		if !bindCommand(c, &request, &request.UserID, &request.BookID) {
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		readingLog, err := tracker.StartRead(&request)
+		trackResponse(c, readingLog, err)
+	}
+}
+
+// FinishController ...
+func FinishController(tracker track.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request track.ReadCommand
+		if !bindCommand(c, &request, &request.UserID, &request.BookID) {
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		readingLog, err := tracker.FinishRead(&request)
+		trackResponse(c, readingLog, err)
+	}
+}
+
+// ProgressController ...
+func ProgressController(tracker track.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request track.ProgressCommand
+		if !bindCommand(c, &request, &request.UserID, &request.BookID) {
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		readingLog, err := tracker.UpdateProgress(&request)
+		trackResponse(c, readingLog, err)
+	}
+}
+
+// SessionController ...
+func SessionController(tracker track.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request track.SessionCommand
+		if !bindCommand(c, &request, &request.UserID, &request.BookID) {
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		readingLog, err := tracker.LogSession(&request)
+		trackResponse(c, readingLog, err)
+	}
+}
+
+// bindCommand binds the book in the url, the optional json body and the
+// logged user into the command, writing the error response when it fails
+func bindCommand(c *gin.Context, request interface{}, userID, bookID *string) bool {
+	var param urlBookParameter
+	if err := c.ShouldBindUri(&param); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return false
+		}
+	}
+
+	user, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Something wrong happened, try again later ...",
+		})
+		return false
+	}
+	*userID = user.(string)
+	*bookID = param.ID
+	return true
+}
+
+var trackErrors = map[string]int{
+	"book not found":                                   http.StatusNotFound,
+	"book is already being read":                       http.StatusBadRequest,
+	"a read can't start before the previous one ended": http.StatusBadRequest,
+	"a read can't finish before it started":            http.StatusBadRequest,
+	"session date is before the read started":          http.StatusBadRequest,
+	"from page must be between 0 and to page":          http.StatusBadRequest,
+	"page out of range":                                http.StatusBadRequest,
+}
+
+func trackResponse(c *gin.Context, readingLog *application.ReadingLogResponse, err error) {
+	if err != nil {
+		if status, ok := trackErrors[err.Error()]; ok {
+			c.JSON(status, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Something wrong happened, try again later ...",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": readingLog,
+	})
+}
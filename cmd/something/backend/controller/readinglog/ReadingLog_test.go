@@ -0,0 +1,258 @@
+package readinglog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookDomain "something/internal/books/domain"
+	bookPersistence "something/internal/books/infraestructure/persistence"
+	"something/internal/readinglog/application/find"
+	"something/internal/readinglog/application/status"
+	"something/internal/readinglog/application/track"
+	"something/internal/readinglog/domain"
+	"something/internal/readinglog/infraestructure/persistence"
+	userDelete "something/internal/users/application/delete"
+	userUpdate "something/internal/users/application/update"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const bookID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
+const missingBookID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+
+func setupServer(
+	readingLogRepo domain.ReadingLogRepository,
+	bookRepo bookDomain.BookRepository,
+	updater userUpdate.Service,
+	bus eventbus.Bus,
+) *gin.Engine {
+	router := gin.Default()
+	finder := find.NewService(readingLogRepo)
+	tracker := track.NewService(readingLogRepo, bookRepo, updater)
+	status.Subscribe(bus, readingLogRepo, bookRepo)
+	RegisterRoutes(finder, tracker, tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+type readingLogResponse struct {
+	Status      string  `json:"status"`
+	Pages       int     `json:"pages"`
+	CurrentPage int     `json:"current_page"`
+	Percentage  float64 `json:"percentage"`
+	TimesRead   int     `json:"times_read"`
+	Reads       []struct {
+		FinishedOn  *time.Time `json:"finished_on"`
+		AbandonedOn *time.Time `json:"abandoned_on"`
+		Sessions    []struct {
+			FromPage  int `json:"from_page"`
+			ToPage    int `json:"to_page"`
+			PagesRead int `json:"pages_read"`
+			Minutes   int `json:"minutes"`
+		} `json:"sessions"`
+	} `json:"reads"`
+}
+
+func TestReadingLogCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reading Log Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var readingLogRepo domain.ReadingLogRepository
+	var userRepo userDomain.UserRepository
+	var updater userUpdate.Service
+	var deleter userDelete.Service
+	var token string
+
+	send := func(method, path, payload string) (int, *readingLogResponse) {
+		req, err := http.NewRequest(method, server.URL+"/user/interests/"+path, bytes.NewBufferString(payload))
+		Expect(err).ShouldNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		response := &struct {
+			Data *readingLogResponse `json:"data"`
+		}{Data: &readingLogResponse{}}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, response)
+		return resp.StatusCode, response.Data
+	}
+
+	interest := func() string {
+		user, err := userRepo.FindByID(userID)
+		Expect(err).ShouldNot(HaveOccurred())
+		return user.Interests[bookID]
+	}
+
+	BeforeEach(func() {
+		readingLogRepo = persistence.NewInMemoryReadingLogRepository()
+		bookRepo := bookPersistence.NewInMemoryBookRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		bus := eventbus.NewInMemoryBus()
+		updater = userUpdate.NewService(userRepo, bus)
+		deleter = userDelete.NewService(userRepo, bus)
+
+		book, _ := bookDomain.NewBook(bookID, "Dune", "Spice", "Frank Herbert", "scifi", 200)
+		bookRepo.Save(book)
+		user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+		userRepo.Save(user)
+
+		generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+		Expect(err).ShouldNot(HaveOccurred())
+		auth.CreateAuth(userID, generateAuth)
+		token = generateAuth.AccessToken
+
+		server = httptest.NewServer(setupServer(readingLogRepo, bookRepo, updater, bus))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /user/interests/:book_id", func() {
+		It("Returns an 401 status code without token", func() {
+			resp, err := http.Get(server.URL + "/user/interests/" + bookID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Returns 404 if the book has no reading log", func() {
+			status, _ := send(http.MethodGet, bookID, "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+		It("Returns 400 with an invalid book id", func() {
+			status, _ := send(http.MethodGet, "not-a-uuid", "")
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("When the reading is tracked", func() {
+		It("Starts a read and marks the book as reading", func() {
+			status, readingLog := send(http.MethodPost, bookID+"/start", "")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.Status).Should(Equal("reading"))
+			Expect(readingLog.Pages).Should(Equal(200))
+			Expect(interest()).Should(Equal("reading"))
+
+			status, _ = send(http.MethodPost, bookID+"/start", "")
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+		It("Returns 404 if the book does not exist", func() {
+			status, _ := send(http.MethodPost, missingBookID+"/start", "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+		It("Updates the progress by page or percentage", func() {
+			status, readingLog := send(http.MethodPatch, bookID+"/progress", `{"page": 50}`)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.CurrentPage).Should(Equal(50))
+			Expect(readingLog.Percentage).Should(Equal(25.0))
+			Expect(readingLog.Status).Should(Equal("reading"))
+
+			status, readingLog = send(http.MethodPatch, bookID+"/progress", `{"percentage": 60}`)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.CurrentPage).Should(Equal(120))
+		})
+		It("Validates the progress", func() {
+			status, _ := send(http.MethodPatch, bookID+"/progress", `{}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+			status, _ = send(http.MethodPatch, bookID+"/progress", `{"page": 201}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+			status, _ = send(http.MethodPatch, bookID+"/progress", `{"percentage": 101}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+			future := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+			status, _ = send(http.MethodPatch, bookID+"/progress", `{"page": 10, "date": "`+future+`"}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+		It("Logs sessions from the current page", func() {
+			send(http.MethodPost, bookID+"/sessions", `{"to_page": 30, "minutes": 25}`)
+			status, readingLog := send(http.MethodPost, bookID+"/sessions", `{"to_page": 80, "minutes": 40}`)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.CurrentPage).Should(Equal(80))
+			Expect(readingLog.Reads).Should(HaveLen(1))
+			sessions := readingLog.Reads[0].Sessions
+			Expect(sessions).Should(HaveLen(2))
+			Expect(sessions[1].FromPage).Should(Equal(30))
+			Expect(sessions[1].PagesRead).Should(Equal(50))
+
+			status, _ = send(http.MethodPost, bookID+"/sessions", `{"from_page": 90, "to_page": 80}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+		It("Finishes the read on the last page and counts re-reads", func() {
+			status, readingLog := send(http.MethodPatch, bookID+"/progress", `{"page": 200}`)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.Status).Should(Equal("done"))
+			Expect(readingLog.TimesRead).Should(Equal(1))
+			Expect(interest()).Should(Equal("done"))
+
+			send(http.MethodPost, bookID+"/start", "")
+			Expect(interest()).Should(Equal("reading"))
+			status, readingLog = send(http.MethodPost, bookID+"/finish", "")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.TimesRead).Should(Equal(2))
+			Expect(readingLog.Reads).Should(HaveLen(2))
+			Expect(readingLog.CurrentPage).Should(Equal(200))
+		})
+		It("Finishes a read with a past date", func() {
+			started := time.Now().Add(-72 * time.Hour).Format(time.RFC3339)
+			finished := time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+			send(http.MethodPost, bookID+"/start", `{"date": "`+started+`"}`)
+			status, readingLog := send(http.MethodPost, bookID+"/finish", `{"date": "`+finished+`"}`)
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.Reads[0].FinishedOn).ShouldNot(BeNil())
+
+			before := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+			status, _ = send(http.MethodPost, bookID+"/start", `{"date": "`+before+`"}`)
+			Expect(status).Should(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("When the interest status is changed", func() {
+		It("Starts, finishes and queues reads in the log", func() {
+			updater.UpdateUserInterests(&userUpdate.UserInterestsCommand{UserID: userID, BookID: bookID, Status: "reading"})
+			status, readingLog := send(http.MethodGet, bookID, "")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(readingLog.Status).Should(Equal("reading"))
+
+			updater.UpdateUserInterests(&userUpdate.UserInterestsCommand{UserID: userID, BookID: bookID, Status: "done"})
+			_, readingLog = send(http.MethodGet, bookID, "")
+			Expect(readingLog.Status).Should(Equal("done"))
+			Expect(readingLog.TimesRead).Should(Equal(1))
+
+			updater.UpdateUserInterests(&userUpdate.UserInterestsCommand{UserID: userID, BookID: bookID, Status: "reading"})
+			updater.UpdateUserInterests(&userUpdate.UserInterestsCommand{UserID: userID, BookID: bookID, Status: "pending"})
+			_, readingLog = send(http.MethodGet, bookID, "")
+			Expect(readingLog.Status).Should(Equal("pending"))
+			Expect(readingLog.Reads).Should(HaveLen(2))
+			Expect(readingLog.Reads[1].AbandonedOn).ShouldNot(BeNil())
+		})
+		It("Deletes the log with the interest", func() {
+			send(http.MethodPost, bookID+"/start", "")
+			Expect(deleter.DeleteUserInterests(userID, bookID)).Should(Succeed())
+			status, _ := send(http.MethodGet, bookID, "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+	})
+})
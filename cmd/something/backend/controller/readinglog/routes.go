@@ -0,0 +1,27 @@
+package readinglog
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/readinglog/application/find"
+	"something/internal/readinglog/application/track"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	finder find.Service,
+	tracker track.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	logRouter := router.Group("/user/interests/:book_id", m.TokenAuthMiddleware(accessKeys, auth))
+	{
+		logRouter.GET("", GetReadingLogController(finder))
+		logRouter.POST("/start", StartController(tracker))
+		logRouter.POST("/finish", FinishController(tracker))
+		logRouter.PATCH("/progress", ProgressController(tracker))
+		logRouter.POST("/sessions", SessionController(tracker))
+	}
+}
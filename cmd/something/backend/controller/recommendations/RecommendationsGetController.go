@@ -0,0 +1,44 @@
+package recommendations
+
+import (
+	"net/http"
+	"something/internal/recommendations/application/find"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecommendationsController ...
+func GetRecommendationsController(finder find.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		recommendations, err := finder.Recommend(&find.Criteria{
+			UserID: userID.(string),
+			Limit:  limit,
+		})
+		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": recommendations,
+		})
+		return
+	}
+}
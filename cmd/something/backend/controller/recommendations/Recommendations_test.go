@@ -0,0 +1,184 @@
+package recommendations
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookReviewPersistence "something/internal/bookreviews/infraestructure/persistence"
+	bookDomain "something/internal/books/domain"
+	bookPersistence "something/internal/books/infraestructure/persistence"
+	"something/internal/recommendations/application/find"
+	userFollowDomain "something/internal/userfollow/domain"
+	userFollowPersistence "something/internal/userfollow/infraestructure/persistence"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const followedID = "4d1a5a2e-3c59-4f3b-9b57-0e2f7c6b8a11"
+const similarID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+
+func setupServer(
+	bookRepo bookDomain.BookRepository,
+	bookReviewRepo bookReviewDomain.BookReviewRepository,
+	userRepo userDomain.UserRepository,
+	userFollowRepo userFollowDomain.UserFollowRepository,
+) *gin.Engine {
+	router := gin.Default()
+	finder := find.NewService(bookRepo, bookReviewRepo, userRepo, userFollowRepo)
+	RegisterRoutes(finder, tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+type recommendationsResponse struct {
+	Data []struct {
+		BookID  string   `json:"book_id"`
+		Score   float64  `json:"score"`
+		Reasons []string `json:"reasons"`
+	} `json:"data"`
+}
+
+func TestRecommendationsCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Recommendations Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var bookRepo bookDomain.BookRepository
+	var bookReviewRepo bookReviewDomain.BookReviewRepository
+	var userRepo userDomain.UserRepository
+	var userFollowRepo userFollowDomain.UserFollowRepository
+	var token string
+
+	saveBook := func(id, genre, author string) {
+		book, _ := bookDomain.NewBook(id, "title "+id, "description", author, genre, 100)
+		bookRepo.Save(book)
+	}
+	saveReview := func(id, bookID, userID string, rating float64) {
+		review, _ := bookReviewDomain.NewBookReview(id, "abc", rating, bookID, userID)
+		bookReviewRepo.Save(review)
+	}
+	getRecommendations := func(query string) (int, *recommendationsResponse) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/user/recommendations"+query, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		recommendations := &recommendationsResponse{}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, recommendations)
+		return resp.StatusCode, recommendations
+	}
+
+	BeforeEach(func() {
+		bookRepo = bookPersistence.NewInMemoryBookRepository()
+		bookReviewRepo = bookReviewPersistence.NewInMemoryBookReviewsRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		userFollowRepo = userFollowPersistence.NewInMemoryUserFollowRepository()
+
+		generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+		Expect(err).ShouldNot(HaveOccurred())
+		auth.CreateAuth(userID, generateAuth)
+		token = generateAuth.AccessToken
+
+		server = httptest.NewServer(setupServer(bookRepo, bookReviewRepo, userRepo, userFollowRepo))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /user/recommendations", func() {
+		It("Returns an 401 status code without token", func() {
+			resp, err := http.Get(server.URL + "/user/recommendations")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Returns an 404 status code in non existing user", func() {
+			status, _ := getRecommendations("")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+		It("Returns empty array data without signals", func() {
+			user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			saveBook("a", "fantasy", "x")
+
+			status, recommendations := getRecommendations("")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(recommendations.Data).Should(BeEmpty())
+		})
+		It("Combines affinity, follows and similar readers excluding known books", func() {
+			user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			saveBook("read", "fantasy", "x")
+			saveBook("pending", "fantasy", "x")
+			saveBook("same-genre", "fantasy", "y")
+			saveBook("same-author", "scifi", "x")
+			saveBook("followed-pick", "romance", "z")
+			saveBook("similar-pick", "poetry", "w")
+			saveBook("unrelated", "history", "v")
+			userRepo.UpdateInterests(userID, "read", "done")
+			userRepo.UpdateInterests(userID, "pending", "pending")
+
+			follow, _ := userFollowDomain.NewUserFollow(userID, followedID)
+			userFollowRepo.Follow(follow)
+			saveReview("1", "followed-pick", followedID, 5)
+			saveReview("2", "unrelated", followedID, 2)
+
+			saveReview("3", "read", userID, 5)
+			saveReview("4", "read", similarID, 5)
+			saveReview("5", "similar-pick", similarID, 4.5)
+
+			status, recommendations := getRecommendations("")
+			Expect(status).Should(Equal(http.StatusOK))
+
+			reasons := map[string][]string{}
+			for _, recommendation := range recommendations.Data {
+				Expect(recommendation.Score).Should(BeNumerically(">", 0))
+				reasons[recommendation.BookID] = recommendation.Reasons
+			}
+			Expect(reasons).Should(HaveLen(4))
+			Expect(reasons["same-genre"]).Should(Equal([]string{"genre"}))
+			Expect(reasons["same-author"]).Should(Equal([]string{"author"}))
+			Expect(reasons["followed-pick"]).Should(Equal([]string{"following"}))
+			Expect(reasons["similar-pick"]).Should(Equal([]string{"similar_readers"}))
+		})
+		It("Limits the number of recommendations", func() {
+			user, _ := userDomain.NewUser(userID, "madison", "madison1", "madison@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			saveBook("read", "fantasy", "x")
+			saveBook("b", "fantasy", "y")
+			saveBook("c", "fantasy", "z")
+			saveBook("d", "scifi", "x")
+			userRepo.UpdateInterests(userID, "read", "done")
+
+			status, recommendations := getRecommendations("?limit=2")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(recommendations.Data).Should(HaveLen(2))
+			// Author affinity weighs more than genre affinity
+			Expect(recommendations.Data[0].BookID).Should(Equal("d"))
+		})
+	})
+})
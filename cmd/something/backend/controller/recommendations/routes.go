@@ -0,0 +1,18 @@
+package recommendations
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/recommendations/application/find"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	finder find.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	router.GET("/user/recommendations", m.TokenAuthMiddleware(accessKeys, auth), GetRecommendationsController(finder))
+}
@@ -0,0 +1,92 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/books"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlShelfBookParameters struct {
+	ShelfID string `uri:"shelf_id" binding:"required,uuid"`
+	BookID  string `uri:"book_id" binding:"required,uuid"`
+}
+
+// PutBookController ...
+func PutBookController(shelfBooks books.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfBookParameters
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request books.ShelfBookCommand
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&request); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.ShelfID = param.ShelfID
+		request.BookID = param.BookID
+		request.UserID = userID.(string)
+
+		if err := shelfBooks.AddBook(&request); err != nil {
+			shelfBookError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
+
+// DeleteBookController ...
+func DeleteBookController(shelfBooks books.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfBookParameters
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		if err := shelfBooks.RemoveBook(param.ShelfID, userID.(string), param.BookID); err != nil {
+			shelfBookError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
+
+func shelfBookError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "shelf not found", "book not found", "book not in shelf":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "Something wrong happened, try again later ...",
+	})
+}
@@ -0,0 +1,49 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/delete"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteController ...
+func DeleteController(deleter delete.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		err := deleter.DeleteShelf(param.ID, userID.(string))
+		if err != nil {
+			switch err.Error() {
+			case "shelf not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			case "default shelves can't be deleted":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
@@ -0,0 +1,41 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/find"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlShelfParameter struct {
+	ID string `uri:"shelf_id" binding:"required,uuid"`
+}
+
+// GetShelfController ...
+func GetShelfController(finder find.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		shelf, err := finder.FindShelf(param.ID, c.GetString("user_id"))
+		if err != nil {
+			if err.Error() == "shelf not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": shelf,
+		})
+		return
+	}
+}
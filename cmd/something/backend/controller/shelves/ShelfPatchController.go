@@ -0,0 +1,60 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/update"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PatchController ...
+func PatchController(updater update.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request update.ShelfCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.ID = param.ID
+		request.UserID = userID.(string)
+
+		err := updater.UpdateShelf(&request)
+		if err != nil {
+			switch err.Error() {
+			case "shelf not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			case "shelf name already exists", "shelf name is required", "default shelves can't be renamed":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
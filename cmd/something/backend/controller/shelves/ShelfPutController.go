@@ -0,0 +1,55 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/create"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PutController ...
+func PutController(creator create.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlShelfParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var request create.ShelfCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		request.ID = param.ID
+		request.UserID = userID.(string)
+
+		err := creator.CreateShelf(&request)
+		if err != nil {
+			switch err.Error() {
+			case "shelf already exists", "shelf name already exists", "shelf name is required":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusCreated)
+		return
+	}
+}
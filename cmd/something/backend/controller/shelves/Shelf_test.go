@@ -0,0 +1,302 @@
+package shelves
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	bookDomain "something/internal/books/domain"
+	bookPersistence "something/internal/books/infraestructure/persistence"
+	"something/internal/shelves/application"
+	"something/internal/shelves/application/books"
+	"something/internal/shelves/application/create"
+	"something/internal/shelves/application/delete"
+	"something/internal/shelves/application/find"
+	"something/internal/shelves/application/status"
+	"something/internal/shelves/application/update"
+	"something/internal/shelves/domain"
+	"something/internal/shelves/infraestructure/persistence"
+	userDelete "something/internal/users/application/delete"
+	userUpdate "something/internal/users/application/update"
+	userDomain "something/internal/users/domain"
+	userPersistence "something/internal/users/infraestructure/persistence"
+	"something/pkg/eventbus"
+	jwt "something/pkg/redisjwt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenParams *jwt.TokenParams = &jwt.TokenParams{
+	AccessKeys:  jwt.NewHMACKeySet("secure-access-token"),
+	RefreshKeys: jwt.NewHMACKeySet("secure-refresh-token"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
+}
+
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+const userID = "c015f5ce-3b42-44c8-8b82-f011b23b989a"
+const otherID = "9b6848af-5e94-44ad-b59c-960c223ee182"
+const shelfID = "0f2b4a6e-1c3d-4e5f-8a9b-0c1d2e3f4a5b"
+const duneID = "c9d6e6f0-27d9-47d2-851e-bb42f72565ed"
+const emmaID = "a3b6f2c1-7d8e-4f90-a1b2-c3d4e5f60718"
+const missingBookID = "4d1a5a2e-3c59-4f3b-9b57-0e2f7c6b8a11"
+const newUserID = "7e5d3c1b-9a8f-4e6d-b2c4-1a3f5e7d9b20"
+
+func setupServer(
+	shelfRepo domain.ShelfRepository,
+	userRepo userDomain.UserRepository,
+	bookRepo bookDomain.BookRepository,
+	bus eventbus.Bus,
+) *gin.Engine {
+	router := gin.Default()
+	interests := userUpdate.NewService(userRepo, bus)
+	interestsDelete := userDelete.NewService(userRepo, bus)
+	status.Subscribe(bus, shelfRepo, userRepo)
+	RegisterRoutes(
+		find.NewService(shelfRepo, userRepo),
+		create.NewService(shelfRepo, userRepo),
+		update.NewService(shelfRepo, userRepo),
+		delete.NewService(shelfRepo),
+		books.NewService(shelfRepo, bookRepo, interests, interestsDelete),
+		tokenParams.AccessKeys, auth, router)
+	return router
+}
+
+// staleRepository misses the shelves on the first read, like a request
+// racing with another one that creates the default shelves
+type staleRepository struct {
+	domain.ShelfRepository
+	read bool
+}
+
+func (r *staleRepository) FindByUserID(userID string) ([]*domain.Shelf, error) {
+	if !r.read {
+		r.read = true
+		return []*domain.Shelf{}, nil
+	}
+	return r.ShelfRepository.FindByUserID(userID)
+}
+
+func TestShelfCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Shelf Suite")
+}
+
+var _ = Describe("Server", func() {
+	var server *httptest.Server
+	var shelfRepo domain.ShelfRepository
+	var userRepo userDomain.UserRepository
+	var bus eventbus.Bus
+	var tokens map[string]string
+
+	send := func(method, path, payload, as string) int {
+		req, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString(payload))
+		Expect(err).ShouldNot(HaveOccurred())
+		if as != "" {
+			req.Header.Set("Authorization", "Bearer "+tokens[as])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	getShelves := func(id, as string) (int, []*application.ShelfResponse) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/users/"+id+"/shelves", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		if as != "" {
+			req.Header.Set("Authorization", "Bearer "+tokens[as])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		response := &struct {
+			Data []*application.ShelfResponse `json:"data"`
+		}{}
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		json.Unmarshal(body, response)
+		return resp.StatusCode, response.Data
+	}
+
+	shelfNamed := func(shelves []*application.ShelfResponse, name string) *application.ShelfResponse {
+		for _, shelf := range shelves {
+			if shelf.Name == name {
+				return shelf
+			}
+		}
+		return nil
+	}
+
+	bookIDs := func(shelf *application.ShelfResponse) []string {
+		ids := []string{}
+		for _, book := range shelf.Books {
+			ids = append(ids, book.BookID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		shelfRepo = persistence.NewInMemoryShelfRepository()
+		userRepo = userPersistence.NewInMemoryUserRepository()
+		bookRepo := bookPersistence.NewInMemoryBookRepository()
+		bus = eventbus.NewInMemoryBus()
+
+		tokens = map[string]string{}
+		for _, id := range []string{userID, otherID} {
+			user, _ := userDomain.NewUser(id, "reader", "reader"+id[:4], id[:4]+"@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			generateAuth, err := jwt.CreateToken(id, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(id, generateAuth)
+			tokens[id] = generateAuth.AccessToken
+		}
+		dune, _ := bookDomain.NewBook(duneID, "Dune", "Spice", "Frank Herbert", "scifi", 400)
+		emma, _ := bookDomain.NewBook(emmaID, "Emma", "Matchmaking", "Jane Austen", "classics", 300)
+		bookRepo.Save(dune)
+		bookRepo.Save(emma)
+
+		server = httptest.NewServer(setupServer(shelfRepo, userRepo, bookRepo, bus))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When GET request is sent to /users/:id/shelves", func() {
+		It("Returns 404 if the user does not exist", func() {
+			status, _ := getShelves(missingBookID, "")
+			Expect(status).Should(Equal(http.StatusNotFound))
+		})
+		It("Returns the default shelves with the user interests", func() {
+			userRepo.UpdateInterests(userID, duneID, "reading")
+			userRepo.UpdateInterests(userID, emmaID, "done")
+
+			status, shelves := getShelves(userID, "")
+			Expect(status).Should(Equal(http.StatusOK))
+			Expect(shelves).Should(HaveLen(3))
+			Expect(shelves[0].Status).Should(Equal("reading"))
+			Expect(shelves[0].Default).Should(BeTrue())
+			Expect(bookIDs(shelves[0])).Should(Equal([]string{duneID}))
+			Expect(bookIDs(shelves[1])).Should(BeEmpty())
+			Expect(bookIDs(shelves[2])).Should(Equal([]string{emmaID}))
+		})
+		It("Hides private shelves from other users", func() {
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "to gift"}`, userID)).Should(Equal(http.StatusCreated))
+
+			_, shelves := getShelves(userID, userID)
+			Expect(shelfNamed(shelves, "to gift")).ShouldNot(BeNil())
+			_, shelves = getShelves(userID, otherID)
+			Expect(shelfNamed(shelves, "to gift")).Should(BeNil())
+			_, shelves = getShelves(userID, "")
+			Expect(shelves).Should(HaveLen(3))
+
+			Expect(send(http.MethodGet, "/shelves/"+shelfID, "", otherID)).Should(Equal(http.StatusNotFound))
+			Expect(send(http.MethodGet, "/shelves/"+shelfID, "", userID)).Should(Equal(http.StatusOK))
+			send(http.MethodPatch, "/user/shelves/"+shelfID, `{"public": true}`, userID)
+			Expect(send(http.MethodGet, "/shelves/"+shelfID, "", "")).Should(Equal(http.StatusOK))
+		})
+	})
+
+	Context("When default shelves are created", func() {
+		It("Creates them on registration", func() {
+			user, _ := userDomain.NewUser(newUserID, "reader", "newreader", "new@example.com", "secret-pass-1")
+			userRepo.Save(user)
+			bus.Publish(userDomain.NewUserRegistered(user))
+
+			shelves, err := shelfRepo.FindByUserID(newUserID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shelves).Should(HaveLen(3))
+		})
+		It("Keeps one shelf per status when requests race", func() {
+			_, err := application.UserShelves(shelfRepo, userRepo, userID)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			shelves, err := application.UserShelves(&staleRepository{ShelfRepository: shelfRepo}, userRepo, userID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shelves).Should(HaveLen(3))
+			stored, _ := shelfRepo.FindByUserID(userID)
+			Expect(stored).Should(HaveLen(3))
+			Expect(shelfRepo.Save(domain.NewDefaultShelf(userID, domain.StatusDone))).ShouldNot(Succeed())
+		})
+	})
+
+	Context("When shelves are managed", func() {
+		It("Returns 401 without token", func() {
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "favourites"}`, "")).Should(Equal(http.StatusUnauthorized))
+		})
+		It("Validates the shelf names", func() {
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": ""}`, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "Reading"}`, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "favourites"}`, userID)).Should(Equal(http.StatusCreated))
+			Expect(send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "other"}`, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(send(http.MethodPut, "/user/shelves/"+missingBookID, `{"name": "Favourites"}`, userID)).Should(Equal(http.StatusBadRequest))
+		})
+		It("Renames and deletes shelves of the owner only", func() {
+			send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "favourites"}`, userID)
+			Expect(send(http.MethodPatch, "/user/shelves/"+shelfID, `{"name": "mine"}`, otherID)).Should(Equal(http.StatusNotFound))
+			Expect(send(http.MethodPatch, "/user/shelves/"+shelfID, `{"name": "book club 2026"}`, userID)).Should(Equal(http.StatusOK))
+			_, shelves := getShelves(userID, userID)
+			Expect(shelfNamed(shelves, "book club 2026")).ShouldNot(BeNil())
+
+			Expect(send(http.MethodDelete, "/user/shelves/"+shelfID, "", otherID)).Should(Equal(http.StatusNotFound))
+			Expect(send(http.MethodDelete, "/user/shelves/"+shelfID, "", userID)).Should(Equal(http.StatusOK))
+			_, shelves = getShelves(userID, userID)
+			Expect(shelves).Should(HaveLen(3))
+		})
+		It("Does not rename or delete default shelves", func() {
+			_, shelves := getShelves(userID, userID)
+			Expect(send(http.MethodPatch, "/user/shelves/"+shelves[0].ID, `{"name": "now"}`, userID)).Should(Equal(http.StatusBadRequest))
+			Expect(send(http.MethodDelete, "/user/shelves/"+shelves[0].ID, "", userID)).Should(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("When books are placed in shelves", func() {
+		It("Orders the books of a shelf", func() {
+			send(http.MethodPut, "/user/shelves/"+shelfID, `{"name": "favourites"}`, userID)
+			path := "/user/shelves/" + shelfID + "/books/"
+			Expect(send(http.MethodPut, path+duneID, "", userID)).Should(Equal(http.StatusOK))
+			Expect(send(http.MethodPut, path+emmaID, `{"position": 0}`, userID)).Should(Equal(http.StatusOK))
+			Expect(send(http.MethodPut, path+missingBookID, "", userID)).Should(Equal(http.StatusNotFound))
+
+			_, shelves := getShelves(userID, userID)
+			Expect(bookIDs(shelfNamed(shelves, "favourites"))).Should(Equal([]string{emmaID, duneID}))
+
+			send(http.MethodPut, path+emmaID, `{"position": 5}`, userID)
+			_, shelves = getShelves(userID, userID)
+			Expect(bookIDs(shelfNamed(shelves, "favourites"))).Should(Equal([]string{duneID, emmaID}))
+
+			Expect(send(http.MethodDelete, path+duneID, "", userID)).Should(Equal(http.StatusOK))
+			Expect(send(http.MethodDelete, path+duneID, "", userID)).Should(Equal(http.StatusNotFound))
+			Expect(send(http.MethodPut, path+duneID, "", otherID)).Should(Equal(http.StatusNotFound))
+		})
+		It("Keeps default shelves and interests in sync", func() {
+			_, shelves := getShelves(userID, userID)
+			reading, pending := shelves[0], shelves[1]
+
+			Expect(send(http.MethodPut, "/user/shelves/"+pending.ID+"/books/"+duneID, "", userID)).Should(Equal(http.StatusOK))
+			user, _ := userRepo.FindByID(userID)
+			Expect(user.Interests[duneID]).Should(Equal("pending"))
+
+			Expect(send(http.MethodPut, "/user/shelves/"+reading.ID+"/books/"+duneID, "", userID)).Should(Equal(http.StatusOK))
+			user, _ = userRepo.FindByID(userID)
+			Expect(user.Interests[duneID]).Should(Equal("reading"))
+			_, shelves = getShelves(userID, userID)
+			Expect(bookIDs(shelves[0])).Should(Equal([]string{duneID}))
+			Expect(bookIDs(shelves[1])).Should(BeEmpty())
+
+			Expect(send(http.MethodDelete, "/user/shelves/"+reading.ID+"/books/"+duneID, "", userID)).Should(Equal(http.StatusOK))
+			user, _ = userRepo.FindByID(userID)
+			Expect(user.Interests).ShouldNot(HaveKey(duneID))
+			_, shelves = getShelves(userID, userID)
+			Expect(bookIDs(shelves[0])).Should(BeEmpty())
+		})
+	})
+})
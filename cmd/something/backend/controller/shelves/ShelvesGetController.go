@@ -0,0 +1,41 @@
+package shelves
+
+import (
+	"net/http"
+	"something/internal/shelves/application/find"
+
+	"github.com/gin-gonic/gin"
+)
+
+type urlUserParameter struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// GetUserShelvesController ...
+func GetUserShelvesController(finder find.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlUserParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		shelves, err := finder.FindUserShelves(param.ID, c.GetString("user_id"))
+		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": shelves,
+		})
+		return
+	}
+}
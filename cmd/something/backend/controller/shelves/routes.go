@@ -0,0 +1,35 @@
+package shelves
+
+import (
+	m "something/cmd/something/backend/controller/middlewares"
+	"something/internal/shelves/application/books"
+	"something/internal/shelves/application/create"
+	"something/internal/shelves/application/delete"
+	"something/internal/shelves/application/find"
+	"something/internal/shelves/application/update"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes ...
+func RegisterRoutes(
+	finder find.Service,
+	creator create.Service,
+	updater update.Service,
+	deleter delete.Service,
+	shelfBooks books.Service,
+	accessKeys jwt.KeySet,
+	auth jwt.AuthRepository,
+	router *gin.Engine) {
+	router.GET("/users/:id/shelves", m.TokenAuthOptionalMiddleware(accessKeys, auth), GetUserShelvesController(finder))
+	router.GET("/shelves/:shelf_id", m.TokenAuthOptionalMiddleware(accessKeys, auth), GetShelfController(finder))
+	shelvesRouter := router.Group("/user/shelves", m.TokenAuthMiddleware(accessKeys, auth))
+	{
+		shelvesRouter.PUT("/:shelf_id", PutController(creator))
+		shelvesRouter.PATCH("/:shelf_id", PatchController(updater))
+		shelvesRouter.DELETE("/:shelf_id", DeleteController(deleter))
+		shelvesRouter.PUT("/:shelf_id/books/:book_id", PutBookController(shelfBooks))
+		shelvesRouter.DELETE("/:shelf_id/books/:book_id", DeleteBookController(shelfBooks))
+	}
+}
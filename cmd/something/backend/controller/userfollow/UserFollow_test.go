@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	"something/pkg/eventbus"
 	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
@@ -25,12 +26,14 @@ import (
 )
 
 var tokenParams *jwt.TokenParams = &jwt.TokenParams{
-	AccessSecret:  "2TkA87mUUU2pT1j2anRmF72sO",
-	RefreshSecret: "xW7xXMWtDv5sDTxEwVFZitjBt",
-	AccessTime:    time.Minute * 1,
-	RefreshTime:   time.Minute * 1,
+	AccessKeys:  jwt.NewHMACKeySet("2TkA87mUUU2pT1j2anRmF72sO"),
+	RefreshKeys: jwt.NewHMACKeySet("xW7xXMWtDv5sDTxEwVFZitjBt"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
 }
 
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
 func TestUserFollowCheck(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "User Follow Suite")
@@ -40,10 +43,11 @@ func setupServer(
 	userFollowRepo domain.UserFollowRepository,
 	userRepo userDomain.UserRepository) *gin.Engine {
 	router := gin.Default()
+	bus := eventbus.NewInMemoryBus()
 	userFinder := userFind.NewService(userRepo)
 	finder := find.NewService(userFollowRepo)
-	follow := followers.NewService(userFollowRepo)
-	RegisterRoutes(finder, userFinder, follow, tokenParams, router)
+	follow := followers.NewService(userFollowRepo, bus)
+	RegisterRoutes(finder, userFinder, follow, tokenParams, auth, router)
 	return router
 }
 
@@ -193,9 +197,12 @@ var _ = Describe("Server", func() {
 				"03de8950-2a96-453c-bc71-29e7487a55cd",
 				"james", "james1", "james@example.com",
 				"super-strong-password")
+			anotherUser.Verified = true
 			userRepo.Save(newUser)
+			userRepo.Save(anotherUser)
 
 			generateAuth, err := jwt.CreateToken(anotherUser.ID, anotherUser.Role, tokenParams)
+			auth.CreateAuth(anotherUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPost,
 				server.URL+"/user/follow/"+newUser.ID,
@@ -207,15 +214,50 @@ var _ = Describe("Server", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
 		})
+		It("return an 403 status code when the email isn't verified", func() {
+			newUser, _ := userDomain.NewUser(
+				"1f2e3d4c-5b6a-4978-8877-665544332211",
+				"madison", "madison1", "madison@example.com",
+				"super-secure-password")
+			unverifiedUser, _ := userDomain.NewUser(
+				"2e3d4c5b-6a79-4887-9766-554433221100",
+				"james", "james1", "james@example.com",
+				"super-strong-password")
+			userRepo.Save(newUser)
+			userRepo.Save(unverifiedUser)
+
+			generateAuth, err := jwt.CreateToken(unverifiedUser.ID, unverifiedUser.Role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(unverifiedUser.ID, generateAuth)
+			req, err := http.NewRequest(
+				http.MethodPost,
+				server.URL+"/user/follow/"+newUser.ID,
+				nil,
+			)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"email not verified"}`))
+
+			following, _ := userFollowRepo.FindFollowing(unverifiedUser.ID)
+			Expect(following).Should(BeEmpty())
+		})
 		It("return an 404 status code in non existing user", func() {
 			nonExistingUserID := "5b9eb022-6445-43d4-8e35-b0e9a6e97275"
 			newUser, _ := userDomain.NewUser(
 				"68a004c8-e1c1-49c0-a430-66f9cf6fd1ad",
 				"dante", "dante06", "dante@gmail.com",
 				"dante-secure-password")
+			newUser.Verified = true
 			userRepo.Save(newUser)
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPost,
 				server.URL+"/user/follow/"+nonExistingUserID,
@@ -245,6 +287,7 @@ var _ = Describe("Server", func() {
 			userFollowRepo.Follow(userFollow)
 
 			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			auth.CreateAuth(userID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPost,
 				server.URL+"/user/unfollow/"+newUser.ID,
@@ -265,6 +308,7 @@ var _ = Describe("Server", func() {
 			userRepo.Save(newUser)
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPost,
 				server.URL+"/user/unfollow/"+nonExistingUserID,
@@ -283,4 +327,81 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Context("When DELETE request is sent to /users/:id/followers/:follower_id", func() {
+		var removeFollower = func(userID, followerID, token string) *http.Response {
+			req, err := http.NewRequest(
+				http.MethodDelete,
+				server.URL+"/users/"+userID+"/followers/"+followerID,
+				nil,
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp
+		}
+
+		It("lets the user remove an existing follower", func() {
+			userID := "0f0b2b4e-7c7b-4f43-9c36-3c1bd1bb9a10"
+			followerID := "9a5d5c39-7d8a-4b0e-8f76-0b6c0f1f8f42"
+			userFollow, _ := domain.NewUserFollow(followerID, userID)
+			userFollowRepo.Follow(userFollow)
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			resp := removeFollower(userID, followerID, generateAuth.AccessToken)
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+
+			userFollowers, err := userFollowRepo.FindFollowers(userID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(userFollowers).Should(BeEmpty())
+		})
+		It("lets admins remove followers of other users", func() {
+			userID := "3d1f3a0a-1f57-4a9c-9d1d-2f7c7e0d6b11"
+			followerID := "c2a6e7b4-0d4f-4c5e-b6a1-7d3f2e9a8c55"
+			adminID := "b8e0c4d2-3a5f-4e6b-9c7d-1e2f3a4b5c66"
+			userFollow, _ := domain.NewUserFollow(followerID, userID)
+			userFollowRepo.Follow(userFollow)
+
+			generateAuth, err := jwt.CreateToken(adminID, "admin", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(adminID, generateAuth)
+			resp := removeFollower(userID, followerID, generateAuth.AccessToken)
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+		})
+		It("return an 403 status code when removing followers of other users", func() {
+			userID := "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a77"
+			followerID := "6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8b88"
+			userFollow, _ := domain.NewUserFollow(followerID, userID)
+			userFollowRepo.Follow(userFollow)
+
+			generateAuth, err := jwt.CreateToken(followerID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(followerID, generateAuth)
+			resp := removeFollower(userID, followerID, generateAuth.AccessToken)
+			Expect(resp.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"forbidden"}`))
+		})
+		It("return an 404 status code in non existing follower", func() {
+			userID := "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c99"
+			followerID := "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d00"
+
+			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			resp := removeFollower(userID, followerID, generateAuth.AccessToken)
+			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"follower not found"}`))
+		})
+	})
+
 })
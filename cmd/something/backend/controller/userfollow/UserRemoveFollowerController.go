@@ -0,0 +1,56 @@
+package userfollow
+
+import (
+	"net/http"
+	"something/internal/userfollow/application/find"
+	"something/internal/userfollow/application/followers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type followerURLParameter struct {
+	ID         string `uri:"id" binding:"required,uuid"`
+	FollowerID string `uri:"follower_id" binding:"required,uuid"`
+}
+
+// RemoveFollowerController makes a follower stop following the user
+func RemoveFollowerController(finder find.Service, uc followers.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param followerURLParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userFollowers, err := finder.Followers(param.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		found := false
+		for _, follower := range userFollowers {
+			if follower.From == param.FollowerID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "follower not found",
+			})
+			return
+		}
+
+		err = uc.Unfollow(param.FollowerID, param.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+}
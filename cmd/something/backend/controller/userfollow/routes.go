@@ -5,6 +5,7 @@ import (
 	"something/internal/userfollow/application/find"
 	"something/internal/userfollow/application/followers"
 	userFind "something/internal/users/application/find"
+	userDomain "something/internal/users/domain"
 	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
@@ -16,9 +17,14 @@ func RegisterRoutes(
 	userFinder userFind.Service,
 	follow followers.Service,
 	tokenParams *jwt.TokenParams,
+	auth jwt.AuthRepository,
 	router *gin.Engine) {
 	router.GET("/users/:id/followers", GetFollowersController(finder, userFinder))
 	router.GET("/users/:id/following", GetFollowingController(finder, userFinder))
-	router.POST("/user/follow/:id", m.TokenAuthMiddleware(tokenParams.AccessSecret), FollowController(follow, userFinder))
-	router.POST("/user/unfollow/:id", m.TokenAuthMiddleware(tokenParams.AccessSecret), UnfollowController(follow, userFinder))
+	router.POST("/user/follow/:id", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), m.VerifiedUserMiddleware(userFinder), FollowController(follow, userFinder))
+	router.POST("/user/unfollow/:id", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), UnfollowController(follow, userFinder))
+	router.DELETE("/users/:id/followers/:follower_id",
+		m.TokenAuthMiddleware(tokenParams.AccessKeys, auth),
+		m.OwnerOrPermissionMiddleware(userDomain.PermissionUsersAdmin),
+		RemoveFollowerController(finder, follow))
 }
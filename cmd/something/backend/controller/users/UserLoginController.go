@@ -9,7 +9,7 @@ import (
 )
 
 // LoginController ...
-func LoginController(usecase login.Service, tokenParams *jwt.TokenParams) func(c *gin.Context) {
+func LoginController(usecase login.Service, tokenParams *jwt.TokenParams, auth jwt.AuthRepository) func(c *gin.Context) {
 	return func(c *gin.Context) {
 
 		var request login.Command
@@ -22,12 +22,12 @@ func LoginController(usecase login.Service, tokenParams *jwt.TokenParams) func(c
 			return
 		}
 
+		request.IP = c.ClientIP()
+
 		user, err := usecase.Login(&request)
 		if err != nil {
-			if err.Error() == "email not found" {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error": err.Error(),
-				})
+			if locked, ok := err.(*login.LockedError); ok {
+				tooManyAttempts(c, locked.RetryAfter, locked.Error())
 				return
 			} else if err.Error() == "invalid email or password" {
 				c.JSON(http.StatusUnauthorized, gin.H{
@@ -45,6 +45,10 @@ func LoginController(usecase login.Service, tokenParams *jwt.TokenParams) func(c
 			c.JSON(http.StatusUnprocessableEntity, err.Error())
 			return
 		}
+		if err := auth.CreateAuth(user.ID, ts); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		tokens := map[string]string{
 			"access_token":  ts.AccessToken,
 			"refresh_token": ts.RefreshToken,
@@ -0,0 +1,41 @@
+package users
+
+import (
+	"net/http"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogoutController ...
+func LogoutController(auth jwt.AuthRepository) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		accessUUID, ok := c.Get("access_uuid")
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
+		err := auth.DeleteTokens(&jwt.AccessDetails{
+			AccessUUID: accessUUID.(string),
+			UserID:     userID.(string),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
@@ -0,0 +1,33 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/password"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordForgotController mails a reset token, the response is the same
+// whether the email is registered or not
+func PasswordForgotController(usecase password.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request password.ForgotCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := usecase.Forgot(&request); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusAccepted)
+		return
+	}
+}
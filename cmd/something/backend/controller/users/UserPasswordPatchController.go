@@ -0,0 +1,70 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/password"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordPatchController changes the password of the logged user, every
+// open session is revoked and the response carries a new token pair
+func PasswordPatchController(
+	usecase password.Service,
+	tokenParams *jwt.TokenParams,
+	auth jwt.AuthRepository) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request password.ChangeCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request.UserID = c.GetString("user_id")
+
+		user, err := usecase.Change(&request)
+		if err != nil {
+			switch err.Error() {
+			case "user not found":
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			case "invalid current password":
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+			}
+			return
+		}
+
+		if err := auth.RevokeSessions(request.UserID, tokenParams.RefreshTime); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		// The role is read again, the one of the old token may be outdated
+		ts, err := jwt.CreateToken(user.ID, user.Role, tokenParams)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := auth.CreateAuth(request.UserID, ts); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		tokens := map[string]string{
+			"access_token":  ts.AccessToken,
+			"refresh_token": ts.RefreshToken,
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"tokens": tokens,
+		})
+		return
+	}
+}
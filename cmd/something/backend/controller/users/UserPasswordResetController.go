@@ -0,0 +1,50 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/password"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetController sets a new password with a reset token and
+// revokes every open session of the user
+func PasswordResetController(
+	usecase password.Service,
+	tokenParams *jwt.TokenParams,
+	auth jwt.AuthRepository) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request password.ResetCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := usecase.Reset(&request)
+		if err != nil {
+			switch err.Error() {
+			case "invalid or expired token":
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+			}
+			return
+		}
+
+		if err := auth.RevokeSessions(user.ID, tokenParams.RefreshTime); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
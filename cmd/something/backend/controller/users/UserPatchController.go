@@ -16,20 +16,6 @@ func PatchController(us update.Service) func(c *gin.Context) {
 			return
 		}
 
-		userID, ok := c.Get("user_id")
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Something wrong happened, try again later ...",
-			})
-			return
-		}
-		if param.ID != userID.(string) {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "unauthorized",
-			})
-			return
-		}
-
 		var request update.UserCommand
 		if err := c.ShouldBindJSON(&request); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -39,6 +25,12 @@ func PatchController(us update.Service) func(c *gin.Context) {
 
 		err := us.UpdateUserByID(&request)
 		if err != nil {
+			if err.Error() == "user not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Something wrong happened, try again later ...",
 			})
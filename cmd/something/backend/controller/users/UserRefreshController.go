@@ -0,0 +1,77 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/find"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshController ...
+func RefreshController(finder find.Service, tokenParams *jwt.TokenParams, auth jwt.AuthRepository) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request refreshRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rd, err := jwt.ExtractRefreshMetadata(request.RefreshToken, tokenParams.RefreshKeys)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		userID, err := auth.FetchRefresh(rd)
+		if err != nil || userID != rd.UserID {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		// Rotate: the old refresh token (and its access token) can't be used again
+		deleted, err := auth.DeleteAuth(rd.RefreshUUID)
+		if err != nil || deleted == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+		auth.DeleteAuth(jwt.AccessUUID(rd.RefreshUUID))
+
+		user, err := finder.FindUserByID(rd.UserID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		ts, err := jwt.CreateToken(user.ID, user.Role, tokenParams)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := auth.CreateAuth(user.ID, ts); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		tokens := map[string]string{
+			"access_token":  ts.AccessToken,
+			"refresh_token": ts.RefreshToken,
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"tokens": tokens,
+		})
+		return
+	}
+}
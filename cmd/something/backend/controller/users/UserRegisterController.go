@@ -3,13 +3,33 @@ package users
 import (
 	"net/http"
 	"something/internal/users/application/create"
+	"something/pkg/lockout"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterController ...
-func RegisterController(creator create.Service) func(c *gin.Context) {
+// RegisterController creates users, every registration counts as an
+// attempt of the client IP so mass registrations get locked out
+func RegisterController(creator create.Service, registrations lockout.Limiter) func(c *gin.Context) {
 	return func(c *gin.Context) {
+		retryAfter, err := registrations.Check(c.ClientIP())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		if retryAfter > 0 {
+			tooManyAttempts(c, retryAfter, "too many registrations, try again later")
+			return
+		}
+		if _, err := registrations.Fail(c.ClientIP()); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+
 		var param urlParameter
 		if err := c.ShouldBindUri(&param); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -27,7 +47,7 @@ func RegisterController(creator create.Service) func(c *gin.Context) {
 		}
 		request.ID = param.ID
 
-		err := creator.CreateUser(&request)
+		err = creator.CreateUser(&request)
 		if err != nil {
 			if err.Error() == "email already in use" ||
 				err.Error() == "username already in use" ||
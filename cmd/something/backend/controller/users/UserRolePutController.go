@@ -0,0 +1,48 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RolePutController assigns a role to a user
+func RolePutController(assigner roles.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var param urlParameter
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var request roles.RoleCommand
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request.UserID = param.ID
+		request.AdminID = c.GetString("user_id")
+
+		err := assigner.AssignRole(&request)
+		if err != nil {
+			switch err.Error() {
+			case "user not found":
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			case "role not found", "can't change your own role":
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+			}
+			return
+		}
+		c.Status(http.StatusOK)
+		return
+	}
+}
@@ -0,0 +1,91 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/social"
+	jwt "something/pkg/redisjwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SocialLoginController redirects the user to the login page of the
+// provider
+func SocialLoginController(usecase social.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		authURL, err := usecase.Begin(c.Param("provider"))
+		if err != nil {
+			if err.Error() == "provider not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+// SocialCallbackController finishes the login when the provider redirects
+// the user back, it answers like LoginController
+func SocialCallbackController(usecase social.Service, tokenParams *jwt.TokenParams, auth jwt.AuthRepository) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var request social.CallbackCommand
+		if err := c.ShouldBindQuery(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := request.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request.Provider = c.Param("provider")
+
+		user, err := usecase.Callback(&request)
+		if err != nil {
+			switch err.Error() {
+			case "provider not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+			case "invalid or expired state",
+				"login denied by the provider",
+				"identity could not be verified":
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": err.Error(),
+				})
+			case "email already in use",
+				"the provider didn't share the email":
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+			}
+			return
+		}
+		ts, err := jwt.CreateToken(user.ID, user.Role, tokenParams)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err := auth.CreateAuth(user.ID, ts); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		tokens := map[string]string{
+			"access_token":  ts.AccessToken,
+			"refresh_token": ts.RefreshToken,
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user":   user,
+			"tokens": tokens,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package users
+
+import (
+	"net/http"
+	"something/internal/users/application/verification"
+
+	"github.com/gin-gonic/gin"
+)
+
+type verifyQuery struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// VerifyController confirms the email of the user the token was sent to
+func VerifyController(verifier verification.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var query verifyQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := verifier.Verify(query.Token)
+		if err != nil {
+			if err.Error() == "invalid or expired token" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Something wrong happened, try again later ...",
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": user,
+		})
+		return
+	}
+}
+
+// SendVerificationController mails a new verification token to the
+// logged user
+func SendVerificationController(verifier verification.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if err := verifier.Send(c.GetString("user_id")); err != nil {
+			switch err.Error() {
+			case "user not found":
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			case "user already verified":
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Something wrong happened, try again later ...",
+				})
+			}
+			return
+		}
+		c.Status(http.StatusAccepted)
+		return
+	}
+}
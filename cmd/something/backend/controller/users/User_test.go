@@ -2,28 +2,44 @@ package users
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"something/config"
-	bookReviewFind "something/internal/bookreviews/application/find"
+	"net/url"
+	"regexp"
+	bookReviewCascade "something/internal/bookreviews/application/cascade"
+	bookReviewDelete "something/internal/bookreviews/application/delete"
+	bookReviewRating "something/internal/bookreviews/application/rating"
 	bookReviewDomain "something/internal/bookreviews/domain"
 	bookReviewPersistence "something/internal/bookreviews/infraestructure/persistence"
 	bookFind "something/internal/books/application/find"
 	bookDomain "something/internal/books/domain"
 	bookPersistence "something/internal/books/infraestructure/persistence"
+	userFollowCascade "something/internal/userfollow/application/cascade"
+	userFollowDomain "something/internal/userfollow/domain"
+	userFollowPersistence "something/internal/userfollow/infraestructure/persistence"
 	"something/internal/users/application/create"
 	"something/internal/users/application/delete"
 	"something/internal/users/application/find"
 	"something/internal/users/application/login"
+	"something/internal/users/application/password"
+	"something/internal/users/application/roles"
+	"something/internal/users/application/social"
 	"something/internal/users/application/update"
+	"something/internal/users/application/verification"
 	"something/internal/users/domain"
 	"something/internal/users/infraestructure/persistence"
 	"something/pkg/crypto"
+	"something/pkg/eventbus"
+	"something/pkg/lockout"
+	"something/pkg/mail"
+	"something/pkg/oidc"
+	"something/pkg/oidc/oidctest"
 	jwt "something/pkg/redisjwt"
+	"something/pkg/session"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,12 +49,34 @@ import (
 )
 
 var tokenParams *jwt.TokenParams = &jwt.TokenParams{
-	AccessSecret:  "2TkA87mUUU2pT1j2anRmF72sO",
-	RefreshSecret: "xW7xXMWtDv5sDTxEwVFZitjBt",
-	AccessTime:    time.Minute * 1,
-	RefreshTime:   time.Minute * 1,
+	AccessKeys:  jwt.NewHMACKeySet("2TkA87mUUU2pT1j2anRmF72sO"),
+	RefreshKeys: jwt.NewHMACKeySet("xW7xXMWtDv5sDTxEwVFZitjBt"),
+	AccessTime:  time.Minute * 1,
+	RefreshTime: time.Minute * 1,
 }
 
+var auth jwt.AuthRepository = jwt.NewInMemoryAuth()
+
+// outboxSender keeps the sent emails so the tests can read them
+type outboxSender struct {
+	messages []*mail.Message
+}
+
+func (s *outboxSender) Send(message *mail.Message) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+var outbox *outboxSender
+
+var accountPolicy = lockout.Policy{MaxAttempts: 3, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: time.Hour}
+var ipPolicy = lockout.Policy{MaxAttempts: 5, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: time.Hour}
+var registrationPolicy = lockout.Policy{MaxAttempts: 3, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: time.Hour}
+
+var bus eventbus.Bus
+
+var provider *oidctest.Provider
+
 func TestUserCheck(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "User Suite")
@@ -48,16 +86,36 @@ func setupServer(
 	userRepo domain.UserRepository,
 	bookRepo bookDomain.BookRepository,
 	bookReviewRepo bookReviewDomain.BookReviewRepository,
+	userFollowRepo userFollowDomain.UserFollowRepository,
 	crypto crypto.Crypto) *gin.Engine {
 	router := gin.Default()
+	bus = eventbus.NewInMemoryBus()
 	finder := find.NewService(userRepo)
 	bookFinder := bookFind.NewService(bookRepo)
-	bookReviewFinder := bookReviewFind.NewService(bookReviewRepo)
-	creator := create.NewService(userRepo, crypto)
-	updater := update.NewService(userRepo)
-	deleter := delete.NewService(userRepo)
-	authLogin := login.NewService(userRepo, crypto)
-	RegisterRoutes(finder, bookFinder, bookReviewFinder, creator, updater, deleter, authLogin, tokenParams, router)
+	creator := create.NewService(userRepo, crypto, bus)
+	updater := update.NewService(userRepo, bus)
+	deleter := delete.NewService(userRepo, bus)
+	lockoutStore := lockout.NewMemoryStore()
+	authLogin := login.NewService(userRepo, crypto,
+		lockout.NewLimiter(lockoutStore, accountPolicy, "login-account:"),
+		lockout.NewLimiter(lockoutStore, ipPolicy, "login-ip:"))
+	registrations := lockout.NewLimiter(lockoutStore, registrationPolicy, "registration-ip:")
+	assigner := roles.NewService(userRepo)
+	outbox = &outboxSender{}
+	store := session.NewMemoryStore()
+	passwords := password.NewService(userRepo, crypto, store, outbox, password.DefaultResetParams)
+	verifier := verification.NewService(userRepo, store, outbox, verification.Params{
+		Secret:   "kq3Zx8VbN2pLw5Rt7YcM1sDf4",
+		TokenTTL: time.Minute,
+	})
+	verification.Subscribe(bus, verifier)
+	provider = oidctest.NewProvider("something-api", "3vF8kQz1LmN6pR2sT9wX4yB7c")
+	socialLogin := social.NewService(userRepo, store, bus,
+		oidc.NewProvider(provider.Config("stub", "http://localhost/login/stub/callback"), nil))
+	ratings := bookReviewRating.NewService(bookReviewRepo, bookRepo)
+	bookReviewCascade.Subscribe(bus, bookReviewRepo, bookReviewDelete.NewService(bookReviewRepo, ratings, bus))
+	userFollowCascade.Subscribe(bus, userFollowRepo)
+	RegisterRoutes(finder, bookFinder, creator, updater, deleter, authLogin, socialLogin, assigner, passwords, verifier, registrations, tokenParams, auth, router)
 	return router
 }
 
@@ -66,29 +124,21 @@ var _ = Describe("Server", func() {
 	var userRepo domain.UserRepository
 	var bookRepo bookDomain.BookRepository
 	var bookReviewRepo bookReviewDomain.BookReviewRepository
+	var userFollowRepo userFollowDomain.UserFollowRepository
 	var cryptoRepo crypto.Crypto
 
-	dbHost := os.Getenv("DB_HOST")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	client := config.ConnectBD(dbUser, dbPass, dbHost)
-
-	database := os.Getenv("TEST_DB_NAME")
-	dbClient := client.Database(database)
-
 	BeforeEach(func() {
-		userRepo = persistence.NewMongoUsersRepository(dbClient)
+		userRepo = persistence.NewInMemoryUserRepository()
 		bookRepo = bookPersistence.NewInMemoryBookRepository()
 		bookReviewRepo = bookReviewPersistence.NewInMemoryBookReviewsRepository()
+		userFollowRepo = userFollowPersistence.NewInMemoryUserFollowRepository()
 		cryptoRepo = crypto.NewBcrypt()
-		server = httptest.NewServer(setupServer(userRepo, bookRepo, bookReviewRepo, cryptoRepo))
+		server = httptest.NewServer(setupServer(userRepo, bookRepo, bookReviewRepo, userFollowRepo, cryptoRepo))
 	})
 
 	AfterEach(func() {
-		if err := dbClient.Collection("users").Drop(context.TODO()); err != nil {
-			Expect(err).ShouldNot(HaveOccurred())
-		}
 		server.Close()
+		provider.Close()
 	})
 
 	Context("When GET request is sent to /users", func() {
@@ -125,8 +175,9 @@ var _ = Describe("Server", func() {
 							"username":"` + newUser.Username + `",
 							"email":"` + newUser.Email + `",
 							"role":"` + newUser.Role + `",
+							"verified":false,
 							"interests":` + string(interests) + `,
-							"created_on":"` + newUser.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
+							"created_on":"` + newUser.CreatedOn.Format(time.RFC3339Nano) + `"
 						}
 					]
 			}`))
@@ -156,8 +207,9 @@ var _ = Describe("Server", func() {
 						"username":"` + newUser.Username + `",
 						"email":"` + newUser.Email + `",
 						"role":"` + newUser.Role + `" ,
+						"verified":false,
 						"interests":` + string(interests) + `,
-						"created_on":"` + newUser.CreatedOn.Format("2006-01-02T15:04:05.999Z07:00") + `"
+						"created_on":"` + newUser.CreatedOn.Format(time.RFC3339Nano) + `"
 					}
 			}`))
 		})
@@ -287,6 +339,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPatch,
 				server.URL+"/users/"+newUser.ID,
@@ -332,6 +385,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPatch,
 				server.URL+"/user/interests/"+newBook.ID,
@@ -365,6 +419,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodPatch,
 				server.URL+"/user/interests/"+bookID,
@@ -397,6 +452,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
 			req, err := http.NewRequest(
 				http.MethodDelete,
 				server.URL+"/user/interests/"+newBook.ID,
@@ -424,6 +480,7 @@ var _ = Describe("Server", func() {
 
 			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodDelete,
@@ -438,11 +495,62 @@ var _ = Describe("Server", func() {
 			user, _ := userRepo.FindByID(newUser.ID)
 			Expect(user).Should(BeNil())
 		})
+		It("delete the user reviews and follows", func() {
+			newUser, _ := domain.NewUser(
+				"552394d5-620c-4b7b-99da-c95fe5e52730",
+				"madison", "madison1", "madison@example.com",
+				"secret-pass-1")
+			userRepo.Save(newUser)
+			otherUserID := "6b5e8cc5-8b8a-4f0e-a2c9-1e1b2d3c4f5a"
+			newBook, _ := bookDomain.NewBook("c9d6e6f0-27d9-47d2-851e-bb42f72565ed", "title", "desc", "author", "genre", 1)
+			newBook.AddRating(5, 2)
+			bookRepo.Save(newBook)
+			review, _ := bookReviewDomain.NewBookReview("1", "abc", 4, newBook.ID, newUser.ID)
+			bookReviewRepo.Save(review)
+			otherReview, _ := bookReviewDomain.NewBookReview("2", "abc", 1, newBook.ID, otherUserID)
+			bookReviewRepo.Save(otherReview)
+			bookReviewRepo.AddVote(otherReview.ID, newUser.ID)
+			bookReviewRepo.AddVote(otherReview.ID, "7c6f9dd6-9c9b-4a1f-b3da-2f2c3e4d5a6b")
+			following, _ := userFollowDomain.NewUserFollow(newUser.ID, otherUserID)
+			userFollowRepo.Follow(following)
+			follower, _ := userFollowDomain.NewUserFollow(otherUserID, newUser.ID)
+			userFollowRepo.Follow(follower)
+
+			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
+
+			req, err := http.NewRequest(
+				http.MethodDelete,
+				server.URL+"/users/"+newUser.ID, nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNoContent))
+
+			reviews, _ := bookReviewRepo.FindByUserID(newUser.ID)
+			Expect(reviews).Should(BeEmpty())
+			reviews, _ = bookReviewRepo.FindByUserID(otherUserID)
+			Expect(reviews).Should(HaveLen(1))
+			Expect(reviews[0].HelpfulVotes).Should(Equal([]string{"7c6f9dd6-9c9b-4a1f-b3da-2f2c3e4d5a6b"}))
+			Expect(reviews[0].Helpful).Should(Equal(1))
+
+			book, _ := bookRepo.FindByID(newBook.ID)
+			Expect(book.RatingCount).Should(Equal(1))
+			Expect(book.RatingSum).Should(Equal(float64(1)))
+
+			follows, _ := userFollowRepo.FindFollowing(otherUserID)
+			Expect(follows).Should(BeEmpty())
+			follows, _ = userFollowRepo.FindFollowers(otherUserID)
+			Expect(follows).Should(BeEmpty())
+		})
 		It("return an 404 status code in non existing user", func() {
 			userID := "9b6848af-5e94-44ad-b59c-960c223ee182"
 
 			generateAuth, err := jwt.CreateToken(userID, "default", tokenParams)
 			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
 
 			req, err := http.NewRequest(
 				http.MethodDelete,
@@ -482,7 +590,7 @@ var _ = Describe("Server", func() {
 			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
 
 		})
-		It("return an 404 status code in non existing email", func() {
+		It("return an 401 status code in non existing email", func() {
 			hash, _ := cryptoRepo.Hash("pep-secret-pass")
 			newUser, _ := domain.NewUser(
 				"68a004c8-e1c1-49c0-a430-66f9cf6fd1ad",
@@ -501,12 +609,12 @@ var _ = Describe("Server", func() {
 			client := &http.Client{}
 			resp, err := client.Do(req)
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
 
 			body, err := ioutil.ReadAll(resp.Body)
 			defer resp.Body.Close()
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(string(body)).To(MatchJSON(`{"error":"email not found"}`))
+			Expect(string(body)).To(MatchJSON(`{"error":"invalid email or password"}`))
 		})
 		It("return an 401 status code in invalid email/password", func() {
 			hash, _ := cryptoRepo.Hash("pep-secret-pass")
@@ -530,4 +638,679 @@ var _ = Describe("Server", func() {
 			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
 		})
 	})
+	Context("When login or registration attempts keep failing", func() {
+		login := func(email, password string) *http.Response {
+			jsonReq, _ := json.Marshal(map[string]interface{}{"email": email, "password": password})
+			resp, err := http.Post(server.URL+"/login", "application/json", bytes.NewBuffer(jsonReq))
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp
+		}
+
+		BeforeEach(func() {
+			hash, _ := cryptoRepo.Hash("secret-pass-1")
+			newUser, _ := domain.NewUser("1d3f5b7d-9f1b-4d3f-8b7d-9f1b3d5f7b9d", "heidi", "heidi1", "heidi@example.com", hash)
+			userRepo.Save(newUser)
+		})
+		It("locks out the account and answers 429 with Retry-After", func() {
+			for i := int64(1); i < accountPolicy.MaxAttempts; i++ {
+				Expect(login("heidi@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+			}
+			resp := login("Heidi@example.com", "wrong-password")
+			Expect(resp.StatusCode).Should(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header.Get("Retry-After")).Should(Equal("60"))
+
+			resp = login("heidi@example.com", "secret-pass-1")
+			Expect(resp.StatusCode).Should(Equal(http.StatusTooManyRequests))
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"too many attempts, try again later"}`))
+		})
+		It("treats unknown emails like wrong passwords", func() {
+			for i := int64(1); i < accountPolicy.MaxAttempts; i++ {
+				Expect(login("nobody@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+			}
+			Expect(login("nobody@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusTooManyRequests))
+		})
+		It("forgets the failures of the account after a successful login", func() {
+			for i := int64(1); i < accountPolicy.MaxAttempts; i++ {
+				Expect(login("heidi@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+			}
+			Expect(login("heidi@example.com", "secret-pass-1").StatusCode).Should(Equal(http.StatusOK))
+			Expect(login("heidi@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("locks out the IP trying many accounts", func() {
+			for i := int64(1); i < ipPolicy.MaxAttempts; i++ {
+				email := fmt.Sprintf("user%d@example.com", i)
+				Expect(login(email, "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+			}
+			Expect(login("last@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusTooManyRequests))
+			Expect(login("heidi@example.com", "secret-pass-1").StatusCode).Should(Equal(http.StatusTooManyRequests))
+		})
+		It("forgets the failures of the IP after a successful login", func() {
+			for i := int64(1); i < ipPolicy.MaxAttempts; i++ {
+				email := fmt.Sprintf("user%d@example.com", i)
+				Expect(login(email, "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+			}
+			Expect(login("heidi@example.com", "secret-pass-1").StatusCode).Should(Equal(http.StatusOK))
+			Expect(login("last@example.com", "wrong-password").StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("limits the registrations of an IP", func() {
+			register := func(i int64) int {
+				jsonReq, _ := json.Marshal(map[string]interface{}{
+					"name":     "ivan",
+					"username": fmt.Sprintf("ivan%d", i),
+					"email":    fmt.Sprintf("ivan%d@example.com", i),
+					"password": "super-secure-password",
+				})
+				req, _ := http.NewRequest(http.MethodPut,
+					server.URL+fmt.Sprintf("/users/7e9a1c3e-5a7c-4e9a-8c1e-%012d", i), bytes.NewBuffer(jsonReq))
+				req.Header.Set("Content-Type", "application/json; charset=utf-8")
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).ShouldNot(HaveOccurred())
+				return resp.StatusCode
+			}
+			for i := int64(1); i <= registrationPolicy.MaxAttempts; i++ {
+				Expect(register(i)).Should(Equal(http.StatusCreated))
+			}
+			Expect(register(registrationPolicy.MaxAttempts + 1)).Should(Equal(http.StatusTooManyRequests))
+		})
+	})
+	Context("When POST request is sent to /token/refresh", func() {
+		It("returns a new token pair and revokes the old one", func() {
+			newUser, _ := domain.NewUser(
+				"1a0f6c4e-2b55-4b8e-8d1a-7b2d0b5f4f6e",
+				"carol", "carol1", "carol@example.com",
+				"secret-pass-1")
+			userRepo.Save(newUser)
+
+			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
+
+			refreshFields := map[string]interface{}{
+				"refresh_token": generateAuth.RefreshToken,
+			}
+			jsonReq, err := json.Marshal(refreshFields)
+			req, err := http.NewRequest(
+				http.MethodPost,
+				server.URL+"/token/refresh", bytes.NewBuffer(jsonReq))
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			_, err = auth.FetchAuth(&jwt.AccessDetails{AccessUUID: generateAuth.AccessUUID, UserID: newUser.ID})
+			Expect(err).Should(HaveOccurred())
+
+			req, err = http.NewRequest(
+				http.MethodPost,
+				server.URL+"/token/refresh", bytes.NewBuffer(jsonReq))
+			resp, err = client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("return an 401 status code with an invalid refresh token", func() {
+			refreshFields := map[string]interface{}{
+				"refresh_token": "invalid-token",
+			}
+			jsonReq, err := json.Marshal(refreshFields)
+			req, err := http.NewRequest(
+				http.MethodPost,
+				server.URL+"/token/refresh", bytes.NewBuffer(jsonReq))
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+	})
+	Context("When PUT request is sent to /users/:id/role", func() {
+		const adminID = "7c1e5a3b-9d2f-4e6a-8b0c-2d4f6a8b0c1e"
+		const memberID = "3b5d7f9a-1c3e-4a5b-9d7f-1a3c5e7a9b2d"
+
+		assignRole := func(userID, body, as, role string) int {
+			generateAuth, err := jwt.CreateToken(as, role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(as, generateAuth)
+			req, err := http.NewRequest(
+				http.MethodPut,
+				server.URL+"/users/"+userID+"/role", bytes.NewBufferString(body))
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp.StatusCode
+		}
+
+		BeforeEach(func() {
+			admin, _ := domain.NewUser(adminID, "ada", "ada1", "ada@example.com", "secret-pass-1")
+			admin.Role = domain.RoleAdmin
+			userRepo.Save(admin)
+			member, _ := domain.NewUser(memberID, "bob", "bob1", "bob@example.com", "secret-pass-1")
+			userRepo.Save(member)
+		})
+		It("assigns the role and new tokens carry it", func() {
+			Expect(assignRole(memberID, `{"role": "staff"}`, adminID, domain.RoleAdmin)).Should(Equal(http.StatusOK))
+
+			member, _ := userRepo.FindByID(memberID)
+			Expect(member.Role).Should(Equal(domain.RoleStaff))
+
+			generateAuth, err := jwt.CreateToken(memberID, domain.RoleDefault, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(memberID, generateAuth)
+			jsonReq, err := json.Marshal(map[string]interface{}{"refresh_token": generateAuth.RefreshToken})
+			resp, err := http.Post(server.URL+"/token/refresh", "application/json", bytes.NewBuffer(jsonReq))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+			tokens := &struct {
+				Tokens map[string]string `json:"tokens"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(tokens)).Should(Succeed())
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			req.Header.Set("Authorization", "Bearer "+tokens.Tokens["access_token"])
+			details, err := jwt.ExtractTokenMetadata(req, tokenParams.AccessKeys)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Role).Should(Equal(domain.RoleStaff))
+		})
+		It("return an 403 status code without the users:admin permission", func() {
+			Expect(assignRole(adminID, `{"role": "default"}`, memberID, domain.RoleStaff)).Should(Equal(http.StatusForbidden))
+			admin, _ := userRepo.FindByID(adminID)
+			Expect(admin.Role).Should(Equal(domain.RoleAdmin))
+		})
+		It("return an 401 status code without token", func() {
+			req, _ := http.NewRequest(
+				http.MethodPut, server.URL+"/users/"+memberID+"/role", bytes.NewBufferString(`{"role": "staff"}`))
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("return an 400 status code with an unknown role or the own role", func() {
+			Expect(assignRole(memberID, `{"role": "owner"}`, adminID, domain.RoleAdmin)).Should(Equal(http.StatusBadRequest))
+			Expect(assignRole(adminID, `{"role": "default"}`, adminID, domain.RoleAdmin)).Should(Equal(http.StatusBadRequest))
+		})
+		It("return an 404 status code in non existing user", func() {
+			Expect(assignRole("9b6848af-5e94-44ad-b59c-960c223ee182", `{"role": "staff"}`, adminID, domain.RoleAdmin)).
+				Should(Equal(http.StatusNotFound))
+		})
+	})
+	Context("When PATCH or DELETE request is sent to /users/:id by other users", func() {
+		const adminID = "2e4a6c8e-0b2d-4f6a-8c0e-4a6c8e0b2d4f"
+		const ownerID = "5c7e9a1c-3e5a-4c7e-9a1c-5e7a9c1e3a5c"
+		const otherID = "8f1b3d5f-7a9c-4e1b-8d5f-9b1d3f5a7c9e"
+
+		request := func(method, userID, body, as, role string) int {
+			generateAuth, err := jwt.CreateToken(as, role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(as, generateAuth)
+			req, err := http.NewRequest(method, server.URL+"/users/"+userID, bytes.NewBufferString(body))
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp.StatusCode
+		}
+
+		BeforeEach(func() {
+			owner, _ := domain.NewUser(ownerID, "carol", "carol1", "carol@example.com", "secret-pass-1")
+			userRepo.Save(owner)
+		})
+		It("return an 403 status code modifying other users", func() {
+			Expect(request(http.MethodPatch, ownerID, `{"name": "Mallory"}`, otherID, domain.RoleDefault)).
+				Should(Equal(http.StatusForbidden))
+			owner, _ := userRepo.FindByID(ownerID)
+			Expect(owner.Name).Should(Equal("carol"))
+		})
+		It("return an 403 status code deleting other users", func() {
+			Expect(request(http.MethodDelete, ownerID, "", otherID, domain.RoleModerator)).
+				Should(Equal(http.StatusForbidden))
+			owner, _ := userRepo.FindByID(ownerID)
+			Expect(owner).ShouldNot(BeNil())
+		})
+		It("lets admins modify other users", func() {
+			Expect(request(http.MethodPatch, ownerID, `{"name": "Carol"}`, adminID, domain.RoleAdmin)).
+				Should(Equal(http.StatusOK))
+			owner, _ := userRepo.FindByID(ownerID)
+			Expect(owner.Name).Should(Equal("Carol"))
+		})
+		It("lets admins delete other users", func() {
+			Expect(request(http.MethodDelete, ownerID, "", adminID, domain.RoleAdmin)).
+				Should(Equal(http.StatusNoContent))
+			owner, _ := userRepo.FindByID(ownerID)
+			Expect(owner).Should(BeNil())
+		})
+		It("return an 404 status code modifying a non existing user as admin", func() {
+			Expect(request(http.MethodPatch, otherID, `{"name": "Carol"}`, adminID, domain.RoleAdmin)).
+				Should(Equal(http.StatusNotFound))
+		})
+	})
+	Context("When PATCH request is sent to /user/password", func() {
+		const userID = "0c3a9e51-6d2b-4f7e-8a1c-5b9d3e7f1a24"
+
+		var accessToken string
+		var refreshToken string
+
+		changePassword := func(body string) *http.Response {
+			req, err := http.NewRequest(http.MethodPatch, server.URL+"/user/password", bytes.NewBufferString(body))
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp
+		}
+
+		BeforeEach(func() {
+			hash, _ := cryptoRepo.Hash("secret-pass-1")
+			newUser, _ := domain.NewUser(userID, "erin", "erin1", "erin@example.com", hash)
+			userRepo.Save(newUser)
+			generateAuth, err := jwt.CreateToken(userID, newUser.Role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			accessToken = generateAuth.AccessToken
+			refreshToken = generateAuth.RefreshToken
+		})
+		It("changes the password and revokes the open sessions", func() {
+			resp := changePassword(`{"current_password": "secret-pass-1", "new_password": "secret-pass-2"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+			tokens := &struct {
+				Tokens map[string]string `json:"tokens"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(tokens)).Should(Succeed())
+
+			user, _ := userRepo.FindByID(userID)
+			Expect(cryptoRepo.CompareHashAndText("secret-pass-2", user.Password)).Should(BeTrue())
+
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+			jsonReq, _ := json.Marshal(map[string]interface{}{"refresh_token": refreshToken})
+			resp, err = http.Post(server.URL+"/token/refresh", "application/json", bytes.NewBuffer(jsonReq))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+			req, _ = http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+tokens.Tokens["access_token"])
+			resp, err = http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		})
+		It("issues the new token with the stored role", func() {
+			generateAuth, err := jwt.CreateToken(userID, domain.RoleAdmin, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			accessToken = generateAuth.AccessToken
+
+			resp := changePassword(`{"current_password": "secret-pass-1", "new_password": "secret-pass-2"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+			tokens := &struct {
+				Tokens map[string]string `json:"tokens"`
+			}{}
+			Expect(json.NewDecoder(resp.Body).Decode(tokens)).Should(Succeed())
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			req.Header.Set("Authorization", "Bearer "+tokens.Tokens["access_token"])
+			details, err := jwt.ExtractTokenMetadata(req, tokenParams.AccessKeys)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Role).Should(Equal(domain.RoleDefault))
+		})
+		It("return an 400 status code with an invalid current password", func() {
+			resp := changePassword(`{"current_password": "secret-pass-3", "new_password": "secret-pass-2"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"invalid current password"}`))
+		})
+		It("return an 400 status code with a short or the same password", func() {
+			resp := changePassword(`{"current_password": "secret-pass-1", "new_password": "short"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			resp = changePassword(`{"current_password": "secret-pass-1", "new_password": "secret-pass-1"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+		})
+		It("return an 401 status code without token", func() {
+			accessToken = ""
+			resp := changePassword(`{"current_password": "secret-pass-1", "new_password": "secret-pass-2"}`)
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+	})
+	Context("When POST request is sent to /user/password/forgot and /user/password/reset", func() {
+		const userID = "4f8b2d6e-1a3c-4e5f-9b7d-2c4e6a8b0d13"
+
+		post := func(path, body string) int {
+			resp, err := http.Post(server.URL+path, "application/json", bytes.NewBufferString(body))
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp.StatusCode
+		}
+		tokenRegexp := regexp.MustCompile(`[0-9a-f]{64}`)
+
+		BeforeEach(func() {
+			hash, _ := cryptoRepo.Hash("secret-pass-1")
+			newUser, _ := domain.NewUser(userID, "frank", "frank1", "frank@example.com", hash)
+			userRepo.Save(newUser)
+		})
+		It("mails a single use token that resets the password", func() {
+			generateAuth, err := jwt.CreateToken(userID, domain.RoleDefault, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+
+			Expect(post("/user/password/forgot", `{"email": "Frank@example.com"}`)).Should(Equal(http.StatusAccepted))
+			Expect(outbox.messages).Should(HaveLen(1))
+			Expect(outbox.messages[0].To).Should(Equal("frank@example.com"))
+			token := tokenRegexp.FindString(outbox.messages[0].Body)
+			Expect(token).ShouldNot(BeEmpty())
+
+			Expect(post("/user/password/reset", `{"token": "`+token+`", "new_password": "secret-pass-2"}`)).
+				Should(Equal(http.StatusOK))
+			user, _ := userRepo.FindByID(userID)
+			Expect(cryptoRepo.CompareHashAndText("secret-pass-2", user.Password)).Should(BeTrue())
+
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+			Expect(post("/user/password/reset", `{"token": "`+token+`", "new_password": "secret-pass-3"}`)).
+				Should(Equal(http.StatusBadRequest))
+			Expect(post("/login", `{"email": "frank@example.com", "password": "secret-pass-2"}`)).
+				Should(Equal(http.StatusOK))
+		})
+		It("only keeps the last token valid", func() {
+			Expect(post("/user/password/forgot", `{"email": "frank@example.com"}`)).Should(Equal(http.StatusAccepted))
+			Expect(post("/user/password/forgot", `{"email": "frank@example.com"}`)).Should(Equal(http.StatusAccepted))
+			Expect(outbox.messages).Should(HaveLen(2))
+			first := tokenRegexp.FindString(outbox.messages[0].Body)
+			last := tokenRegexp.FindString(outbox.messages[1].Body)
+
+			Expect(post("/user/password/reset", `{"token": "`+first+`", "new_password": "secret-pass-2"}`)).
+				Should(Equal(http.StatusBadRequest))
+			Expect(post("/user/password/reset", `{"token": "`+last+`", "new_password": "secret-pass-2"}`)).
+				Should(Equal(http.StatusOK))
+		})
+		It("doesn't tell whether the email is registered", func() {
+			Expect(post("/user/password/forgot", `{"email": "nobody@example.com"}`)).Should(Equal(http.StatusAccepted))
+			Expect(outbox.messages).Should(BeEmpty())
+		})
+		It("return an 400 status code with an invalid token", func() {
+			resp, err := http.Post(server.URL+"/user/password/reset", "application/json",
+				bytes.NewBufferString(`{"token": "not-a-token", "new_password": "secret-pass-2"}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"invalid or expired token"}`))
+		})
+	})
+	Context("When the email of a registered user is verified", func() {
+		const userID = "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d"
+
+		register := func() {
+			user := map[string]interface{}{
+				"name":     "grace",
+				"username": "grace1",
+				"email":    "grace@example.com",
+				"password": "super-secure-password",
+			}
+			jsonReq, err := json.Marshal(user)
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/users/"+userID, bytes.NewBuffer(jsonReq))
+			Expect(err).ShouldNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusCreated))
+			bus.Wait()
+		}
+		verify := func(token string) int {
+			resp, err := http.Get(server.URL + "/users/verify?token=" + token)
+			Expect(err).ShouldNot(HaveOccurred())
+			return resp.StatusCode
+		}
+		sentToken := func(message *mail.Message) string {
+			lines := strings.Split(strings.TrimSpace(message.Body), "\n")
+			return lines[len(lines)-1]
+		}
+
+		It("starts unverified and the mailed token verifies it once", func() {
+			register()
+			user, _ := userRepo.FindByID(userID)
+			Expect(user.Verified).Should(BeFalse())
+			Expect(outbox.messages).Should(HaveLen(1))
+			Expect(outbox.messages[0].To).Should(Equal("grace@example.com"))
+
+			token := sentToken(outbox.messages[0])
+			Expect(verify(token)).Should(Equal(http.StatusOK))
+			user, _ = userRepo.FindByID(userID)
+			Expect(user.Verified).Should(BeTrue())
+
+			Expect(verify(token)).Should(Equal(http.StatusBadRequest))
+		})
+		It("still looks up users by ID next to /users/verify", func() {
+			register()
+			resp, err := http.Get(server.URL + "/users/" + userID)
+			Expect(err).ShouldNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		})
+		It("sends a new token that replaces the previous one", func() {
+			register()
+			generateAuth, err := jwt.CreateToken(userID, domain.RoleDefault, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(userID, generateAuth)
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/user/verify", nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusAccepted))
+			Expect(outbox.messages).Should(HaveLen(2))
+
+			Expect(verify(sentToken(outbox.messages[0]))).Should(Equal(http.StatusBadRequest))
+			Expect(verify(sentToken(outbox.messages[1]))).Should(Equal(http.StatusOK))
+
+			resp, err = http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+		})
+		It("return an 400 status code with a forged token", func() {
+			register()
+			forged := strings.Split(sentToken(outbox.messages[0]), ".")
+			forged[2] = "c2lnbmF0dXJl"
+			Expect(verify(strings.Join(forged, "."))).Should(Equal(http.StatusBadRequest))
+			Expect(verify("")).Should(Equal(http.StatusBadRequest))
+
+			user, _ := userRepo.FindByID(userID)
+			Expect(user.Verified).Should(BeFalse())
+		})
+	})
+	Context("When GET request is sent to /login/:provider", func() {
+		noRedirects := &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		// authorize follows the redirections to the provider and returns
+		// the callback URL the provider sends the user back to
+		authorize := func(user *oidctest.User) string {
+			resp, err := noRedirects.Get(server.URL + "/login/stub")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusFound))
+			authURL, err := url.Parse(resp.Header.Get("Location"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(authURL.Path).Should(Equal("/authorize"))
+			Expect(authURL.Query().Get("code_challenge_method")).Should(Equal("S256"))
+			Expect(authURL.Query().Get("code_challenge")).ShouldNot(BeEmpty())
+
+			provider.Login(user)
+			resp, err = noRedirects.Get(authURL.String())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusFound))
+			callback, err := url.Parse(resp.Header.Get("Location"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(callback.Path).Should(Equal("/login/stub/callback"))
+			return server.URL + callback.Path + "?" + callback.RawQuery
+		}
+		type loginResponse struct {
+			User struct {
+				ID       string `json:"id"`
+				Name     string `json:"name"`
+				Username string `json:"username"`
+				Email    string `json:"email"`
+				Verified bool   `json:"verified"`
+			} `json:"user"`
+			Tokens map[string]string `json:"tokens"`
+		}
+		login := func(user *oidctest.User) *loginResponse {
+			resp, err := http.Get(authorize(user))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			defer resp.Body.Close()
+			response := &loginResponse{}
+			Expect(json.NewDecoder(resp.Body).Decode(response)).Should(Succeed())
+			return response
+		}
+		ada := &oidctest.User{
+			Subject:           "248289761001",
+			Email:             "Ada@Example.com",
+			EmailVerified:     true,
+			Name:              "Ada Lovelace",
+			PreferredUsername: "Ada",
+		}
+
+		It("creates the user on the first login and issues the tokens", func() {
+			first := login(ada)
+			Expect(first.User.Name).Should(Equal("Ada Lovelace"))
+			Expect(first.User.Username).Should(Equal("ada"))
+			Expect(first.User.Email).Should(Equal("ada@example.com"))
+			Expect(first.User.Verified).Should(BeTrue())
+			Expect(first.Tokens["access_token"]).ShouldNot(BeEmpty())
+			Expect(first.Tokens["refresh_token"]).ShouldNot(BeEmpty())
+			bus.Wait()
+			Expect(outbox.messages).Should(BeEmpty())
+
+			second := login(ada)
+			Expect(second.User.ID).Should(Equal(first.User.ID))
+			user, err := userRepo.FindByID(first.User.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(user.Identities).Should(Equal([]domain.Identity{{Provider: "stub", Subject: ada.Subject}}))
+
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+second.Tokens["access_token"])
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		})
+		It("numbers the username when it is already in use", func() {
+			newUser, _ := domain.NewUser(
+				"0c6f2a8e-3b5d-4e71-9a24-7d8e1f3c5b60",
+				"ada", "ada", "ada.byron@example.com", "")
+			userRepo.Save(newUser)
+
+			response := login(&oidctest.User{
+				Subject:           "9034",
+				Email:             "countess@example.com",
+				PreferredUsername: "Ada",
+			})
+			Expect(response.User.Username).Should(Equal("ada2"))
+			Expect(response.User.Name).Should(Equal("ada2"))
+			Expect(response.User.Verified).Should(BeFalse())
+			bus.Wait()
+			Expect(outbox.messages).Should(HaveLen(1))
+			Expect(outbox.messages[0].To).Should(Equal("countess@example.com"))
+		})
+		It("links the identity to the verified user owning the email", func() {
+			newUser, _ := domain.NewUser(
+				"7e1d4b2a-6c3f-4a8e-b5d9-2f0a1c7e3b84",
+				"ada", "lovelace", "ada@example.com", "")
+			newUser.Verified = true
+			userRepo.Save(newUser)
+
+			response := login(ada)
+			Expect(response.User.ID).Should(Equal(newUser.ID))
+			Expect(response.User.Username).Should(Equal("lovelace"))
+			user, _ := userRepo.FindByIdentity("stub", ada.Subject)
+			Expect(user.ID).Should(Equal(newUser.ID))
+		})
+		It("return an 400 status code when the email can't be linked", func() {
+			newUser, _ := domain.NewUser(
+				"7e1d4b2a-6c3f-4a8e-b5d9-2f0a1c7e3b84",
+				"ada", "lovelace", "ada@example.com", "")
+			userRepo.Save(newUser)
+
+			resp, err := http.Get(authorize(ada))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			body, err := ioutil.ReadAll(resp.Body)
+			defer resp.Body.Close()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(`{"error":"email already in use"}`))
+		})
+		It("return an 401 status code on denied logins and reused states", func() {
+			resp, err := http.Get(authorize(nil))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+			callback := authorize(ada)
+			resp, err = http.Get(callback)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			resp, err = http.Get(callback)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+		It("return an 401 status code when the callback is forged", func() {
+			callback, _ := url.Parse(authorize(ada))
+			query := callback.Query()
+			query.Set("code", "forged-code")
+			callback.RawQuery = query.Encode()
+			resp, err := http.Get(callback.String())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+			resp, err = http.Get(server.URL + "/login/stub/callback?code=code")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+		})
+		It("return an 404 status code with unknown providers", func() {
+			resp, err := noRedirects.Get(server.URL + "/login/other")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+		})
+	})
+	Context("When POST request is sent to /logout", func() {
+		It("revokes the access token", func() {
+			newUser, _ := domain.NewUser(
+				"5d2e8f3a-9c41-4a7b-b6e2-3f8c1d9a0e57",
+				"dave", "dave1", "dave@example.com",
+				"secret-pass-1")
+			userRepo.Save(newUser)
+
+			generateAuth, err := jwt.CreateToken(newUser.ID, newUser.Role, tokenParams)
+			Expect(err).ShouldNot(HaveOccurred())
+			auth.CreateAuth(newUser.ID, generateAuth)
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+
+			req, err = http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+			req.Header.Set("Authorization", "Bearer "+generateAuth.AccessToken)
+			resp, err = client.Do(req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+	})
 })
@@ -5,18 +5,14 @@ import (
 	"strconv"
 	"strings"
 
-	bookReviewFinder "something/internal/bookreviews/application/find"
 	bookFinder "something/internal/books/application/find"
-	"something/internal/helpers"
 	"something/internal/users/application/find"
 
 	"github.com/gin-gonic/gin"
 )
 
 // GetUsersController ...
-func GetUsersController(finder find.Service, bFinder bookFinder.Service,
-	reviewFinder bookReviewFinder.Service,
-) func(c *gin.Context) {
+func GetUsersController(finder find.Service, bFinder bookFinder.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		username := c.Query("username")
 		if username != "" {
@@ -33,7 +29,7 @@ func GetUsersController(finder find.Service, bFinder bookFinder.Service,
 				})
 				return
 			}
-			interests := classifyBookInterests(user.Interests, bFinder, reviewFinder)
+			interests := classifyBookInterests(user.Interests, bFinder)
 			c.JSON(http.StatusOK, gin.H{
 				"data":      user,
 				"interests": interests,
@@ -76,7 +72,7 @@ type bookShort struct {
 	Status string  `json:"status"`
 }
 
-func classifyBookInterests(interests map[string]string, finder bookFinder.Service, reviewFinder bookReviewFinder.Service) []*bookShort {
+func classifyBookInterests(interests map[string]string, finder bookFinder.Service) []*bookShort {
 
 	bookInterests := []*bookShort{}
 
@@ -89,11 +85,7 @@ func classifyBookInterests(interests map[string]string, finder bookFinder.Servic
 		book.ID = bookResponse.ID
 		book.Title = bookResponse.Title
 		book.Author = bookResponse.Author
-
-		reviews, err := reviewFinder.FindBookReviews(book.ID)
-		if err == nil {
-			book.Rating = helpers.GetBookRating(reviews)
-		}
+		book.Rating = bookResponse.Rating
 
 		switch status {
 		case "pending":
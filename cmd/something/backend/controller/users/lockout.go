@@ -0,0 +1,18 @@
+package users
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tooManyAttempts answers a 429 telling the client when to try again
+func tooManyAttempts(c *gin.Context, retryAfter time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error": message,
+	})
+}
@@ -2,13 +2,18 @@ package users
 
 import (
 	m "something/cmd/something/backend/controller/middlewares"
-	bookReviewFinder "something/internal/bookreviews/application/find"
 	bookFind "something/internal/books/application/find"
 	"something/internal/users/application/create"
 	"something/internal/users/application/delete"
 	"something/internal/users/application/find"
 	"something/internal/users/application/login"
+	"something/internal/users/application/password"
+	"something/internal/users/application/roles"
+	"something/internal/users/application/social"
 	"something/internal/users/application/update"
+	"something/internal/users/application/verification"
+	"something/internal/users/domain"
+	"something/pkg/lockout"
 	jwt "something/pkg/redisjwt"
 
 	"github.com/gin-gonic/gin"
@@ -18,22 +23,51 @@ import (
 func RegisterRoutes(
 	finder find.Service,
 	bookFinder bookFind.Service,
-	reviewFinder bookReviewFinder.Service,
 	creator create.Service,
 	updater update.Service,
 	deleter delete.Service,
 	login login.Service,
+	socialLogin social.Service,
+	assigner roles.Service,
+	passwords password.Service,
+	verifier verification.Service,
+	registrations lockout.Limiter,
 	tokenParams *jwt.TokenParams,
+	auth jwt.AuthRepository,
 	router *gin.Engine) {
 	usersRouter := router.Group("/users")
 	{
-		usersRouter.GET("", GetUsersController(finder, bookFinder, reviewFinder))
-		usersRouter.GET("/:id", GetUserController(finder))
-		usersRouter.PUT("/:id", RegisterController(creator))
-		usersRouter.PATCH("/:id", m.TokenAuthMiddleware(tokenParams.AccessSecret), PatchController(updater))
-		usersRouter.DELETE("/:id", m.TokenAuthMiddleware(tokenParams.AccessSecret), DeleteUserController(deleter))
+		usersRouter.GET("", GetUsersController(finder, bookFinder))
+		// gin can't register /users/verify next to /users/:id, so the
+		// verification is dispatched from the same route
+		getUser, verify := GetUserController(finder), VerifyController(verifier)
+		usersRouter.GET("/:id", func(c *gin.Context) {
+			if c.Param("id") == "verify" {
+				verify(c)
+				return
+			}
+			getUser(c)
+		})
+		usersRouter.PUT("/:id", RegisterController(creator, registrations))
+		usersRouter.PATCH("/:id",
+			m.TokenAuthMiddleware(tokenParams.AccessKeys, auth),
+			m.OwnerOrPermissionMiddleware(domain.PermissionUsersAdmin),
+			PatchController(updater))
+		usersRouter.DELETE("/:id",
+			m.TokenAuthMiddleware(tokenParams.AccessKeys, auth),
+			m.OwnerOrPermissionMiddleware(domain.PermissionUsersAdmin),
+			DeleteUserController(deleter))
+		usersRouter.PUT("/:id/role", m.TokenAuthPermissionMiddleware(domain.PermissionUsersAdmin, tokenParams.AccessKeys, auth), RolePutController(assigner))
 	}
-	router.PATCH("/user/interests/:book_id", m.TokenAuthMiddleware(tokenParams.AccessSecret), InterestsPatchController(updater, bookFinder))
-	router.DELETE("/user/interests/:book_id", m.TokenAuthMiddleware(tokenParams.AccessSecret), InterestsDeleteController(deleter, bookFinder))
-	router.POST("/login", LoginController(login, tokenParams))
+	router.PATCH("/user/interests/:book_id", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), InterestsPatchController(updater, bookFinder))
+	router.DELETE("/user/interests/:book_id", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), InterestsDeleteController(deleter, bookFinder))
+	router.PATCH("/user/password", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), PasswordPatchController(passwords, tokenParams, auth))
+	router.POST("/user/password/forgot", PasswordForgotController(passwords))
+	router.POST("/user/password/reset", PasswordResetController(passwords, tokenParams, auth))
+	router.POST("/user/verify", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), SendVerificationController(verifier))
+	router.POST("/login", LoginController(login, tokenParams, auth))
+	router.GET("/login/:provider", SocialLoginController(socialLogin))
+	router.GET("/login/:provider/callback", SocialCallbackController(socialLogin, tokenParams, auth))
+	router.POST("/logout", m.TokenAuthMiddleware(tokenParams.AccessKeys, auth), LogoutController(auth))
+	router.POST("/token/refresh", RefreshController(finder, tokenParams, auth))
 }
@@ -4,41 +4,103 @@ import (
 	"log"
 	"os"
 	"something/cmd/something/backend/controller/healthcheck"
+	"something/cmd/something/backend/controller/jwks"
+	m "something/cmd/something/backend/controller/middlewares"
 	"something/config"
 	"something/pkg/crypto"
+	"something/pkg/eventbus"
+	"something/pkg/lockout"
+	"something/pkg/mail"
+	"something/pkg/oidc"
+	"something/pkg/ratelimit"
 	jwt "something/pkg/redisjwt"
+	"something/pkg/session"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"something/cmd/something/backend/controller/bookreviews"
+	bookReviewCascade "something/internal/bookreviews/application/cascade"
 	"something/internal/bookreviews/application/create"
 	"something/internal/bookreviews/application/delete"
 	"something/internal/bookreviews/application/find"
+	"something/internal/bookreviews/application/ranking"
+	"something/internal/bookreviews/application/rating"
 	"something/internal/bookreviews/application/update"
+	"something/internal/bookreviews/application/vote"
 	"something/internal/bookreviews/infraestructure/persistence"
 
+	"something/cmd/something/backend/controller/comments"
+	commentCascade "something/internal/comments/application/cascade"
+	commentCreate "something/internal/comments/application/create"
+	commentDelete "something/internal/comments/application/delete"
+	commentFinder "something/internal/comments/application/find"
+	commentUpdate "something/internal/comments/application/update"
+	commentPersistence "something/internal/comments/infraestructure/persistence"
+
 	"something/cmd/something/backend/controller/books"
 	bookCreate "something/internal/books/application/create"
 	bookDelete "something/internal/books/application/delete"
 	bookFinder "something/internal/books/application/find"
+	bookSearch "something/internal/books/application/search"
 	bookUpdate "something/internal/books/application/update"
 	bookPersistance "something/internal/books/infraestructure/persistence"
 
 	"something/cmd/something/backend/controller/users"
+	userCascade "something/internal/users/application/cascade"
 	userCreate "something/internal/users/application/create"
 	userDelete "something/internal/users/application/delete"
 	userFinder "something/internal/users/application/find"
 	"something/internal/users/application/login"
+	userPassword "something/internal/users/application/password"
+	userRoles "something/internal/users/application/roles"
+	userSocial "something/internal/users/application/social"
 	userUpdate "something/internal/users/application/update"
+	userVerification "something/internal/users/application/verification"
+	userDomain "something/internal/users/domain"
 	userPersistance "something/internal/users/infraestructure/persistence"
 
+	"something/cmd/something/backend/controller/feed"
+	feedFinder "something/internal/feed/application/find"
+	feedRecord "something/internal/feed/application/record"
+	feedPersistence "something/internal/feed/infraestructure/persistence"
+
+	"something/cmd/something/backend/controller/challenges"
+	challengeCascade "something/internal/challenges/application/cascade"
+	challengeSet "something/internal/challenges/application/set"
+	challengeStats "something/internal/challenges/application/stats"
+	challengePersistence "something/internal/challenges/infraestructure/persistence"
+
+	"something/cmd/something/backend/controller/readinglog"
+	readingLogFinder "something/internal/readinglog/application/find"
+	readingLogStatus "something/internal/readinglog/application/status"
+	readingLogTrack "something/internal/readinglog/application/track"
+	readingLogPersistence "something/internal/readinglog/infraestructure/persistence"
+
+	"something/cmd/something/backend/controller/shelves"
+	shelfBooks "something/internal/shelves/application/books"
+	shelfCreate "something/internal/shelves/application/create"
+	shelfDelete "something/internal/shelves/application/delete"
+	shelfFinder "something/internal/shelves/application/find"
+	shelfStatus "something/internal/shelves/application/status"
+	shelfUpdate "something/internal/shelves/application/update"
+	shelfPersistence "something/internal/shelves/infraestructure/persistence"
+
+	"something/cmd/something/backend/controller/recommendations"
+	recommendationFinder "something/internal/recommendations/application/find"
+
 	"something/cmd/something/backend/controller/userfollow"
+	userFollowCascade "something/internal/userfollow/application/cascade"
 	userFollowFinder "something/internal/userfollow/application/find"
 	userFollow "something/internal/userfollow/application/followers"
 	userFollowPersistance "something/internal/userfollow/infraestructure/persistence"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis"
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func init() {
@@ -65,12 +127,14 @@ func main() {
 func setupServer() *gin.Engine {
 
 	tokenParams := &jwt.TokenParams{
-		AccessSecret:  os.Getenv("ACCESS_SECRET"),
-		RefreshSecret: os.Getenv("REFRESH_SECRET"),
-		AccessTime:    time.Hour * 24,
-		RefreshTime:   time.Hour * 24 * 7,
+		AccessKeys:  newAccessKeys(),
+		RefreshKeys: jwt.NewHMACKeySet(os.Getenv("REFRESH_SECRET")),
+		AccessTime:  time.Hour * 24,
+		RefreshTime: time.Hour * 24 * 7,
 	}
 
+	redisClient := newRedisClient()
+
 	router := gin.Default()
 
 	router.Use(gin.Recovery())
@@ -81,6 +145,8 @@ func setupServer() *gin.Engine {
 	corsConfig.AddAllowHeaders("authorization")
 	router.Use(cors.New(corsConfig))
 
+	router.Use(m.RateLimitMiddleware(newRateLimitStore(redisClient), newRateLimitRules(), tokenParams.AccessKeys))
+
 	// init database
 	dbHost := os.Getenv("DB_HOST")
 	dbUser := os.Getenv("DB_USER")
@@ -98,38 +164,371 @@ func setupServer() *gin.Engine {
 	inMemoryBookReviewRepo := persistence.NewMongoBookReviewRepository(dbClient)
 	inMemoryUserRepo := userPersistance.NewMongoUsersRepository(dbClient)
 	inMemoryUserFollowRepo := userFollowPersistance.NewMongoUserFollowRepository(dbClient)
+	activityRepo := feedPersistence.NewMongoActivityRepository(dbClient)
+	readingLogRepo := readingLogPersistence.NewMongoReadingLogRepository(dbClient)
+	challengeRepo := challengePersistence.NewMongoChallengeRepository(dbClient)
+	shelfRepo := shelfPersistence.NewMongoShelfRepository(dbClient)
+	commentRepo := commentPersistence.NewMongoCommentRepository(dbClient)
+
+	// Domain events
+	eventBus := eventbus.NewInMemoryBus()
 
 	// Finders
 	bookFind := bookFinder.NewService(inMemoryBookRepo)
+	bookSearcher := bookSearch.NewService(inMemoryBookRepo)
 	bookReviewFinder := find.NewService(inMemoryBookReviewRepo)
+	bookRanker := ranking.NewService(inMemoryBookReviewRepo, inMemoryBookRepo, newRankingPrior())
 	userFind := userFinder.NewService(inMemoryUserRepo)
 	userFollowFind := userFollowFinder.NewService(inMemoryUserFollowRepo)
+	feedFind := feedFinder.NewService(activityRepo, inMemoryUserFollowRepo)
+	readingLogFind := readingLogFinder.NewService(readingLogRepo)
+	statsFind := challengeStats.NewService(
+		challengeRepo, readingLogRepo, inMemoryUserRepo, inMemoryBookRepo, inMemoryBookReviewRepo, shelfRepo)
+	commentFind := commentFinder.NewService(commentRepo)
+	shelfFind := shelfFinder.NewService(shelfRepo, inMemoryUserRepo)
+	recommendationFind := recommendationFinder.NewService(inMemoryBookRepo, inMemoryBookReviewRepo, inMemoryUserRepo, inMemoryUserFollowRepo)
+
+	// Ratings
+	bookRating := rating.NewService(inMemoryBookReviewRepo, inMemoryBookRepo)
+	// Books reviewed before the aggregates existed report no rating
+	if err := bookRating.Backfill(); err != nil {
+		log.Println("Failed to backfill the book ratings:", err)
+	}
 
 	// Creators
-	bookCreator := bookCreate.NewService(inMemoryBookRepo)
-	bookReviewCreator := create.NewService(inMemoryBookReviewRepo)
-	userCreator := userCreate.NewService(inMemoryUserRepo, cryptoRepo)
+	bookCreator := bookCreate.NewService(inMemoryBookRepo, eventBus)
+	bookReviewCreator := create.NewService(inMemoryBookReviewRepo, inMemoryBookRepo, bookRating, eventBus)
+	userCreator := userCreate.NewService(inMemoryUserRepo, cryptoRepo, eventBus)
+	commentCreator := commentCreate.NewService(commentRepo, inMemoryBookReviewRepo)
+	shelfCreator := shelfCreate.NewService(shelfRepo, inMemoryUserRepo)
 
 	// Updaters
-	bookUpdater := bookUpdate.NewService(inMemoryBookRepo)
-	bookReviewUpdater := update.NewService(inMemoryBookReviewRepo)
-	userUpdater := userUpdate.NewService(inMemoryUserRepo)
-	userFollower := userFollow.NewService(inMemoryUserFollowRepo)
+	bookUpdater := bookUpdate.NewService(inMemoryBookRepo, eventBus)
+	bookReviewUpdater := update.NewService(inMemoryBookReviewRepo, bookRating, eventBus)
+	bookReviewVoter := vote.NewService(inMemoryBookReviewRepo)
+	userUpdater := userUpdate.NewService(inMemoryUserRepo, eventBus)
+	userFollower := userFollow.NewService(inMemoryUserFollowRepo, eventBus)
+	commentUpdater := commentUpdate.NewService(commentRepo)
+	shelfUpdater := shelfUpdate.NewService(shelfRepo, inMemoryUserRepo)
+	challengeSetter := challengeSet.NewService(challengeRepo)
+	roleAssigner := userRoles.NewService(inMemoryUserRepo)
+	bootstrapAdmin(inMemoryUserRepo)
+	readingLogTracker := readingLogTrack.NewService(readingLogRepo, inMemoryBookRepo, userUpdater)
 
 	// Deletors
-	bookReviewDelete := delete.NewService(inMemoryBookReviewRepo)
-	userDeletor := userDelete.NewService(inMemoryUserRepo)
-	bookDeletor := bookDelete.NewService(inMemoryBookRepo)
+	bookReviewDelete := delete.NewService(inMemoryBookReviewRepo, bookRating, eventBus)
+	userDeletor := userDelete.NewService(inMemoryUserRepo, eventBus)
+	bookDeletor := bookDelete.NewService(inMemoryBookRepo, eventBus)
+	commentDeletor := commentDelete.NewService(commentRepo)
+	shelfDeletor := shelfDelete.NewService(shelfRepo)
+	shelfBooksService := shelfBooks.NewService(shelfRepo, inMemoryBookRepo, userUpdater, userDeletor)
+
+	// Cascades
+	bookReviewCascade.Subscribe(eventBus, inMemoryBookReviewRepo, bookReviewDelete)
+	userCascade.Subscribe(eventBus, inMemoryUserRepo)
+	userFollowCascade.Subscribe(eventBus, inMemoryUserFollowRepo)
+	challengeCascade.Subscribe(eventBus, challengeRepo)
+	commentCascade.Subscribe(eventBus, commentRepo)
+
+	// Subscribers
+	feedRecord.Subscribe(eventBus, activityRepo)
+	readingLogStatus.Subscribe(eventBus, readingLogRepo, inMemoryBookRepo)
+	shelfStatus.Subscribe(eventBus, shelfRepo, inMemoryUserRepo)
 
 	// Auth
-	authLogin := login.NewService(inMemoryUserRepo, cryptoRepo)
+	lockoutStore := newLockoutStore(redisClient)
+	authLogin := login.NewService(inMemoryUserRepo, cryptoRepo,
+		lockout.NewLimiter(lockoutStore, login.AccountPolicy, "login-account:"),
+		lockout.NewLimiter(lockoutStore, login.IPPolicy, "login-ip:"))
+	registrations := lockout.NewLimiter(lockoutStore, userCreate.RegistrationPolicy, "registration-ip:")
+	sessionStore := newSessionStore(dbClient, redisClient)
+	authRepo := jwt.NewAuth(sessionStore)
+	mailSender := newMailSender()
+	userPasswords := userPassword.NewService(inMemoryUserRepo, cryptoRepo, sessionStore, mailSender, newResetParams())
+	userVerifier := userVerification.NewService(inMemoryUserRepo, sessionStore, mailSender, newVerificationParams())
+	userVerification.Subscribe(eventBus, userVerifier)
+	socialLogin := userSocial.NewService(inMemoryUserRepo, sessionStore, eventBus, newOIDCProviders()...)
 
 	//Routes
-	books.RegisterRoutes(bookFind, bookReviewFinder, bookCreator, bookUpdater, bookDeletor, tokenParams.AccessSecret, router)
-	bookreviews.RegisterRoutes(bookReviewFinder, bookFind, userFind, bookReviewCreator, bookReviewUpdater, bookReviewDelete, tokenParams.AccessSecret, router)
-	users.RegisterRoutes(userFind, bookFind, bookReviewFinder, userCreator, userUpdater, userDeletor, authLogin, tokenParams, router)
-	userfollow.RegisterRoutes(userFollowFind, userFind, userFollower, tokenParams, router)
+	books.RegisterRoutes(bookFind, bookSearcher, bookReviewFinder, bookRanker, bookCreator, bookUpdater, bookDeletor, tokenParams.AccessKeys, authRepo, router)
+	bookreviews.RegisterRoutes(bookReviewFinder, bookFind, userFind, commentFind, bookReviewCreator, bookReviewUpdater, bookReviewDelete, bookReviewVoter, tokenParams.AccessKeys, authRepo, router)
+	comments.RegisterRoutes(commentFind, bookReviewFinder, userFind, commentCreator, commentUpdater, commentDeletor, tokenParams.AccessKeys, authRepo, router)
+	users.RegisterRoutes(userFind, bookFind, userCreator, userUpdater, userDeletor, authLogin, socialLogin, roleAssigner, userPasswords, userVerifier, registrations, tokenParams, authRepo, router)
+	userfollow.RegisterRoutes(userFollowFind, userFind, userFollower, tokenParams, authRepo, router)
+	feed.RegisterRoutes(feedFind, userFind, tokenParams.AccessKeys, authRepo, router)
+	readinglog.RegisterRoutes(readingLogFind, readingLogTracker, tokenParams.AccessKeys, authRepo, router)
+	challenges.RegisterRoutes(statsFind, challengeSetter, tokenParams.AccessKeys, authRepo, router)
+	shelves.RegisterRoutes(shelfFind, shelfCreator, shelfUpdater, shelfDeletor, shelfBooksService, tokenParams.AccessKeys, authRepo, router)
+	recommendations.RegisterRoutes(recommendationFind, tokenParams.AccessKeys, authRepo, router)
+	jwks.RegisterRoutes(tokenParams.AccessKeys, router)
 	healthcheck.RegisterRoutes(router)
 
 	return router
 }
+
+// bootstrapAdmin gives the admin role to the user in ADMIN_USERNAME, so a
+// fresh install has someone able to assign roles
+func bootstrapAdmin(repository userDomain.UserRepository) {
+	username := os.Getenv("ADMIN_USERNAME")
+	if username == "" {
+		return
+	}
+	user, err := repository.FindByUsername(username)
+	if err != nil {
+		log.Printf("Admin user %s not found", username)
+		return
+	}
+	if user.Role != userDomain.RoleAdmin {
+		if err := repository.UpdateRole(user.ID, userDomain.RoleAdmin); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// newRankingPrior reads the prior of the top rated books ranking from
+// RANKING_PRIOR_MEAN, RANKING_PRIOR_WEIGHT and RANKING_MIN_REVIEWS, unset
+// values keep the default ones
+func newRankingPrior() ranking.Prior {
+	prior := ranking.DefaultPrior
+	if mean, err := strconv.ParseFloat(os.Getenv("RANKING_PRIOR_MEAN"), 64); err == nil {
+		prior.Mean = mean
+	}
+	if weight, err := strconv.ParseFloat(os.Getenv("RANKING_PRIOR_WEIGHT"), 64); err == nil {
+		prior.Weight = weight
+	}
+	if minReviews, err := strconv.Atoi(os.Getenv("RANKING_MIN_REVIEWS")); err == nil {
+		prior.MinReviews = minReviews
+	}
+	return prior
+}
+
+// newMailSender selects how emails are sent with MAIL_SENDER: log (the
+// default) prints them, file saves them in the MAIL_OUTBOX_DIR folder and
+// smtp delivers them through SMTP_HOST:SMTP_PORT as MAIL_FROM, logging in
+// with SMTP_USERNAME and SMTP_PASSWORD when set
+func newMailSender() mail.Sender {
+	switch driver := os.Getenv("MAIL_SENDER"); driver {
+	case "smtp":
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			port = 587
+		}
+		return mail.NewSMTPSender(
+			os.Getenv("SMTP_HOST"), port,
+			os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("MAIL_FROM"))
+	case "", "log":
+		log.Println("Emails are written to the log")
+		return mail.NewLogSender(nil)
+	case "file":
+		dir := os.Getenv("MAIL_OUTBOX_DIR")
+		if dir == "" {
+			dir = "outbox"
+		}
+		sender, err := mail.NewFileSender(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return sender
+	default:
+		log.Fatalf("Unknown MAIL_SENDER: %s", driver)
+	}
+	return nil
+}
+
+// newResetParams reads the password reset page from PASSWORD_RESET_URL and
+// the reset token lifetime from PASSWORD_RESET_TTL (a duration like 30m)
+func newResetParams() userPassword.ResetParams {
+	params := userPassword.DefaultResetParams
+	params.URL = os.Getenv("PASSWORD_RESET_URL")
+	if ttl, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TTL")); err == nil && ttl > 0 {
+		params.TokenTTL = ttl
+	}
+	return params
+}
+
+// newVerificationParams reads the email verification settings: tokens are
+// signed with VERIFY_SECRET (ACCESS_SECRET when unset), last VERIFY_TTL
+// and link to VERIFY_URL
+func newVerificationParams() userVerification.Params {
+	params := userVerification.Params{
+		Secret:   os.Getenv("VERIFY_SECRET"),
+		TokenTTL: userVerification.DefaultTokenTTL,
+		URL:      os.Getenv("VERIFY_URL"),
+	}
+	if params.Secret == "" {
+		params.Secret = os.Getenv("ACCESS_SECRET")
+	}
+	if params.Secret == "" {
+		log.Fatal("VERIFY_SECRET is required when ACCESS_SECRET isn't set")
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("VERIFY_TTL")); err == nil && ttl > 0 {
+		params.TokenTTL = ttl
+	}
+	return params
+}
+
+// newAccessKeys signs the access tokens with the RSA or Ed25519 keys of
+// the JWT_KEYS_DIR folder, published in /.well-known/jwks.json, or with
+// HS256 and ACCESS_SECRET when it isn't set
+func newAccessKeys() jwt.KeySet {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		return jwt.NewHMACKeySet(os.Getenv("ACCESS_SECRET"))
+	}
+	keys, err := jwt.LoadKeys(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keySet, err := jwt.NewKeySet(keys...)
+	if err != nil {
+		log.Fatalf("JWT_KEYS_DIR %s: %s", dir, err)
+	}
+	return keySet
+}
+
+// newOIDCProviders returns the OpenID Connect providers listed in
+// OIDC_PROVIDERS (like OIDC_PROVIDERS=google,gitlab). Each provider reads
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET,
+// OIDC_<NAME>_REDIRECT_URL (the API /login/<name>/callback URL) and the
+// optional space separated OIDC_<NAME>_SCOPES.
+func newOIDCProviders() []oidc.Provider {
+	providers := []oidc.Provider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+			log.Fatalf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", prefix, prefix, prefix)
+		}
+		providers = append(providers, oidc.NewProvider(config, nil))
+	}
+	return providers
+}
+
+// newRateLimitRules returns the quotas of each route group, they can be
+// changed with RATE_LIMIT_<GROUP> (like RATE_LIMIT_BOOKS=300/1m)
+func newRateLimitRules() []m.RateLimitRule {
+	rules := []m.RateLimitRule{
+		{
+			Name:     "reviews",
+			Prefixes: []string{"/books/:id/reviews", "/book/reviews", "/users/:id/reviews", "/book/comments"},
+			Quota:    ratelimit.Quota{Limit: 120, Period: time.Minute},
+		},
+		{
+			Name:     "follow",
+			Prefixes: []string{"/users/:id/followers", "/users/:id/following", "/user/follow", "/user/unfollow"},
+			Quota:    ratelimit.Quota{Limit: 30, Period: time.Minute},
+		},
+		{
+			Name:     "books",
+			Prefixes: []string{"/books"},
+			Quota:    ratelimit.Quota{Limit: 300, Period: time.Minute},
+		},
+		{
+			Name:     "users",
+			Prefixes: []string{"/users", "/user", "/login", "/logout", "/token"},
+			Quota:    ratelimit.Quota{Limit: 60, Period: time.Minute},
+		},
+		{
+			Name:     "default",
+			Prefixes: []string{"/"},
+			Quota:    ratelimit.Quota{Limit: 300, Period: time.Minute},
+		},
+	}
+	for i := range rules {
+		value := os.Getenv("RATE_LIMIT_" + strings.ToUpper(rules[i].Name))
+		if value == "" {
+			continue
+		}
+		quota, err := ratelimit.ParseQuota(value)
+		if err != nil {
+			log.Fatalf("Invalid RATE_LIMIT_%s: %s", strings.ToUpper(rules[i].Name), err)
+		}
+		rules[i].Quota = quota
+	}
+	return rules
+}
+
+// newRedisClient returns the getter of the Redis client shared by the
+// session, lockout and rate limit stores, it only connects to REDIS_DSN
+// when a store needs it
+func newRedisClient() func() *redis.Client {
+	var once sync.Once
+	var client *redis.Client
+	return func() *redis.Client {
+		once.Do(func() {
+			client = config.ConnectRedis(os.Getenv("REDIS_DSN"), os.Getenv("REDIS_PASSWORD"))
+		})
+		return client
+	}
+}
+
+// newRateLimitStore selects where the rate limit buckets are kept with
+// RATE_LIMIT_STORE (memory or redis). Defaults to redis when REDIS_DSN is
+// set and to memory otherwise.
+func newRateLimitStore(redisClient func() *redis.Client) ratelimit.Store {
+	driver := os.Getenv("RATE_LIMIT_STORE")
+	if driver == "" && os.Getenv("REDIS_DSN") != "" {
+		driver = "redis"
+	}
+	switch driver {
+	case "redis":
+		return ratelimit.NewRedisStore(redisClient())
+	case "", "memory":
+		log.Println("Using in memory rate limits")
+		return ratelimit.NewMemoryStore()
+	}
+	log.Fatalf("Unknown RATE_LIMIT_STORE: %s", driver)
+	return nil
+}
+
+// newLockoutStore selects where the failed logins are counted with
+// LOCKOUT_STORE (memory or redis). Defaults to redis when REDIS_DSN is set,
+// so every instance shares the counters, and to memory otherwise.
+func newLockoutStore(redisClient func() *redis.Client) lockout.Store {
+	driver := os.Getenv("LOCKOUT_STORE")
+	if driver == "" && os.Getenv("REDIS_DSN") != "" {
+		driver = "redis"
+	}
+	switch driver {
+	case "redis":
+		return lockout.NewRedisStore(redisClient())
+	case "", "memory":
+		log.Println("Using in memory login lockouts")
+		return lockout.NewMemoryStore()
+	}
+	log.Fatalf("Unknown LOCKOUT_STORE: %s", driver)
+	return nil
+}
+
+// newSessionStore selects where sessions are saved with SESSION_STORE
+// (memory, redis or mongo). Defaults to redis when REDIS_DSN is set and
+// to memory otherwise.
+func newSessionStore(dbClient *mongo.Database, redisClient func() *redis.Client) session.Store {
+	driver := os.Getenv("SESSION_STORE")
+	if driver == "" && os.Getenv("REDIS_DSN") != "" {
+		driver = "redis"
+	}
+	switch driver {
+	case "redis":
+		return session.NewRedisStore(redisClient())
+	case "mongo":
+		return session.NewMongoStore(dbClient)
+	case "", "memory":
+		log.Println("Using in memory sessions")
+		return session.NewMemoryStore()
+	}
+	log.Fatalf("Unknown SESSION_STORE: %s", driver)
+	return nil
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"log"
+
+	"github.com/go-redis/redis"
+)
+
+// ConnectRedis ...
+func ConnectRedis(dsn, password string) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     dsn,
+		Password: password,
+	})
+	if _, err := client.Ping().Result(); err != nil {
+		log.Fatalf("Failed to connect to Redis: %s", err.Error())
+	}
+	return client
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"math"
 	"time"
 
 	"something/internal/bookreviews/domain"
@@ -13,6 +14,8 @@ type BookReviewResponse struct {
 	Rating    float64 `json:"rating"`
 	BookID    string  `json:"book_id"`
 	User      `json:"user"`
+	Comments  int       `json:"comments"`
+	Helpful   int       `json:"helpful"`
 	CreatedOn time.Time `json:"created_on"`
 }
 
@@ -22,6 +25,7 @@ type BookRatingResponse struct {
 	Title  string  `json:"title"`
 	Author string  `json:"author"`
 	Rating float64 `json:"rating"`
+	Score  float64 `json:"score"`
 	Total  int     `json:"total"`
 }
 
@@ -40,6 +44,7 @@ func NewBookReviewResponse(bookReview *domain.BookReview) *BookReviewResponse {
 		Rating:    bookReview.Rating,
 		BookID:    bookReview.BookID,
 		User:      User{ID: bookReview.UserID},
+		Helpful:   bookReview.Helpful,
 		CreatedOn: bookReview.CreatedOn,
 	}
 }
@@ -56,7 +61,8 @@ func NewReviewsResponse(bookReviews []*domain.BookReview) []*BookReviewResponse
 // NewBookReviewShortResponse ...
 func NewBookReviewShortResponse(bookReview *domain.BookReviewShort) *BookRatingResponse {
 	return &BookRatingResponse{
-		Rating: bookReview.Rating,
+		Rating: math.Round(bookReview.Rating*100) / 100,
+		Score:  math.Round(bookReview.Score*100) / 100,
 		BookID: bookReview.ID,
 		Total:  bookReview.Total,
 	}
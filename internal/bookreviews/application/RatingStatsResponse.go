@@ -0,0 +1,31 @@
+package application
+
+import "something/internal/bookreviews/domain"
+
+// RatingStatsResponse ...
+type RatingStatsResponse struct {
+	Total     int                    `json:"total"`
+	Mean      float64                `json:"mean"`
+	Median    float64                `json:"median"`
+	Histogram []*RatingCountResponse `json:"histogram"`
+}
+
+// RatingCountResponse ...
+type RatingCountResponse struct {
+	Rating float64 `json:"rating"`
+	Count  int     `json:"count"`
+}
+
+// NewRatingStatsResponse ...
+func NewRatingStatsResponse(stats *domain.RatingStats) *RatingStatsResponse {
+	histogram := []*RatingCountResponse{}
+	for _, bucket := range stats.Histogram {
+		histogram = append(histogram, &RatingCountResponse{Rating: bucket.Rating, Count: bucket.Count})
+	}
+	return &RatingStatsResponse{
+		Total:     stats.Total,
+		Mean:      stats.Mean,
+		Median:    stats.Median,
+		Histogram: histogram,
+	}
+}
@@ -0,0 +1,47 @@
+package cascade
+
+import (
+	"something/internal/bookreviews/application/delete"
+	"something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe removes the reviews of deleted books and users. They go
+// through the delete service so every review publishes its ReviewDeleted
+// and the feed, comments and book ratings are cleaned up too. The helpful
+// votes of deleted users are pulled from the remaining reviews.
+func Subscribe(bus eventbus.Bus, repository domain.BookReviewRepository, deleter delete.Service) {
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		bookReviews, err := repository.Find(domain.NewReviewListCriteria(event.(*bookDomain.BookDeleted).BookID, ""))
+		if err != nil {
+			return err
+		}
+		return deleteAll(deleter, bookReviews)
+	})
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		userID := event.(*userDomain.UserDeleted).UserID
+		bookReviews, err := repository.FindByUserID(userID)
+		if err != nil {
+			return err
+		}
+		deleteErr := deleteAll(deleter, bookReviews)
+		if err := repository.RemoveUserVotes(userID); err != nil {
+			return err
+		}
+		return deleteErr
+	})
+}
+
+// deleteAll deletes every review, one failure doesn't stop the others
+func deleteAll(deleter delete.Service, bookReviews []*domain.BookReview) error {
+	var firstErr error
+	for _, bookReview := range bookReviews {
+		err := deleter.DeleteBookReviewByID(bookReview.ID)
+		if err != nil && err.Error() != "book review not found" && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
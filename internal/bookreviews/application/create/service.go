@@ -2,7 +2,10 @@ package create
 
 import (
 	"errors"
+	"something/internal/bookreviews/application/rating"
 	"something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -11,12 +14,19 @@ type Service interface {
 }
 
 type service struct {
-	repository domain.BookReviewRepository
+	repository     domain.BookReviewRepository
+	bookRepository bookDomain.BookRepository
+	ratings        rating.Service
+	bus            eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookReviewRepository) Service {
-	return &service{repository: repository}
+func NewService(
+	repository domain.BookReviewRepository,
+	bookRepository bookDomain.BookRepository,
+	ratings rating.Service,
+	bus eventbus.Bus) Service {
+	return &service{repository: repository, bookRepository: bookRepository, ratings: ratings, bus: bus}
 }
 
 func (s *service) CreateBookReview(command *BookReviewCommand) error {
@@ -30,10 +40,20 @@ func (s *service) CreateBookReview(command *BookReviewCommand) error {
 	if existingReviewID != nil {
 		return errors.New("book review id already exists")
 	}
+	existingReview, _ := s.repository.FindByBookAndUser(command.BookID, command.UserID)
+	if existingReview != nil {
+		return errors.New("book already reviewed")
+	}
+	existingBook, _ := s.bookRepository.FindByID(command.BookID)
+	if existingBook == nil {
+		return errors.New("book not found")
+	}
 
 	err = s.repository.Save(bookReview)
 	if err != nil {
 		return err
 	}
-	return nil
+	err = s.ratings.Recompute(bookReview.BookID)
+	s.bus.Publish(domain.NewReviewCreated(bookReview))
+	return err
 }
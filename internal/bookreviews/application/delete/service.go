@@ -2,7 +2,9 @@ package delete
 
 import (
 	"errors"
+	"something/internal/bookreviews/application/rating"
 	"something/internal/bookreviews/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -12,11 +14,13 @@ type Service interface {
 
 type service struct {
 	repository domain.BookReviewRepository
+	ratings    rating.Service
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookReviewRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.BookReviewRepository, ratings rating.Service, bus eventbus.Bus) Service {
+	return &service{repository: repository, ratings: ratings, bus: bus}
 }
 
 func (s *service) DeleteBookReviewByID(id string) error {
@@ -25,5 +29,10 @@ func (s *service) DeleteBookReviewByID(id string) error {
 		return errors.New("book review not found")
 	}
 	err := s.repository.Delete(id)
+	if err != nil {
+		return err
+	}
+	err = s.ratings.Recompute(bookReview.BookID)
+	s.bus.Publish(domain.NewReviewDeleted(bookReview))
 	return err
 }
@@ -1,8 +1,16 @@
 package find
 
-// Criteria ...
-type Criteria struct {
-	Page    int
-	PerPage int
-	Sort    int
+import "time"
+
+// ReviewListCriteria filters and paginates the reviews of a book or a user
+type ReviewListCriteria struct {
+	BookID    string
+	UserID    string
+	MinRating float64
+	MaxRating float64
+	From      *time.Time
+	To        *time.Time
+	Sort      string
+	Page      int
+	PerPage   int
 }
@@ -5,11 +5,20 @@ import (
 	"something/internal/bookreviews/domain"
 )
 
+// PAGE Default pagination page
+const PAGE int = 1
+
+// PERPAGE Default number of reviews per page
+const PERPAGE int = 20
+
+// MAXPERPAGE Maximum number of reviews per page
+const MAXPERPAGE int = 100
+
 // Service ...
 type Service interface {
-	FindBookReviews(bookID string) ([]*application.BookReviewResponse, error)
+	FindBookReviews(criteria *ReviewListCriteria) ([]*application.BookReviewResponse, error)
 	FindBookReviewByID(id string) (*application.BookReviewResponse, error)
-	FindReviews(criteria *Criteria) ([]*application.BookRatingResponse, error)
+	FindRatingStats(bookID string) (*application.RatingStatsResponse, error)
 }
 
 type service struct {
@@ -21,8 +30,24 @@ func NewService(repository domain.BookReviewRepository) Service {
 	return &service{repository: repository}
 }
 
-func (s *service) FindBookReviews(bookID string) ([]*application.BookReviewResponse, error) {
-	bookReviews, err := s.repository.Find(bookID)
+// FindBookReviews returns a page of the reviews of a book or of a user
+func (s *service) FindBookReviews(criteria *ReviewListCriteria) ([]*application.BookReviewResponse, error) {
+	if criteria.Page <= 0 {
+		criteria.Page = PAGE
+	}
+	if criteria.PerPage <= 0 || criteria.PerPage > MAXPERPAGE {
+		criteria.PerPage = PERPAGE
+	}
+
+	listCriteria := domain.NewReviewListCriteria(criteria.BookID, criteria.Sort).
+		Paginate(criteria.Page, criteria.PerPage)
+	listCriteria.UserID = criteria.UserID
+	listCriteria.MinRating = criteria.MinRating
+	listCriteria.MaxRating = criteria.MaxRating
+	listCriteria.From = criteria.From
+	listCriteria.To = criteria.To
+
+	bookReviews, err := s.repository.Find(listCriteria)
 	if err != nil {
 		return nil, err
 	}
@@ -37,11 +62,12 @@ func (s *service) FindBookReviewByID(id string) (*application.BookReviewResponse
 	return application.NewBookReviewResponse(bookReview), nil
 }
 
-func (s *service) FindReviews(criteria *Criteria) ([]*application.BookRatingResponse, error) {
-	newBookReviewCriteria := domain.NewBookReviewCriteria(criteria.Sort)
-	bookReviews, err := s.repository.FindReviews(newBookReviewCriteria)
+// FindRatingStats returns the rating histogram, mean, median and total
+// reviews of a book
+func (s *service) FindRatingStats(bookID string) (*application.RatingStatsResponse, error) {
+	ratingCounts, err := s.repository.RatingCounts(bookID)
 	if err != nil {
 		return nil, err
 	}
-	return application.NewReviewShortResponse(bookReviews), nil
+	return application.NewRatingStatsResponse(domain.NewRatingStats(ratingCounts)), nil
 }
@@ -0,0 +1,10 @@
+package ranking
+
+// Criteria ...
+type Criteria struct {
+	Sort    int
+	Page    int
+	PerPage int
+	Genre   string
+	Period  string
+}
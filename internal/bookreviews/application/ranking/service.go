@@ -0,0 +1,126 @@
+package ranking
+
+import (
+	"errors"
+	"time"
+
+	"something/internal/bookreviews/application"
+	"something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+)
+
+// PAGE Default pagination page
+const PAGE int = 1
+
+// PERPAGE Default number of books per page
+const PERPAGE int = 25
+
+// MAXPERPAGE Maximum number of books per page
+const MAXPERPAGE int = 100
+
+// Ranking orders, the best rated books first by default
+const (
+	SortAsc  = 1
+	SortDesc = -1
+)
+
+// Prior is the belief about a book before reading its reviews. Scores start
+// at Mean and move towards the real mean as reviews add up, Weight is the
+// number of reviews the prior counts as. Books with less than MinReviews
+// reviews are left out.
+type Prior struct {
+	Mean       float64
+	Weight     float64
+	MinReviews int
+}
+
+// DefaultPrior ...
+var DefaultPrior = Prior{Mean: 3, Weight: 10, MinReviews: 1}
+
+// Periods of the time window, reviews older than the window don't count
+const (
+	PeriodAll   = "all"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// Service ...
+type Service interface {
+	RankBooks(criteria *Criteria) ([]*application.BookRatingResponse, error)
+}
+
+type service struct {
+	repository     domain.BookReviewRepository
+	bookRepository bookDomain.BookRepository
+	prior          Prior
+}
+
+// NewService ...
+func NewService(repository domain.BookReviewRepository, bookRepository bookDomain.BookRepository, prior Prior) Service {
+	return &service{repository: repository, bookRepository: bookRepository, prior: prior}
+}
+
+// RankBooks returns a page of the leaderboard, books ranked by the bayesian
+// average of their ratings so a single 5 star review doesn't beat hundreds
+// of 4.5 ones
+func (s *service) RankBooks(criteria *Criteria) ([]*application.BookRatingResponse, error) {
+	from, err := periodStart(criteria.Period, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if criteria.Sort != SortAsc {
+		criteria.Sort = SortDesc
+	}
+	if criteria.Page <= 0 {
+		criteria.Page = PAGE
+	}
+	if criteria.PerPage <= 0 || criteria.PerPage > MAXPERPAGE {
+		criteria.PerPage = PERPAGE
+	}
+
+	rankingCriteria := domain.NewBookReviewCriteria(
+		criteria.Sort, criteria.Page, criteria.PerPage,
+		s.prior.Mean, s.prior.Weight, s.prior.MinReviews)
+	rankingCriteria.From = from
+
+	if criteria.Genre != "" {
+		books, err := s.bookRepository.Find(bookDomain.NewBookCriteria(
+			1, 0, "", criteria.Genre, "", 0, bookDomain.SortNone))
+		if err != nil {
+			return nil, err
+		}
+		rankingCriteria.BookIDs = []string{}
+		for _, book := range books {
+			rankingCriteria.BookIDs = append(rankingCriteria.BookIDs, book.ID)
+		}
+		if len(rankingCriteria.BookIDs) == 0 {
+			return []*application.BookRatingResponse{}, nil
+		}
+	}
+
+	bookReviews, err := s.repository.FindReviews(rankingCriteria)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewReviewShortResponse(bookReviews), nil
+}
+
+// periodStart returns when the time window of the period starts, nil for
+// the whole history
+func periodStart(period string, now time.Time) (*time.Time, error) {
+	var from time.Time
+	switch period {
+	case "", PeriodAll:
+		return nil, nil
+	case PeriodWeek:
+		from = now.AddDate(0, 0, -7)
+	case PeriodMonth:
+		from = now.AddDate(0, -1, 0)
+	case PeriodYear:
+		from = now.AddDate(-1, 0, 0)
+	default:
+		return nil, errors.New("period must be one of week, month, year or all")
+	}
+	return &from, nil
+}
@@ -0,0 +1,60 @@
+package rating
+
+import (
+	"something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+)
+
+// Service keeps the rating aggregates of the books in sync with their
+// reviews. The create, update and delete services recompute them in the
+// same request as the review, so a failure reaches the caller. The
+// aggregates are always recomputed from the reviews, so a failed or
+// concurrent update is fixed by the next one instead of leaving the sum
+// and count drifting.
+type Service interface {
+	// Recompute sets the aggregates of the book from its reviews
+	Recompute(bookID string) error
+	// Backfill recomputes the aggregates of every reviewed book
+	Backfill() error
+}
+
+type service struct {
+	repository     domain.BookReviewRepository
+	bookRepository bookDomain.BookRepository
+}
+
+// NewService ...
+func NewService(repository domain.BookReviewRepository, bookRepository bookDomain.BookRepository) Service {
+	return &service{repository: repository, bookRepository: bookRepository}
+}
+
+func (s *service) Recompute(bookID string) error {
+	ratingCounts, err := s.repository.RatingCounts(bookID)
+	if err != nil {
+		return err
+	}
+	sum, count := 0.0, 0
+	for _, ratingCount := range ratingCounts {
+		sum += ratingCount.Rating * float64(ratingCount.Count)
+		count += ratingCount.Count
+	}
+	err = s.bookRepository.SetRating(bookID, sum, count)
+	// The reviews of deleted books are removed after the book
+	if err != nil && err.Error() == "book not found" {
+		return nil
+	}
+	return err
+}
+
+func (s *service) Backfill() error {
+	bookIDs, err := s.repository.RatedBookIDs()
+	if err != nil {
+		return err
+	}
+	for _, bookID := range bookIDs {
+		if err := s.Recompute(bookID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,19 +1,25 @@
 package update
 
 import (
+	"errors"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
 // BookReviewCommand ...
 type BookReviewCommand struct {
-	ID     string `json:"id"`
-	Text   string `json:"text"`
-	UserID string `json:"user_id"`
+	ID     string  `json:"id"`
+	Text   string  `json:"text,omitempty"`
+	Rating float64 `json:"rating,omitempty"`
 }
 
 // Validate ...
 func (b BookReviewCommand) Validate() error {
+	if b.Text == "" && b.Rating == 0 {
+		return errors.New("text or rating is required")
+	}
 	return validation.ValidateStruct(&b,
-		validation.Field(&b.Text, validation.Required, validation.Length(1, 250)),
+		validation.Field(&b.Text, validation.Length(1, 250)),
+		validation.Field(&b.Rating, validation.Min(0.5), validation.Max(5.0)),
 	)
 }
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"strings"
 
+	"something/internal/bookreviews/application/rating"
 	"something/internal/bookreviews/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -15,11 +17,13 @@ type Service interface {
 
 type service struct {
 	repository domain.BookReviewRepository
+	ratings    rating.Service
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookReviewRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.BookReviewRepository, ratings rating.Service, bus eventbus.Bus) Service {
+	return &service{repository: repository, ratings: ratings, bus: bus}
 }
 
 func (s *service) UpdateBookReviewByID(bookReview *BookReviewCommand) error {
@@ -27,9 +31,8 @@ func (s *service) UpdateBookReviewByID(bookReview *BookReviewCommand) error {
 	if existingBookReview == nil {
 		return errors.New("book review not found")
 	}
-	if existingBookReview.UserID != bookReview.UserID {
-		return errors.New("unauthorized")
-	}
+
+	previousReview := *existingBookReview
 
 	out, err := json.Marshal(bookReview)
 	if err != nil {
@@ -47,8 +50,17 @@ func (s *service) UpdateBookReviewByID(bookReview *BookReviewCommand) error {
 	if err != nil {
 		return err
 	}
+	updatedBookReview.HelpfulVotes = existingBookReview.HelpfulVotes
+	updatedBookReview.Helpful = existingBookReview.Helpful
 	updatedBookReview.CreatedOn = existingBookReview.CreatedOn
 
 	err = s.repository.Update(updatedBookReview)
+	if err != nil {
+		return err
+	}
+	if updatedBookReview.Rating != previousReview.Rating {
+		err = s.ratings.Recompute(updatedBookReview.BookID)
+	}
+	s.bus.Publish(domain.NewReviewUpdated(updatedBookReview, previousReview.Rating))
 	return err
 }
@@ -0,0 +1,40 @@
+package vote
+
+import (
+	"errors"
+
+	"something/internal/bookreviews/domain"
+)
+
+// Service ...
+type Service interface {
+	Vote(reviewID, userID string) error
+	Unvote(reviewID, userID string) error
+}
+
+type service struct {
+	repository domain.BookReviewRepository
+}
+
+// NewService ...
+func NewService(repository domain.BookReviewRepository) Service {
+	return &service{repository: repository}
+}
+
+// Vote marks the review as helpful for the user, once per user and never
+// on their own reviews
+func (s *service) Vote(reviewID, userID string) error {
+	review, err := s.repository.FindByID(reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID == userID {
+		return errors.New("can't vote your own review")
+	}
+	return s.repository.AddVote(reviewID, userID)
+}
+
+// Unvote undoes the helpful vote of the user
+func (s *service) Unvote(reviewID, userID string) error {
+	return s.repository.RemoveVote(reviewID, userID)
+}
@@ -4,29 +4,36 @@ import "time"
 
 // BookReview ...
 type BookReview struct {
-	ID        string
-	Text      string
-	Rating    float64
-	BookID    string
-	UserID    string
-	CreatedOn time.Time
+	ID     string
+	Text   string
+	Rating float64
+	BookID string
+	UserID string
+	// HelpfulVotes are the users that found the review helpful, Helpful
+	// is their count kept for sorting
+	HelpfulVotes []string
+	Helpful      int
+	CreatedOn    time.Time
 }
 
-// BookReviewShort ...
+// BookReviewShort is a leaderboard entry, Rating is the mean of the reviews
+// and Score their bayesian average
 type BookReviewShort struct {
 	ID     string `bson:"_id,omitempty"`
 	Rating float64
+	Score  float64
 	Total  int
 }
 
 // NewBookReview ...
 func NewBookReview(id, text string, rating float64, bookID, userID string) (*BookReview, error) {
 	return &BookReview{
-		ID:        id,
-		Text:      text,
-		Rating:    rating,
-		BookID:    bookID,
-		UserID:    userID,
-		CreatedOn: time.Now().UTC(),
+		ID:           id,
+		Text:         text,
+		Rating:       rating,
+		BookID:       bookID,
+		UserID:       userID,
+		HelpfulVotes: []string{},
+		CreatedOn:    time.Now().UTC(),
 	}, nil
 }
@@ -1,13 +1,96 @@
 package domain
 
-// BookReviewCriteria ...
+import "time"
+
+// BookReviewCriteria selects a page of the books leaderboard. Books are
+// ranked by the bayesian average of the reviews written since From, a nil
+// BookIDs ranks every book
 type BookReviewCriteria struct {
-	Sort int64
+	Sort        int64
+	PriorMean   float64
+	PriorWeight float64
+	MinReviews  int64
+	BookIDs     []string
+	From        *time.Time
+	Page        int64
+	PerPage     int64
 }
 
 // NewBookReviewCriteria ...
-func NewBookReviewCriteria(sort int) *BookReviewCriteria {
+func NewBookReviewCriteria(sort, page, perPage int, priorMean, priorWeight float64, minReviews int) *BookReviewCriteria {
 	return &BookReviewCriteria{
-		Sort: int64(sort),
+		Sort:        int64(sort),
+		PriorMean:   priorMean,
+		PriorWeight: priorWeight,
+		MinReviews:  int64(minReviews),
+		Page:        int64(page),
+		PerPage:     int64(perPage),
+	}
+}
+
+// BayesianAverage pulls the mean of few reviews towards the prior mean, as
+// if the book had priorWeight more reviews rated with the prior mean
+func BayesianAverage(sum float64, total int, priorMean, priorWeight float64) float64 {
+	if priorWeight+float64(total) == 0 {
+		return 0
+	}
+	return (priorMean*priorWeight + sum) / (priorWeight + float64(total))
+}
+
+// Review list orders, SortRecent is the default
+const (
+	SortRecent  = "recent"
+	SortHelpful = "helpful"
+	SortRating  = "rating"
+)
+
+// ReviewListCriteria selects the reviews of a book or of a user. Zero
+// values leave a filter out, PerPage 0 returns every matching review
+type ReviewListCriteria struct {
+	BookID    string
+	UserID    string
+	MinRating float64
+	MaxRating float64
+	From      *time.Time
+	To        *time.Time
+	Sort      string
+	Page      int64
+	PerPage   int64
+}
+
+// NewReviewListCriteria ...
+func NewReviewListCriteria(bookID, sort string) *ReviewListCriteria {
+	if sort != SortHelpful && sort != SortRating {
+		sort = SortRecent
+	}
+	return &ReviewListCriteria{
+		BookID: bookID,
+		Sort:   sort,
+	}
+}
+
+// Paginate limits the result to the given page
+func (c *ReviewListCriteria) Paginate(page, perPage int) *ReviewListCriteria {
+	c.Page = int64(page)
+	c.PerPage = int64(perPage)
+	return c
+}
+
+// Matches reports whether the review passes every filter of the criteria
+func (c *ReviewListCriteria) Matches(review *BookReview) bool {
+	switch {
+	case c.BookID != "" && review.BookID != c.BookID:
+		return false
+	case c.UserID != "" && review.UserID != c.UserID:
+		return false
+	case c.MinRating > 0 && review.Rating < c.MinRating:
+		return false
+	case c.MaxRating > 0 && review.Rating > c.MaxRating:
+		return false
+	case c.From != nil && review.CreatedOn.Before(*c.From):
+		return false
+	case c.To != nil && review.CreatedOn.After(*c.To):
+		return false
 	}
+	return true
 }
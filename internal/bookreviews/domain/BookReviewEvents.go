@@ -0,0 +1,81 @@
+package domain
+
+import "something/pkg/eventbus"
+
+// Book review event names
+const (
+	ReviewCreatedEvent = "review.created"
+	ReviewUpdatedEvent = "review.updated"
+	ReviewDeletedEvent = "review.deleted"
+)
+
+// ReviewCreated ...
+type ReviewCreated struct {
+	eventbus.BaseEvent
+	ReviewID string
+	BookID   string
+	UserID   string
+	Rating   float64
+}
+
+// NewReviewCreated ...
+func NewReviewCreated(review *BookReview) *ReviewCreated {
+	return &ReviewCreated{
+		BaseEvent: eventbus.NewBaseEvent(),
+		ReviewID:  review.ID,
+		BookID:    review.BookID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+	}
+}
+
+// Name ...
+func (e *ReviewCreated) Name() string { return ReviewCreatedEvent }
+
+// ReviewUpdated ...
+type ReviewUpdated struct {
+	eventbus.BaseEvent
+	ReviewID       string
+	BookID         string
+	UserID         string
+	Rating         float64
+	PreviousRating float64
+}
+
+// NewReviewUpdated ...
+func NewReviewUpdated(review *BookReview, previousRating float64) *ReviewUpdated {
+	return &ReviewUpdated{
+		BaseEvent:      eventbus.NewBaseEvent(),
+		ReviewID:       review.ID,
+		BookID:         review.BookID,
+		UserID:         review.UserID,
+		Rating:         review.Rating,
+		PreviousRating: previousRating,
+	}
+}
+
+// Name ...
+func (e *ReviewUpdated) Name() string { return ReviewUpdatedEvent }
+
+// ReviewDeleted ...
+type ReviewDeleted struct {
+	eventbus.BaseEvent
+	ReviewID string
+	BookID   string
+	UserID   string
+	Rating   float64
+}
+
+// NewReviewDeleted ...
+func NewReviewDeleted(review *BookReview) *ReviewDeleted {
+	return &ReviewDeleted{
+		BaseEvent: eventbus.NewBaseEvent(),
+		ReviewID:  review.ID,
+		BookID:    review.BookID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+	}
+}
+
+// Name ...
+func (e *ReviewDeleted) Name() string { return ReviewDeletedEvent }
@@ -2,10 +2,19 @@ package domain
 
 // BookReviewRepository ...
 type BookReviewRepository interface {
-	Find(string) ([]*BookReview, error)
+	Find(*ReviewListCriteria) ([]*BookReview, error)
 	FindByID(string) (*BookReview, error)
+	FindByUserID(string) ([]*BookReview, error)
+	FindByBookAndUser(bookID, userID string) (*BookReview, error)
+	FindCoReviews(bookIDs []string, userID string, limit int64) ([]*BookReview, error)
+	FindByUserIDs(userIDs []string, minRating float64) ([]*BookReview, error)
 	FindReviews(*BookReviewCriteria) ([]*BookReviewShort, error)
+	RatingCounts(bookID string) ([]*RatingCount, error)
+	RatedBookIDs() ([]string, error)
 	Update(*BookReview) error
 	Save(*BookReview) error
+	AddVote(reviewID, userID string) error
+	RemoveVote(reviewID, userID string) error
+	RemoveUserVotes(userID string) error
 	Delete(string) error
 }
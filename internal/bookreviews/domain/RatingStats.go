@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// RatingCount is the number of reviews of a book with the same rating
+type RatingCount struct {
+	Rating float64 `bson:"_id"`
+	Count  int
+}
+
+// RatingStats summarizes the ratings of a book. The histogram has a bucket
+// for every half star, mean and median use the exact ratings
+type RatingStats struct {
+	Total     int
+	Mean      float64
+	Median    float64
+	Histogram []*RatingCount
+}
+
+// Half star buckets of the histogram
+const (
+	minBucket  = 0.5
+	maxBucket  = 5.0
+	bucketSize = 0.5
+)
+
+// NewRatingStats builds the stats of a book from its review count per rating
+func NewRatingStats(counts []*RatingCount) *RatingStats {
+	stats := &RatingStats{Histogram: []*RatingCount{}}
+	buckets := map[float64]*RatingCount{}
+	for rating := minBucket; rating <= maxBucket; rating += bucketSize {
+		bucket := &RatingCount{Rating: rating}
+		buckets[rating] = bucket
+		stats.Histogram = append(stats.Histogram, bucket)
+	}
+
+	sorted := make([]*RatingCount, 0, len(counts))
+	sum := 0.0
+	for _, count := range counts {
+		if count.Count <= 0 {
+			continue
+		}
+		sorted = append(sorted, count)
+		stats.Total += count.Count
+		sum += count.Rating * float64(count.Count)
+		if bucket, ok := buckets[math.Round(count.Rating/bucketSize)*bucketSize]; ok {
+			bucket.Count += count.Count
+		}
+	}
+	if stats.Total == 0 {
+		return stats
+	}
+	stats.Mean = sum / float64(stats.Total)
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Rating < sorted[j].Rating })
+	if stats.Total%2 == 1 {
+		stats.Median = ratingAt(sorted, stats.Total/2)
+	} else {
+		stats.Median = (ratingAt(sorted, stats.Total/2-1) + ratingAt(sorted, stats.Total/2)) / 2
+	}
+	return stats
+}
+
+// ratingAt returns the rating in the given position of the ascending list
+// of every review rating
+func ratingAt(sorted []*RatingCount, position int) float64 {
+	for _, count := range sorted {
+		if position < count.Count {
+			return count.Rating
+		}
+		position -= count.Count
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"errors"
+	"sort"
 
 	"something/internal/bookreviews/domain"
 )
@@ -22,15 +23,37 @@ func NewInMemoryBookReviewsRepository() domain.BookReviewRepository {
 	return reviewInstance
 }
 
-func (r *repository) Find(bookID string) ([]*domain.BookReview, error) {
+func (r *repository) Find(criteria *domain.ReviewListCriteria) ([]*domain.BookReview, error) {
 	var bookReviews []*domain.BookReview
 	for _, bookReview := range r.bookReviews {
-		if bookReview.BookID == bookID {
+		if criteria.Matches(bookReview) {
 			bookReviews = append(bookReviews, bookReview)
 		}
 	}
 
-	return bookReviews, nil
+	sort.Slice(bookReviews, func(i, j int) bool {
+		a, b := bookReviews[i], bookReviews[j]
+		switch {
+		case criteria.Sort == domain.SortHelpful && a.Helpful != b.Helpful:
+			return a.Helpful > b.Helpful
+		case criteria.Sort == domain.SortRating && a.Rating != b.Rating:
+			return a.Rating > b.Rating
+		}
+		return a.CreatedOn.After(b.CreatedOn)
+	})
+
+	if criteria.PerPage == 0 {
+		return bookReviews, nil
+	}
+	start := (criteria.Page - 1) * criteria.PerPage
+	if start >= int64(len(bookReviews)) {
+		return []*domain.BookReview{}, nil
+	}
+	end := start + criteria.PerPage
+	if end > int64(len(bookReviews)) {
+		end = int64(len(bookReviews))
+	}
+	return bookReviews[start:end], nil
 }
 
 func (r *repository) FindByID(id string) (*domain.BookReview, error) {
@@ -42,8 +65,149 @@ func (r *repository) FindByID(id string) (*domain.BookReview, error) {
 	return bookReview, nil
 }
 
+func (r *repository) FindByUserID(userID string) ([]*domain.BookReview, error) {
+	var bookReviews []*domain.BookReview
+	for _, bookReview := range r.bookReviews {
+		if bookReview.UserID == userID {
+			bookReviews = append(bookReviews, bookReview)
+		}
+	}
+
+	return bookReviews, nil
+}
+
+func (r *repository) FindByBookAndUser(bookID, userID string) (*domain.BookReview, error) {
+	for _, bookReview := range r.bookReviews {
+		if bookReview.BookID == bookID && bookReview.UserID == userID {
+			return bookReview, nil
+		}
+	}
+	return nil, errors.New("book review not found")
+}
+
+func (r *repository) FindCoReviews(bookIDs []string, userID string, limit int64) ([]*domain.BookReview, error) {
+	books := map[string]bool{}
+	for _, bookID := range bookIDs {
+		books[bookID] = true
+	}
+	bookReviews := []*domain.BookReview{}
+	for _, bookReview := range r.bookReviews {
+		if books[bookReview.BookID] && bookReview.UserID != userID {
+			bookReviews = append(bookReviews, bookReview)
+		}
+	}
+	sort.Slice(bookReviews, func(i, j int) bool {
+		return bookReviews[i].CreatedOn.After(bookReviews[j].CreatedOn)
+	})
+	if limit > 0 && int64(len(bookReviews)) > limit {
+		bookReviews = bookReviews[:limit]
+	}
+	return bookReviews, nil
+}
+
+func (r *repository) FindByUserIDs(userIDs []string, minRating float64) ([]*domain.BookReview, error) {
+	users := map[string]bool{}
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+	bookReviews := []*domain.BookReview{}
+	for _, bookReview := range r.bookReviews {
+		if users[bookReview.UserID] && bookReview.Rating >= minRating {
+			bookReviews = append(bookReviews, bookReview)
+		}
+	}
+	return bookReviews, nil
+}
+
 func (r *repository) FindReviews(criteria *domain.BookReviewCriteria) ([]*domain.BookReviewShort, error) {
-	return nil, nil
+	var bookIDs map[string]bool
+	if criteria.BookIDs != nil {
+		bookIDs = map[string]bool{}
+		for _, bookID := range criteria.BookIDs {
+			bookIDs[bookID] = true
+		}
+	}
+
+	sums := map[string]float64{}
+	entries := map[string]*domain.BookReviewShort{}
+	for _, bookReview := range r.bookReviews {
+		if bookIDs != nil && !bookIDs[bookReview.BookID] {
+			continue
+		}
+		if criteria.From != nil && bookReview.CreatedOn.Before(*criteria.From) {
+			continue
+		}
+		entry, ok := entries[bookReview.BookID]
+		if !ok {
+			entry = &domain.BookReviewShort{ID: bookReview.BookID}
+			entries[bookReview.BookID] = entry
+		}
+		entry.Total++
+		sums[bookReview.BookID] += bookReview.Rating
+	}
+
+	bookReviews := []*domain.BookReviewShort{}
+	for bookID, entry := range entries {
+		if int64(entry.Total) < criteria.MinReviews {
+			continue
+		}
+		entry.Rating = sums[bookID] / float64(entry.Total)
+		entry.Score = domain.BayesianAverage(sums[bookID], entry.Total, criteria.PriorMean, criteria.PriorWeight)
+		bookReviews = append(bookReviews, entry)
+	}
+
+	sort.Slice(bookReviews, func(i, j int) bool {
+		a, b := bookReviews[i], bookReviews[j]
+		switch {
+		case a.Score != b.Score && criteria.Sort > 0:
+			return a.Score < b.Score
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		case a.Total != b.Total:
+			return a.Total > b.Total
+		}
+		return a.ID < b.ID
+	})
+
+	start := (criteria.Page - 1) * criteria.PerPage
+	if start >= int64(len(bookReviews)) {
+		return []*domain.BookReviewShort{}, nil
+	}
+	end := start + criteria.PerPage
+	if end > int64(len(bookReviews)) {
+		end = int64(len(bookReviews))
+	}
+	return bookReviews[start:end], nil
+}
+
+func (r *repository) RatingCounts(bookID string) ([]*domain.RatingCount, error) {
+	counts := map[float64]*domain.RatingCount{}
+	ratingCounts := []*domain.RatingCount{}
+	for _, bookReview := range r.bookReviews {
+		if bookReview.BookID != bookID {
+			continue
+		}
+		count, ok := counts[bookReview.Rating]
+		if !ok {
+			count = &domain.RatingCount{Rating: bookReview.Rating}
+			counts[bookReview.Rating] = count
+			ratingCounts = append(ratingCounts, count)
+		}
+		count.Count++
+	}
+	return ratingCounts, nil
+}
+
+func (r *repository) RatedBookIDs() ([]string, error) {
+	seen := map[string]bool{}
+	bookIDs := []string{}
+	for _, bookReview := range r.bookReviews {
+		if !seen[bookReview.BookID] {
+			seen[bookReview.BookID] = true
+			bookIDs = append(bookIDs, bookReview.BookID)
+		}
+	}
+	return bookIDs, nil
 }
 
 func (r *repository) Update(bookReview *domain.BookReview) error {
@@ -52,10 +216,57 @@ func (r *repository) Update(bookReview *domain.BookReview) error {
 }
 
 func (r *repository) Save(bookReview *domain.BookReview) error {
+	// Same rule as the unique (bookid, userid) index of the mongo repository
+	if existing, err := r.FindByBookAndUser(bookReview.BookID, bookReview.UserID); err == nil && existing.ID != bookReview.ID {
+		return errors.New("book already reviewed")
+	}
 	r.bookReviews[bookReview.ID] = bookReview
 	return nil
 }
 
+func (r *repository) AddVote(reviewID, userID string) error {
+	bookReview, err := r.FindByID(reviewID)
+	if err != nil {
+		return err
+	}
+	for _, voter := range bookReview.HelpfulVotes {
+		if voter == userID {
+			return errors.New("review already voted")
+		}
+	}
+	bookReview.HelpfulVotes = append(bookReview.HelpfulVotes, userID)
+	bookReview.Helpful = len(bookReview.HelpfulVotes)
+	return nil
+}
+
+func (r *repository) RemoveVote(reviewID, userID string) error {
+	bookReview, err := r.FindByID(reviewID)
+	if err != nil {
+		return err
+	}
+	for i, voter := range bookReview.HelpfulVotes {
+		if voter == userID {
+			bookReview.HelpfulVotes = append(bookReview.HelpfulVotes[:i], bookReview.HelpfulVotes[i+1:]...)
+			bookReview.Helpful = len(bookReview.HelpfulVotes)
+			return nil
+		}
+	}
+	return errors.New("vote not found")
+}
+
+func (r *repository) RemoveUserVotes(userID string) error {
+	for _, bookReview := range r.bookReviews {
+		for i, voter := range bookReview.HelpfulVotes {
+			if voter == userID {
+				bookReview.HelpfulVotes = append(bookReview.HelpfulVotes[:i], bookReview.HelpfulVotes[i+1:]...)
+				bookReview.Helpful = len(bookReview.HelpfulVotes)
+				break
+			}
+		}
+	}
+	return nil
+}
+
 func (r *repository) Delete(id string) error {
 	_, ok := r.bookReviews[id]
 	if ok {
@@ -18,15 +18,47 @@ type mongoRepository struct {
 
 // NewMongoBookReviewRepository ...
 func NewMongoBookReviewRepository(m *mongo.Database) domain.BookReviewRepository {
+	con := m.Collection("book_reviews")
+	_, err := con.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{primitive.E{Key: "bookid", Value: 1}, primitive.E{Key: "createdon", Value: -1}}},
+		{Keys: bson.D{primitive.E{Key: "bookid", Value: 1}, primitive.E{Key: "helpful", Value: -1}}},
+		{Keys: bson.D{primitive.E{Key: "userid", Value: 1}, primitive.E{Key: "createdon", Value: -1}}},
+		{
+			Keys:    bson.D{primitive.E{Key: "bookid", Value: 1}, primitive.E{Key: "userid", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
 	return &mongoRepository{
-		con: m.Collection("book_reviews"),
+		con: con,
 	}
 }
 
-func (r *mongoRepository) Find(bookID string) ([]*domain.BookReview, error) {
+// reviewSorts are the sort documents of each review list order, ties go to
+// the most recent review
+var reviewSorts = map[string]bson.D{
+	domain.SortRecent: {primitive.E{Key: "createdon", Value: -1}},
+	domain.SortHelpful: {
+		primitive.E{Key: "helpful", Value: -1},
+		primitive.E{Key: "createdon", Value: -1},
+	},
+	domain.SortRating: {
+		primitive.E{Key: "rating", Value: -1},
+		primitive.E{Key: "createdon", Value: -1},
+	},
+}
+
+func (r *mongoRepository) Find(criteria *domain.ReviewListCriteria) ([]*domain.BookReview, error) {
 	var bookReviews []*domain.BookReview
 
-	cur, err := r.con.Find(context.TODO(), bson.D{primitive.E{Key: "bookid", Value: bookID}}, nil)
+	findOptions := options.Find().SetSort(reviewSorts[criteria.Sort])
+	if criteria.PerPage > 0 {
+		findOptions.SetSkip((criteria.Page - 1) * criteria.PerPage).SetLimit(criteria.PerPage)
+	}
+
+	cur, err := r.con.Find(context.TODO(), reviewListFilter(criteria), findOptions)
 	if err != nil {
 		log.Println(err)
 		return bookReviews, err
@@ -40,6 +72,37 @@ func (r *mongoRepository) Find(bookID string) ([]*domain.BookReview, error) {
 	return bookReviews, nil
 }
 
+func reviewListFilter(criteria *domain.ReviewListCriteria) bson.M {
+	filter := bson.M{}
+	if criteria.BookID != "" {
+		filter["bookid"] = criteria.BookID
+	}
+	if criteria.UserID != "" {
+		filter["userid"] = criteria.UserID
+	}
+	rating := bson.M{}
+	if criteria.MinRating > 0 {
+		rating["$gte"] = criteria.MinRating
+	}
+	if criteria.MaxRating > 0 {
+		rating["$lte"] = criteria.MaxRating
+	}
+	if len(rating) > 0 {
+		filter["rating"] = rating
+	}
+	createdOn := bson.M{}
+	if criteria.From != nil {
+		createdOn["$gte"] = *criteria.From
+	}
+	if criteria.To != nil {
+		createdOn["$lte"] = *criteria.To
+	}
+	if len(createdOn) > 0 {
+		filter["createdon"] = createdOn
+	}
+	return filter
+}
+
 func (r *mongoRepository) FindByID(id string) (*domain.BookReview, error) {
 	var result *domain.BookReview
 	err := r.con.FindOne(
@@ -53,29 +116,72 @@ func (r *mongoRepository) FindByID(id string) (*domain.BookReview, error) {
 	return result, nil
 }
 
-func (r *mongoRepository) FindReviews(criteria *domain.BookReviewCriteria) ([]*domain.BookReviewShort, error) {
+func (r *mongoRepository) FindByUserID(userID string) ([]*domain.BookReview, error) {
+	var bookReviews []*domain.BookReview
 
-	var bookReviews []*domain.BookReviewShort
+	cur, err := r.con.Find(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}}, nil)
+	if err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	if err = cur.All(context.TODO(), &bookReviews); err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	return bookReviews, nil
+}
 
-	sortStage := bson.D{{"$sort", bson.D{{"rating", criteria.Sort}}}}
-	groupStage := bson.D{
-		{"$group",
-			bson.D{
-				{"_id", "$bookid"},
-				{"total",
-					bson.D{{"$sum", 1}}},
-				{"rating", bson.D{{"$avg", "$rating"}}},
-			},
-		}}
-	limit := bson.D{{"$limit", 25}}
-
-	cur, err := r.con.Aggregate(
+func (r *mongoRepository) FindByBookAndUser(bookID, userID string) (*domain.BookReview, error) {
+	var result *domain.BookReview
+	err := r.con.FindOne(
 		context.TODO(),
-		mongo.Pipeline{
-			sortStage,
-			groupStage,
-			limit,
-		})
+		bson.M{"bookid": bookID, "userid": userID},
+		options.FindOne()).Decode(&result)
+	if result == nil {
+		log.Println(err)
+		return nil, errors.New("book review not found")
+	}
+	return result, nil
+}
+
+// FindCoReviews returns the newest reviews of the books written by users
+// other than userID in a single query, served by the (bookid, userid) index,
+// at most limit of them
+func (r *mongoRepository) FindCoReviews(bookIDs []string, userID string, limit int64) ([]*domain.BookReview, error) {
+	var bookReviews []*domain.BookReview
+
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "createdon", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+	cur, err := r.con.Find(context.TODO(), bson.M{
+		"bookid": bson.M{"$in": bookIDs},
+		"userid": bson.M{"$ne": userID},
+	}, findOptions)
+	if err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	if err = cur.All(context.TODO(), &bookReviews); err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	return bookReviews, nil
+}
+
+// FindByUserIDs returns the reviews of all the users rated at least
+// minRating in a single query
+func (r *mongoRepository) FindByUserIDs(userIDs []string, minRating float64) ([]*domain.BookReview, error) {
+	var bookReviews []*domain.BookReview
+
+	cur, err := r.con.Find(context.TODO(), bson.M{
+		"userid": bson.M{"$in": userIDs},
+		"rating": bson.M{"$gte": minRating},
+	})
 	if err != nil {
 		log.Println(err)
 		return bookReviews, err
@@ -89,11 +195,103 @@ func (r *mongoRepository) FindReviews(criteria *domain.BookReviewCriteria) ([]*d
 	return bookReviews, nil
 }
 
+// FindReviews ranks the books in the database: reviews are grouped by book
+// and the bayesian average is computed in the pipeline, so only the
+// requested page leaves the server
+func (r *mongoRepository) FindReviews(criteria *domain.BookReviewCriteria) ([]*domain.BookReviewShort, error) {
+	var bookReviews []*domain.BookReviewShort
+
+	match := bson.M{}
+	if criteria.BookIDs != nil {
+		match["bookid"] = bson.M{"$in": criteria.BookIDs}
+	}
+	if criteria.From != nil {
+		match["createdon"] = bson.M{"$gte": *criteria.From}
+	}
+	priorSum := criteria.PriorMean * criteria.PriorWeight
+
+	cur, err := r.con.Aggregate(context.TODO(), mongo.Pipeline{
+		bson.D{primitive.E{Key: "$match", Value: match}},
+		bson.D{primitive.E{Key: "$group", Value: bson.M{
+			"_id":   "$bookid",
+			"total": bson.M{"$sum": 1},
+			"sum":   bson.M{"$sum": "$rating"},
+		}}},
+		bson.D{primitive.E{Key: "$match", Value: bson.M{"total": bson.M{"$gte": criteria.MinReviews}}}},
+		bson.D{primitive.E{Key: "$addFields", Value: bson.M{
+			"rating": bson.M{"$divide": bson.A{"$sum", "$total"}},
+			"score": bson.M{"$divide": bson.A{
+				bson.M{"$add": bson.A{priorSum, "$sum"}},
+				bson.M{"$add": bson.A{criteria.PriorWeight, "$total"}},
+			}},
+		}}},
+		bson.D{primitive.E{Key: "$sort", Value: bson.D{
+			primitive.E{Key: "score", Value: criteria.Sort},
+			primitive.E{Key: "total", Value: -1},
+			primitive.E{Key: "_id", Value: 1},
+		}}},
+		bson.D{primitive.E{Key: "$skip", Value: (criteria.Page - 1) * criteria.PerPage}},
+		bson.D{primitive.E{Key: "$limit", Value: criteria.PerPage}},
+	})
+	if err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	if err = cur.All(context.TODO(), &bookReviews); err != nil {
+		log.Println(err)
+		return bookReviews, err
+	}
+
+	return bookReviews, nil
+}
+
+// RatingCounts groups the reviews of the book by rating, the stats are built
+// from the groups so only one document per distinct rating is transferred
+func (r *mongoRepository) RatingCounts(bookID string) ([]*domain.RatingCount, error) {
+	var ratingCounts []*domain.RatingCount
+
+	cur, err := r.con.Aggregate(context.TODO(), mongo.Pipeline{
+		bson.D{primitive.E{Key: "$match", Value: bson.M{"bookid": bookID}}},
+		bson.D{primitive.E{Key: "$group", Value: bson.M{
+			"_id":   "$rating",
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		log.Println(err)
+		return ratingCounts, err
+	}
+
+	if err = cur.All(context.TODO(), &ratingCounts); err != nil {
+		log.Println(err)
+		return ratingCounts, err
+	}
+
+	return ratingCounts, nil
+}
+
+// RatedBookIDs returns the books with at least one review
+func (r *mongoRepository) RatedBookIDs() ([]string, error) {
+	values, err := r.con.Distinct(context.TODO(), "bookid", bson.M{})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	bookIDs := []string{}
+	for _, value := range values {
+		if bookID, ok := value.(string); ok {
+			bookIDs = append(bookIDs, bookID)
+		}
+	}
+	return bookIDs, nil
+}
+
 func (r *mongoRepository) Update(bookReview *domain.BookReview) error {
-	_, err := r.con.UpdateOne(context.TODO(), bson.M{"id": bookReview.ID}, bson.D{
-		{"$set", bson.D{
-			primitive.E{Key: "text", Value: bookReview.Text},
-		},
+	_, err := r.con.UpdateOne(context.TODO(), bson.M{"id": bookReview.ID}, bson.M{
+		"$set": bson.M{
+			"text":   bookReview.Text,
+			"rating": bookReview.Rating,
 		},
 	})
 	if err != nil {
@@ -105,6 +303,85 @@ func (r *mongoRepository) Update(bookReview *domain.BookReview) error {
 
 func (r *mongoRepository) Save(bookReview *domain.BookReview) error {
 	_, err := r.con.InsertOne(context.TODO(), bookReview)
+	if err != nil {
+		log.Println(err)
+		if isDuplicateKey(err) {
+			return errors.New("book already reviewed")
+		}
+		return err
+	}
+	return nil
+}
+
+// isDuplicateKey reports whether the write broke a unique index, which for
+// reviews means a second review of the same book by the same user
+func isDuplicateKey(err error) bool {
+	writeException, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+	for _, writeError := range writeException.WriteErrors {
+		if writeError.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
+
+// AddVote pushes the vote only when the user has not voted yet, so the
+// count can't drift with concurrent votes
+func (r *mongoRepository) AddVote(reviewID, userID string) error {
+	result, err := r.con.UpdateOne(
+		context.TODO(),
+		bson.M{"id": reviewID, "helpfulvotes": bson.M{"$ne": userID}},
+		bson.M{
+			"$push": bson.M{"helpfulvotes": userID},
+			"$inc":  bson.M{"helpful": 1},
+		})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err := r.FindByID(reviewID); err != nil {
+			return err
+		}
+		return errors.New("review already voted")
+	}
+	return nil
+}
+
+func (r *mongoRepository) RemoveVote(reviewID, userID string) error {
+	result, err := r.con.UpdateOne(
+		context.TODO(),
+		bson.M{"id": reviewID, "helpfulvotes": userID},
+		bson.M{
+			"$pull": bson.M{"helpfulvotes": userID},
+			"$inc":  bson.M{"helpful": -1},
+		})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err := r.FindByID(reviewID); err != nil {
+			return err
+		}
+		return errors.New("vote not found")
+	}
+	return nil
+}
+
+// RemoveUserVotes pulls the votes of the user from every review, a review
+// holds one vote per user at most so the count drops by one
+func (r *mongoRepository) RemoveUserVotes(userID string) error {
+	_, err := r.con.UpdateMany(
+		context.TODO(),
+		bson.M{"helpfulvotes": userID},
+		bson.M{
+			"$pull": bson.M{"helpfulvotes": userID},
+			"$inc":  bson.M{"helpful": -1},
+		})
 	if err != nil {
 		log.Println(err)
 		return err
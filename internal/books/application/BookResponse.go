@@ -2,32 +2,35 @@ package application
 
 import (
 	"something/internal/books/domain"
+	"something/internal/helpers"
 	"time"
 )
 
 // BookResponse ...
 type BookResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Author      string    `json:"author"`
-	Genre       string    `json:"genre"`
-	Pages       int       `json:"pages"`
-	Rating      float64   `json:"rating"`
-	CreatedOn   time.Time `json:"created_on"`
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Author       string    `json:"author"`
+	Genre        string    `json:"genre"`
+	Pages        int       `json:"pages"`
+	Rating       float64   `json:"rating"`
+	TotalReviews int       `json:"total_reviews"`
+	CreatedOn    time.Time `json:"created_on"`
 }
 
 // NewBookResponse ...
 func NewBookResponse(book *domain.Book) *BookResponse {
 	return &BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		Author:      book.Author,
-		Genre:       book.Genre,
-		Pages:       book.Pages,
-		Rating:      0,
-		CreatedOn:   book.CreatedOn,
+		ID:           book.ID,
+		Title:        book.Title,
+		Description:  book.Description,
+		Author:       book.Author,
+		Genre:        book.Genre,
+		Pages:        book.Pages,
+		Rating:       helpers.Round(book.Rating, 0.5),
+		TotalReviews: book.RatingCount,
+		CreatedOn:    book.CreatedOn,
 	}
 }
 
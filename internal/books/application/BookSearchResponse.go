@@ -0,0 +1,21 @@
+package application
+
+import "something/internal/books/domain"
+
+// BookSearchResponse is a book matching a search with its relevance and the
+// matched fragments of each field wrapped in <em></em>, the rest of the
+// fragment text is HTML escaped
+type BookSearchResponse struct {
+	*BookResponse
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// NewBookSearchResponse ...
+func NewBookSearchResponse(book *domain.Book, score float64, highlights map[string]string) *BookSearchResponse {
+	return &BookSearchResponse{
+		BookResponse: NewBookResponse(book),
+		Score:        score,
+		Highlights:   highlights,
+	}
+}
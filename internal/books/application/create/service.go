@@ -4,6 +4,7 @@ import (
 	"errors"
 	"something/internal/books/application"
 	"something/internal/books/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -13,11 +14,12 @@ type Service interface {
 
 type service struct {
 	repository domain.BookRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.BookRepository, bus eventbus.Bus) Service {
+	return &service{repository: repository, bus: bus}
 }
 
 func (s *service) CreateBook(command *application.BookCommand) error {
@@ -34,5 +36,6 @@ func (s *service) CreateBook(command *application.BookCommand) error {
 	if err != nil {
 		return err
 	}
+	s.bus.Publish(domain.NewBookCreated(book))
 	return nil
 }
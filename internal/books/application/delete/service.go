@@ -3,6 +3,7 @@ package delete
 import (
 	"errors"
 	"something/internal/books/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -12,18 +13,23 @@ type Service interface {
 
 type service struct {
 	repository domain.BookRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.BookRepository, bus eventbus.Bus) Service {
+	return &service{repository: repository, bus: bus}
 }
 
 func (s *service) DeleteBookByID(id string) error {
-	review, _ := s.repository.FindByID(id)
-	if review == nil {
+	book, _ := s.repository.FindByID(id)
+	if book == nil {
 		return errors.New("book not found")
 	}
 	err := s.repository.Delete(id)
-	return err
+	if err != nil {
+		return err
+	}
+	s.bus.Publish(domain.NewBookDeleted(book))
+	return nil
 }
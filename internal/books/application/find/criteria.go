@@ -7,4 +7,8 @@ type Criteria struct {
 	Query   string
 	Genre   string
 	Author  string
+	// MinRating filters out books with a lower mean rating
+	MinRating float64
+	// Sort by rating, 1 ascending and -1 descending
+	Sort int
 }
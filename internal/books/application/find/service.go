@@ -37,7 +37,7 @@ func (s *service) FindBooks(criteria *Criteria) ([]*application.BookResponse, er
 
 	newBookCriteria := domain.NewBookCriteria(
 		criteria.Page, criteria.PerPage, criteria.Query,
-		criteria.Genre, criteria.Author,
+		criteria.Genre, criteria.Author, criteria.MinRating, criteria.Sort,
 	)
 
 	books, err := s.repository.Find(newBookCriteria)
@@ -0,0 +1,11 @@
+package search
+
+// Criteria ...
+type Criteria struct {
+	Query     string
+	Page      int
+	PerPage   int
+	Genre     string
+	Author    string
+	MinRating float64
+}
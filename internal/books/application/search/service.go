@@ -0,0 +1,100 @@
+package search
+
+import (
+	"math"
+	"sort"
+
+	"something/internal/books/application"
+	"something/internal/books/domain"
+	"something/pkg/search"
+)
+
+// PAGE Default pagination page
+const PAGE int = 1
+
+// PERPAGE Default page size (the number of items to return per page).
+const PERPAGE int = 50
+
+// MAXRESULTS Maximum number of candidates ranked for a query, results past
+// it can't be paged
+const MAXRESULTS int = 500
+
+// Field weights, a match in the title counts the most
+const (
+	titleWeight       = 3.0
+	authorWeight      = 2.0
+	genreWeight       = 1.5
+	descriptionWeight = 1.0
+)
+
+// Service ...
+type Service interface {
+	SearchBooks(criteria *Criteria) ([]*application.BookSearchResponse, error)
+}
+
+type service struct {
+	repository domain.BookRepository
+}
+
+// NewService ...
+func NewService(repository domain.BookRepository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) SearchBooks(criteria *Criteria) ([]*application.BookSearchResponse, error) {
+	if criteria.Page <= 0 {
+		criteria.Page = PAGE
+	}
+	if criteria.PerPage <= 0 || criteria.PerPage > 1000 {
+		criteria.PerPage = PERPAGE
+	}
+
+	results := []*application.BookSearchResponse{}
+	query := search.NewQuery(criteria.Query)
+	if query.Empty() {
+		return results, nil
+	}
+
+	candidates, err := s.repository.Search(domain.NewBookSearchCriteria(
+		query.Terms, criteria.Genre, criteria.Author, criteria.MinRating, MAXRESULTS))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		book := &candidate.Book
+		match := query.Match(
+			search.Field{Name: "title", Text: book.Title, Weight: titleWeight},
+			search.Field{Name: "author", Text: book.Author, Weight: authorWeight},
+			search.Field{Name: "genre", Text: book.Genre, Weight: genreWeight},
+			search.Field{Name: "description", Text: book.Description, Weight: descriptionWeight, Snippet: true},
+		)
+		if match.Score == 0 {
+			// Matched by the repository alone, like the stemmed words of
+			// the mongo text index, its relevance is the repository one
+			if candidate.Score == 0 {
+				continue
+			}
+			match.Score = candidate.Score
+		}
+		results = append(results, application.NewBookSearchResponse(
+			book, math.Round(match.Score*100)/100, match.Highlights))
+	}
+	// Better rated books first among equally relevant ones
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].Rating > results[j].Rating
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	from := (criteria.Page - 1) * criteria.PerPage
+	if from >= len(results) {
+		return []*application.BookSearchResponse{}, nil
+	}
+	to := from + criteria.PerPage
+	if to > len(results) {
+		to = len(results)
+	}
+	return results[from:to], nil
+}
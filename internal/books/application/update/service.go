@@ -5,6 +5,7 @@ import (
 	"errors"
 	"something/internal/books/application"
 	"something/internal/books/domain"
+	"something/pkg/eventbus"
 	"strings"
 )
 
@@ -15,11 +16,12 @@ type Service interface {
 
 type service struct {
 	repository domain.BookRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.BookRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.BookRepository, bus eventbus.Bus) Service {
+	return &service{repository: repository, bus: bus}
 }
 
 func (s *service) UpdateBookByID(book *application.BookCommand) error {
@@ -45,7 +47,14 @@ func (s *service) UpdateBookByID(book *application.BookCommand) error {
 		return err
 	}
 	updatedBook.CreatedOn = existingBook.CreatedOn
+	updatedBook.RatingSum = existingBook.RatingSum
+	updatedBook.RatingCount = existingBook.RatingCount
+	updatedBook.Rating = existingBook.Rating
 
 	err = s.repository.Update(updatedBook)
-	return err
+	if err != nil {
+		return err
+	}
+	s.bus.Publish(domain.NewBookUpdated(updatedBook))
+	return nil
 }
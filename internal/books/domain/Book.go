@@ -10,6 +10,9 @@ type Book struct {
 	Author      string
 	Genre       string
 	Pages       int
+	RatingSum   float64
+	RatingCount int
+	Rating      float64
 	CreatedOn   time.Time
 }
 
@@ -25,3 +28,24 @@ func NewBook(id, title, description, author, genre string, pages int) (*Book, er
 		CreatedOn:   time.Now().UTC(),
 	}, nil
 }
+
+// AddRating updates the rating aggregates with the sum and number
+// of reviews added (negative values when reviews are removed)
+func (b *Book) AddRating(sum float64, count int) {
+	b.SetRating(b.RatingSum+sum, b.RatingCount+count)
+}
+
+// SetRating replaces the rating aggregates with the sum and number of
+// reviews of the book
+func (b *Book) SetRating(sum float64, count int) {
+	b.RatingSum = sum
+	b.RatingCount = count
+	if b.RatingCount <= 0 {
+		b.RatingSum = 0
+		b.RatingCount = 0
+	}
+	b.Rating = 0
+	if b.RatingCount > 0 {
+		b.Rating = b.RatingSum / float64(b.RatingCount)
+	}
+}
@@ -2,20 +2,31 @@ package domain
 
 // BookCriteria ...
 type BookCriteria struct {
-	Page    int64
-	PerPage int64
-	Query   string
-	Genre   string
-	Author  string
+	Page      int64
+	PerPage   int64
+	Query     string
+	Genre     string
+	Author    string
+	MinRating float64
+	Sort      int
 }
 
+// Sort by rating, books without sort are returned in storage order
+const (
+	SortNone       = 0
+	SortRatingAsc  = 1
+	SortRatingDesc = -1
+)
+
 // NewBookCriteria ...
-func NewBookCriteria(page, perPage int, query, genre, author string) *BookCriteria {
+func NewBookCriteria(page, perPage int, query, genre, author string, minRating float64, sort int) *BookCriteria {
 	return &BookCriteria{
-		Page:    int64(page),
-		PerPage: int64(perPage),
-		Query:   query,
-		Genre:   genre,
-		Author:  author,
+		Page:      int64(page),
+		PerPage:   int64(perPage),
+		Query:     query,
+		Genre:     genre,
+		Author:    author,
+		MinRating: minRating,
+		Sort:      sort,
 	}
 }
@@ -0,0 +1,61 @@
+package domain
+
+import "something/pkg/eventbus"
+
+// Book event names
+const (
+	BookCreatedEvent = "book.created"
+	BookUpdatedEvent = "book.updated"
+	BookDeletedEvent = "book.deleted"
+)
+
+// BookCreated ...
+type BookCreated struct {
+	eventbus.BaseEvent
+	BookID string
+	Title  string
+	Author string
+	Genre  string
+}
+
+// NewBookCreated ...
+func NewBookCreated(book *Book) *BookCreated {
+	return &BookCreated{
+		BaseEvent: eventbus.NewBaseEvent(),
+		BookID:    book.ID,
+		Title:     book.Title,
+		Author:    book.Author,
+		Genre:     book.Genre,
+	}
+}
+
+// Name ...
+func (e *BookCreated) Name() string { return BookCreatedEvent }
+
+// BookUpdated ...
+type BookUpdated struct {
+	eventbus.BaseEvent
+	BookID string
+}
+
+// NewBookUpdated ...
+func NewBookUpdated(book *Book) *BookUpdated {
+	return &BookUpdated{BaseEvent: eventbus.NewBaseEvent(), BookID: book.ID}
+}
+
+// Name ...
+func (e *BookUpdated) Name() string { return BookUpdatedEvent }
+
+// BookDeleted ...
+type BookDeleted struct {
+	eventbus.BaseEvent
+	BookID string
+}
+
+// NewBookDeleted ...
+func NewBookDeleted(book *Book) *BookDeleted {
+	return &BookDeleted{BaseEvent: eventbus.NewBaseEvent(), BookID: book.ID}
+}
+
+// Name ...
+func (e *BookDeleted) Name() string { return BookDeletedEvent }
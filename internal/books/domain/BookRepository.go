@@ -4,7 +4,10 @@ package domain
 type BookRepository interface {
 	Find(*BookCriteria) ([]*Book, error)
 	FindByID(string) (*Book, error)
+	FindByIDs([]string) ([]*Book, error)
+	Search(*BookSearchCriteria) ([]*BookMatch, error)
 	Update(*Book) error
+	SetRating(id string, sum float64, count int) error
 	Save(*Book) error
 	Delete(string) error
 }
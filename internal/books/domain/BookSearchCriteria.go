@@ -0,0 +1,29 @@
+package domain
+
+// BookSearchCriteria selects the candidate books of a text search, they are
+// ranked by the search service
+type BookSearchCriteria struct {
+	Terms     []string
+	Genre     string
+	Author    string
+	MinRating float64
+	Limit     int64
+}
+
+// BookMatch is a candidate of a text search with the relevance given by the
+// repository, 0 when it can't tell
+type BookMatch struct {
+	Book  `bson:",inline"`
+	Score float64
+}
+
+// NewBookSearchCriteria ...
+func NewBookSearchCriteria(terms []string, genre, author string, minRating float64, limit int) *BookSearchCriteria {
+	return &BookSearchCriteria{
+		Terms:     terms,
+		Genre:     genre,
+		Author:    author,
+		MinRating: minRating,
+		Limit:     int64(limit),
+	}
+}
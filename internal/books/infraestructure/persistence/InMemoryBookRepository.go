@@ -3,10 +3,15 @@ package persistence
 import (
 	"errors"
 	"something/internal/books/domain"
+	"sort"
+	"strings"
+
+	"something/pkg/search"
 )
 
 type repository struct {
 	books map[string]*domain.Book
+	index *search.Index
 }
 
 var (
@@ -17,6 +22,7 @@ var (
 func NewInMemoryBookRepository() domain.BookRepository {
 	bookInstance = &repository{
 		books: make(map[string]*domain.Book),
+		index: search.NewIndex(),
 	}
 	return bookInstance
 }
@@ -24,8 +30,22 @@ func NewInMemoryBookRepository() domain.BookRepository {
 func (r *repository) Find(criteria *domain.BookCriteria) ([]*domain.Book, error) {
 	var books []*domain.Book
 	for _, book := range r.books {
+		if book.Rating < criteria.MinRating {
+			continue
+		}
+		if !containsFold(book.Genre, criteria.Genre) || !containsFold(book.Author, criteria.Author) {
+			continue
+		}
 		books = append(books, book)
 	}
+	if criteria.Sort != domain.SortNone {
+		sort.SliceStable(books, func(i, j int) bool {
+			if criteria.Sort == domain.SortRatingAsc {
+				return books[i].Rating < books[j].Rating
+			}
+			return books[i].Rating > books[j].Rating
+		})
+	}
 	return books, nil
 }
 
@@ -37,13 +57,59 @@ func (r *repository) FindByID(id string) (*domain.Book, error) {
 	return book, nil
 }
 
+func (r *repository) FindByIDs(ids []string) ([]*domain.Book, error) {
+	books := []*domain.Book{}
+	for _, id := range ids {
+		if book, ok := r.books[id]; ok {
+			books = append(books, book)
+		}
+	}
+	return books, nil
+}
+
+func (r *repository) Search(criteria *domain.BookSearchCriteria) ([]*domain.BookMatch, error) {
+	var matches []*domain.BookMatch
+	for id, score := range r.index.Search(&search.Query{Terms: criteria.Terms}) {
+		book := r.books[id]
+		if book.Rating < criteria.MinRating {
+			continue
+		}
+		if !containsFold(book.Genre, criteria.Genre) || !containsFold(book.Author, criteria.Author) {
+			continue
+		}
+		matches = append(matches, &domain.BookMatch{Book: *book, Score: score})
+	}
+	// The most relevant candidates are kept, like the text score of mongo
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score == matches[j].Score {
+			return matches[i].ID < matches[j].ID
+		}
+		return matches[i].Score > matches[j].Score
+	})
+	if criteria.Limit > 0 && int64(len(matches)) > criteria.Limit {
+		matches = matches[:criteria.Limit]
+	}
+	return matches, nil
+}
+
 func (r *repository) Update(book *domain.Book) error {
 	r.books[book.ID] = book
+	r.index.Add(book.ID, book.Title, book.Author, book.Description, book.Genre)
+	return nil
+}
+
+func (r *repository) SetRating(id string, sum float64, count int) error {
+	book, ok := r.books[id]
+	if !ok {
+		return errors.New("book not found")
+	}
+	book.SetRating(sum, count)
 	return nil
 }
 
 func (r *repository) Save(book *domain.Book) error {
 	r.books[book.ID] = book
+	r.index.Add(book.ID, book.Title, book.Author, book.Description, book.Genre)
 	return nil
 }
 
@@ -51,6 +117,11 @@ func (r *repository) Delete(id string) error {
 	_, ok := r.books[id]
 	if ok {
 		delete(r.books, id)
+		r.index.Remove(id)
 	}
 	return nil
 }
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
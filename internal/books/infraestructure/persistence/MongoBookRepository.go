@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"log"
+	"regexp"
 	"something/internal/books/domain"
+	"something/pkg/search"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,15 +21,37 @@ type mongoRepository struct {
 
 // NewMongoBookRepository ...
 func NewMongoBookRepository(m *mongo.Database) domain.BookRepository {
-	return &mongoRepository{
-		con: m.Collection("books"),
+	con := m.Collection("books")
+	_, err := con.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{
+			primitive.E{Key: "title", Value: "text"},
+			primitive.E{Key: "author", Value: "text"},
+			primitive.E{Key: "genre", Value: "text"},
+			primitive.E{Key: "description", Value: "text"},
+		},
+		Options: options.Index().SetName("books_text").SetWeights(bson.M{
+			"title":       10,
+			"author":      5,
+			"genre":       3,
+			"description": 1,
+		}),
+	})
+	if err != nil {
+		log.Println(err)
 	}
+	return &mongoRepository{con: con}
 }
 
 func (r *mongoRepository) Find(criteria *domain.BookCriteria) ([]*domain.Book, error) {
 	findOptions := options.Find()
 	findOptions.SetSkip((criteria.Page - 1) * criteria.PerPage)
 	findOptions.SetLimit(criteria.PerPage)
+	if criteria.Sort != domain.SortNone {
+		findOptions.SetSort(bson.D{
+			primitive.E{Key: "rating", Value: criteria.Sort},
+			primitive.E{Key: "ratingcount", Value: -1},
+		})
+	}
 
 	var books []*domain.Book
 
@@ -47,20 +72,24 @@ func (r *mongoRepository) Find(criteria *domain.BookCriteria) ([]*domain.Book, e
 func generateQueryWithCriteria(criteria *domain.BookCriteria) bson.D {
 	query := bson.D{}
 	if criteria.Query != "" {
-		regex := primitive.Regex{Pattern: criteria.Query, Options: "i"}
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(criteria.Query), Options: "i"}
 		condition := primitive.E{Key: "title", Value: regex}
 		query = append(query, condition)
 	}
 	if criteria.Author != "" {
-		regex := primitive.Regex{Pattern: criteria.Author, Options: "i"}
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(criteria.Author), Options: "i"}
 		condition := primitive.E{Key: "author", Value: regex}
 		query = append(query, condition)
 	}
 	if criteria.Genre != "" {
-		regex := primitive.Regex{Pattern: criteria.Genre, Options: "i"}
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(criteria.Genre), Options: "i"}
 		condition := primitive.E{Key: "genre", Value: regex}
 		query = append(query, condition)
 	}
+	if criteria.MinRating > 0 {
+		condition := primitive.E{Key: "rating", Value: bson.M{"$gte": criteria.MinRating}}
+		query = append(query, condition)
+	}
 	return query
 }
 
@@ -81,6 +110,92 @@ func (r *mongoRepository) FindByID(id string) (*domain.Book, error) {
 	return result, nil
 }
 
+// FindByIDs returns the books of the IDs found, in no particular order
+func (r *mongoRepository) FindByIDs(ids []string) ([]*domain.Book, error) {
+	if len(ids) == 0 {
+		return []*domain.Book{}, nil
+	}
+	return r.findBooks(bson.D{primitive.E{Key: "id", Value: bson.M{"$in": ids}}}, options.Find())
+}
+
+// Search looks up the candidates in the text index, which handles stemming
+// but not typos, scored by their text score. The rest of the limit is filled
+// with the books having words one typo away from the terms, which can't use
+// the index and have no score.
+func (r *mongoRepository) Search(criteria *domain.BookSearchCriteria) ([]*domain.BookMatch, error) {
+	filters := generateQueryWithCriteria(&domain.BookCriteria{
+		Genre:     criteria.Genre,
+		Author:    criteria.Author,
+		MinRating: criteria.MinRating,
+	})
+
+	textQuery := append(bson.D{primitive.E{Key: "$text", Value: bson.M{
+		"$search": strings.Join(criteria.Terms, " "),
+	}}}, filters...)
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetLimit(criteria.Limit)
+	matches, err := r.findMatches(textQuery, findOptions)
+	if err != nil || (criteria.Limit > 0 && int64(len(matches)) >= criteria.Limit) {
+		return matches, err
+	}
+
+	found := make([]string, 0, len(matches))
+	for _, match := range matches {
+		found = append(found, match.ID)
+	}
+
+	var fuzzyConditions []interface{}
+	for _, term := range criteria.Terms {
+		regex := primitive.Regex{Pattern: search.FuzzyPattern(term), Options: "i"}
+		for _, field := range []string{"title", "author", "genre", "description"} {
+			fuzzyConditions = append(fuzzyConditions, bson.D{primitive.E{Key: field, Value: regex}})
+		}
+	}
+	fuzzyQuery := append(bson.D{
+		primitive.E{Key: "$or", Value: fuzzyConditions},
+		primitive.E{Key: "id", Value: bson.M{"$nin": found}},
+	}, filters...)
+	fuzzyOptions := options.Find()
+	if criteria.Limit > 0 {
+		fuzzyOptions.SetLimit(criteria.Limit - int64(len(matches)))
+	}
+	fuzzyMatches, err := r.findMatches(fuzzyQuery, fuzzyOptions)
+	if err != nil {
+		return nil, err
+	}
+	return append(matches, fuzzyMatches...), nil
+}
+
+func (r *mongoRepository) findMatches(query bson.D, findOptions *options.FindOptions) ([]*domain.BookMatch, error) {
+	var matches []*domain.BookMatch
+	cur, err := r.con.Find(context.TODO(), query, findOptions)
+	if err != nil {
+		log.Println(err)
+		return matches, err
+	}
+	if err = cur.All(context.TODO(), &matches); err != nil {
+		log.Println(err)
+		return matches, err
+	}
+	return matches, nil
+}
+
+func (r *mongoRepository) findBooks(query bson.D, findOptions *options.FindOptions) ([]*domain.Book, error) {
+	var books []*domain.Book
+	cur, err := r.con.Find(context.TODO(), query, findOptions)
+	if err != nil {
+		log.Println(err)
+		return books, err
+	}
+	if err = cur.All(context.TODO(), &books); err != nil {
+		log.Println(err)
+		return books, err
+	}
+	return books, nil
+}
+
 func (r *mongoRepository) Update(book *domain.Book) error {
 	_, err := r.con.UpdateOne(context.TODO(), bson.M{"id": book.ID}, bson.D{
 		{"$set", bson.D{
@@ -99,6 +214,26 @@ func (r *mongoRepository) Update(book *domain.Book) error {
 	return nil
 }
 
+func (r *mongoRepository) SetRating(id string, sum float64, count int) error {
+	book := &domain.Book{}
+	book.SetRating(sum, count)
+	result, err := r.con.UpdateOne(context.TODO(), bson.M{"id": id}, bson.M{
+		"$set": bson.M{
+			"ratingsum":   book.RatingSum,
+			"ratingcount": book.RatingCount,
+			"rating":      book.Rating,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("book not found")
+	}
+	return nil
+}
+
 func (r *mongoRepository) Save(book *domain.Book) error {
 	_, err := r.con.InsertOne(context.TODO(), book)
 	if err != nil {
@@ -0,0 +1,38 @@
+package application
+
+// StatsResponse ...
+type StatsResponse struct {
+	UserID           string             `json:"user_id"`
+	Year             int                `json:"year"`
+	BooksFinished    int                `json:"books_finished"`
+	PagesFinished    int                `json:"pages_finished"`
+	Months           []*MonthResponse   `json:"months"`
+	FavouriteGenres  []*CountResponse   `json:"favourite_genres"`
+	FavouriteAuthors []*CountResponse   `json:"favourite_authors"`
+	ReviewsWritten   int                `json:"reviews_written"`
+	AverageRating    float64            `json:"average_rating"`
+	Challenge        *ChallengeResponse `json:"challenge"`
+}
+
+// MonthResponse ...
+type MonthResponse struct {
+	Month int `json:"month"`
+	Books int `json:"books"`
+	Pages int `json:"pages"`
+}
+
+// CountResponse ...
+type CountResponse struct {
+	Name  string `json:"name"`
+	Books int    `json:"books"`
+}
+
+// ChallengeResponse ...
+type ChallengeResponse struct {
+	Year       int     `json:"year"`
+	Goal       int     `json:"goal"`
+	Completed  int     `json:"completed"`
+	Percentage float64 `json:"percentage"`
+	Expected   int     `json:"expected"`
+	Status     string  `json:"status"`
+}
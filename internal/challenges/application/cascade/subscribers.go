@@ -0,0 +1,14 @@
+package cascade
+
+import (
+	"something/internal/challenges/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe removes the challenges of deleted users
+func Subscribe(bus eventbus.Bus, repository domain.ChallengeRepository) {
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUserID(event.(*userDomain.UserDeleted).UserID)
+	})
+}
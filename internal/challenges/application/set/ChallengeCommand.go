@@ -0,0 +1,22 @@
+package set
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ChallengeCommand ...
+type ChallengeCommand struct {
+	UserID string `json:"user_id"`
+	Year   int    `json:"year"`
+	Goal   int    `json:"goal"`
+}
+
+// Validate ...
+func (c ChallengeCommand) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Year, validation.Required, validation.Min(1900), validation.Max(time.Now().UTC().Year()+1)),
+		validation.Field(&c.Goal, validation.Required, validation.Min(1), validation.Max(1000)),
+	)
+}
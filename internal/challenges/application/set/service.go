@@ -0,0 +1,35 @@
+package set
+
+import (
+	"something/internal/challenges/domain"
+)
+
+// Service ...
+type Service interface {
+	SetChallenge(*ChallengeCommand) error
+	DeleteChallenge(userID string, year int) error
+}
+
+type service struct {
+	repository domain.ChallengeRepository
+}
+
+// NewService ...
+func NewService(repository domain.ChallengeRepository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) SetChallenge(command *ChallengeCommand) error {
+	challenge, err := domain.NewChallenge(command.UserID, command.Year, command.Goal)
+	if err != nil {
+		return err
+	}
+	if existing, _ := s.repository.Find(command.UserID, command.Year); existing != nil {
+		challenge.CreatedOn = existing.CreatedOn
+	}
+	return s.repository.Save(challenge)
+}
+
+func (s *service) DeleteChallenge(userID string, year int) error {
+	return s.repository.Delete(userID, year)
+}
@@ -0,0 +1,252 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/internal/challenges/application"
+	"something/internal/challenges/domain"
+	readingLogDomain "something/internal/readinglog/domain"
+	shelfDomain "something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// FAVOURITES is the number of genres and authors listed as favourites
+const FAVOURITES = 5
+
+// Criteria ...
+type Criteria struct {
+	UserID   string
+	ViewerID string
+	Year     int
+}
+
+// Service ...
+type Service interface {
+	UserStats(*Criteria) (*application.StatsResponse, error)
+}
+
+type service struct {
+	repository           domain.ChallengeRepository
+	readingLogRepository readingLogDomain.ReadingLogRepository
+	userRepository       userDomain.UserRepository
+	bookRepository       bookDomain.BookRepository
+	bookReviewRepository bookReviewDomain.BookReviewRepository
+	shelfRepository      shelfDomain.ShelfRepository
+}
+
+// NewService ...
+func NewService(
+	repository domain.ChallengeRepository,
+	readingLogRepository readingLogDomain.ReadingLogRepository,
+	userRepository userDomain.UserRepository,
+	bookRepository bookDomain.BookRepository,
+	bookReviewRepository bookReviewDomain.BookReviewRepository,
+	shelfRepository shelfDomain.ShelfRepository,
+) Service {
+	return &service{
+		repository:           repository,
+		readingLogRepository: readingLogRepository,
+		userRepository:       userRepository,
+		bookRepository:       bookRepository,
+		bookReviewRepository: bookReviewRepository,
+		shelfRepository:      shelfRepository,
+	}
+}
+
+// UserStats computes the year statistics of the user. Books finished come
+// from the interests marked as done, dated by the finish of each read in
+// their reading log, so re-reads count once per read. Books marked as done
+// without a finished read, before reading logs existed, count once dated by
+// the review of the book or else the last update of the log. Like the
+// shelves, the stats of other users are not found when their done shelf is
+// private.
+func (s *service) UserStats(criteria *Criteria) (*application.StatsResponse, error) {
+	user, err := s.userRepository.FindByID(criteria.UserID)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := s.visible(user.ID, criteria.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, errors.New("user not found")
+	}
+	year := criteria.Year
+	if year == 0 {
+		year = time.Now().UTC().Year()
+	}
+
+	stats := &application.StatsResponse{
+		UserID:           user.ID,
+		Year:             year,
+		Months:           make([]*application.MonthResponse, 12),
+		FavouriteGenres:  []*application.CountResponse{},
+		FavouriteAuthors: []*application.CountResponse{},
+	}
+	for i := range stats.Months {
+		stats.Months[i] = &application.MonthResponse{Month: i + 1}
+	}
+
+	readingLogs, err := s.readingLogRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	logs := map[string]*readingLogDomain.ReadingLog{}
+	for _, readingLog := range readingLogs {
+		logs[readingLog.BookID] = readingLog
+	}
+
+	reviews, err := s.bookReviewRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	reviewed := map[string]time.Time{}
+	for _, review := range reviews {
+		reviewed[review.BookID] = review.CreatedOn
+	}
+
+	genres := map[string]int{}
+	authors := map[string]int{}
+	for bookID, status := range user.Interests {
+		readingLog, ok := logs[bookID]
+		if status != readingLogDomain.StatusDone && !ok {
+			continue
+		}
+		finished := finishedIn(readingLog, year)
+		if status == readingLogDomain.StatusDone && (readingLog == nil || readingLog.TimesRead() == 0) {
+			finished = undatedIn(readingLog, reviewed[bookID], year)
+		}
+		if len(finished) == 0 {
+			continue
+		}
+		book, err := s.bookRepository.FindByID(bookID)
+		if err != nil {
+			continue
+		}
+		for _, date := range finished {
+			month := stats.Months[date.Month()-1]
+			month.Books++
+			month.Pages += book.Pages
+			stats.BooksFinished++
+			stats.PagesFinished += book.Pages
+		}
+		if book.Genre != "" {
+			genres[book.Genre]++
+		}
+		if book.Author != "" {
+			authors[book.Author]++
+		}
+	}
+	stats.FavouriteGenres = favourites(genres)
+	stats.FavouriteAuthors = favourites(authors)
+
+	ratingSum := 0.0
+	for _, review := range reviews {
+		if review.CreatedOn.Year() != year {
+			continue
+		}
+		stats.ReviewsWritten++
+		ratingSum += review.Rating
+	}
+	if stats.ReviewsWritten > 0 {
+		stats.AverageRating = math.Round(ratingSum/float64(stats.ReviewsWritten)*100) / 100
+	}
+
+	if challenge, _ := s.repository.Find(user.ID, year); challenge != nil {
+		stats.Challenge = challengeResponse(challenge, stats.BooksFinished)
+	}
+	return stats, nil
+}
+
+func finishedIn(readingLog *readingLogDomain.ReadingLog, year int) []time.Time {
+	finished := []time.Time{}
+	if readingLog == nil {
+		return finished
+	}
+	for _, read := range readingLog.Reads {
+		if read.FinishedOn != nil && read.FinishedOn.Year() == year {
+			finished = append(finished, *read.FinishedOn)
+		}
+	}
+	return finished
+}
+
+// undatedIn dates a book marked as done without a finished read by its
+// review, or by the last update of its reading log
+func undatedIn(readingLog *readingLogDomain.ReadingLog, reviewedOn time.Time, year int) []time.Time {
+	date := reviewedOn
+	if date.IsZero() && readingLog != nil {
+		date = readingLog.UpdatedOn
+	}
+	if date.IsZero() || date.Year() != year {
+		return []time.Time{}
+	}
+	return []time.Time{date}
+}
+
+// visible applies the shelf privacy to the stats, they list the finished
+// books so other users only see them when the done shelf is public
+func (s *service) visible(userID, viewerID string) (bool, error) {
+	if userID == viewerID {
+		return true, nil
+	}
+	shelves, err := s.shelfRepository.FindByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, shelf := range shelves {
+		if shelf.Status == shelfDomain.StatusDone {
+			return shelf.Public, nil
+		}
+	}
+	// Default shelves are public until the user changes them
+	return true, nil
+}
+
+func favourites(counts map[string]int) []*application.CountResponse {
+	result := []*application.CountResponse{}
+	for name, books := range counts {
+		result = append(result, &application.CountResponse{Name: name, Books: books})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Books != result[j].Books {
+			return result[i].Books > result[j].Books
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > FAVOURITES {
+		result = result[:FAVOURITES]
+	}
+	return result
+}
+
+func challengeResponse(challenge *domain.Challenge, completed int) *application.ChallengeResponse {
+	expected := challenge.Expected(time.Now().UTC())
+	status := "behind"
+	switch {
+	case completed >= challenge.Goal:
+		status = "completed"
+	case completed > expected:
+		status = "ahead"
+	case completed == expected:
+		status = "on_track"
+	}
+	percentage := float64(completed) / float64(challenge.Goal) * 100
+	if percentage > 100 {
+		percentage = 100
+	}
+	return &application.ChallengeResponse{
+		Year:       challenge.Year,
+		Goal:       challenge.Goal,
+		Completed:  completed,
+		Percentage: math.Round(percentage*10) / 10,
+		Expected:   expected,
+		Status:     status,
+	}
+}
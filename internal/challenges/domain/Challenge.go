@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Challenge is the yearly goal of books a user wants to finish
+type Challenge struct {
+	UserID    string
+	Year      int
+	Goal      int
+	CreatedOn time.Time
+	UpdatedOn time.Time
+}
+
+// NewChallenge ...
+func NewChallenge(userID string, year, goal int) (*Challenge, error) {
+	if goal < 1 {
+		return nil, errors.New("goal must be at least one book")
+	}
+	now := time.Now().UTC()
+	return &Challenge{
+		UserID:    userID,
+		Year:      year,
+		Goal:      goal,
+		CreatedOn: now,
+		UpdatedOn: now,
+	}, nil
+}
+
+// Expected is the number of books that should be finished by date to keep
+// pace with the goal, the whole goal once the year is over
+func (c *Challenge) Expected(date time.Time) int {
+	start := time.Date(c.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	if date.Before(start) {
+		return 0
+	}
+	if !date.Before(end) {
+		return c.Goal
+	}
+	elapsed := date.Sub(start).Hours() / end.Sub(start).Hours()
+	return int(float64(c.Goal) * elapsed)
+}
@@ -0,0 +1,10 @@
+package domain
+
+// ChallengeRepository ...
+type ChallengeRepository interface {
+	Find(userID string, year int) (*Challenge, error)
+	FindByUserID(string) ([]*Challenge, error)
+	Save(*Challenge) error
+	Delete(userID string, year int) error
+	DeleteByUserID(string) error
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"something/internal/challenges/domain"
+)
+
+type repository struct {
+	challenges map[string]*domain.Challenge
+}
+
+var (
+	challengeInstance *repository
+)
+
+// NewInMemoryChallengeRepository ...
+func NewInMemoryChallengeRepository() domain.ChallengeRepository {
+	challengeInstance = &repository{
+		challenges: make(map[string]*domain.Challenge),
+	}
+	return challengeInstance
+}
+
+func key(userID string, year int) string {
+	return userID + "/" + strconv.Itoa(year)
+}
+
+func (r *repository) Find(userID string, year int) (*domain.Challenge, error) {
+	challenge, ok := r.challenges[key(userID, year)]
+	if !ok {
+		return nil, errors.New("challenge not found")
+	}
+	return challenge, nil
+}
+
+func (r *repository) FindByUserID(userID string) ([]*domain.Challenge, error) {
+	challenges := []*domain.Challenge{}
+	for _, challenge := range r.challenges {
+		if challenge.UserID == userID {
+			challenges = append(challenges, challenge)
+		}
+	}
+	sort.Slice(challenges, func(i, j int) bool {
+		return challenges[i].Year > challenges[j].Year
+	})
+	return challenges, nil
+}
+
+func (r *repository) Save(challenge *domain.Challenge) error {
+	r.challenges[key(challenge.UserID, challenge.Year)] = challenge
+	return nil
+}
+
+func (r *repository) Delete(userID string, year int) error {
+	if _, ok := r.challenges[key(userID, year)]; !ok {
+		return errors.New("challenge not found")
+	}
+	delete(r.challenges, key(userID, year))
+	return nil
+}
+
+func (r *repository) DeleteByUserID(userID string) error {
+	for id, challenge := range r.challenges {
+		if challenge.UserID == userID {
+			delete(r.challenges, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"something/internal/challenges/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepository struct {
+	con *mongo.Collection
+}
+
+// NewMongoChallengeRepository ...
+func NewMongoChallengeRepository(m *mongo.Database) domain.ChallengeRepository {
+	con := m.Collection("challenges")
+	_, err := con.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{
+			primitive.E{Key: "userid", Value: 1},
+			primitive.E{Key: "year", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoRepository{con: con}
+}
+
+func (r *mongoRepository) Find(userID string, year int) (*domain.Challenge, error) {
+	var result *domain.Challenge
+	err := r.con.FindOne(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "userid", Value: userID},
+			primitive.E{Key: "year", Value: year},
+		},
+		options.FindOne()).Decode(&result)
+	if result == nil {
+		log.Println(err)
+		return nil, errors.New("challenge not found")
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) FindByUserID(userID string) ([]*domain.Challenge, error) {
+	challenges := []*domain.Challenge{}
+	cur, err := r.con.Find(
+		context.TODO(),
+		bson.D{primitive.E{Key: "userid", Value: userID}},
+		options.Find().SetSort(bson.D{primitive.E{Key: "year", Value: -1}}))
+	if err != nil {
+		log.Println(err)
+		return challenges, err
+	}
+	if err = cur.All(context.TODO(), &challenges); err != nil {
+		log.Println(err)
+		return challenges, err
+	}
+	return challenges, nil
+}
+
+func (r *mongoRepository) Save(challenge *domain.Challenge) error {
+	_, err := r.con.ReplaceOne(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "userid", Value: challenge.UserID},
+			primitive.E{Key: "year", Value: challenge.Year},
+		},
+		challenge,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) Delete(userID string, year int) error {
+	result, err := r.con.DeleteOne(context.TODO(), bson.D{
+		primitive.E{Key: "userid", Value: userID},
+		primitive.E{Key: "year", Value: year},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("challenge not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByUserID(userID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"time"
+
+	"something/internal/comments/domain"
+)
+
+// CommentResponse ...
+type CommentResponse struct {
+	ID        string             `json:"id"`
+	ReviewID  string             `json:"review_id"`
+	ParentID  string             `json:"parent_id,omitempty"`
+	User      User               `json:"user"`
+	Text      string             `json:"text"`
+	Hidden    bool               `json:"hidden"`
+	CreatedOn time.Time          `json:"created_on"`
+	EditedOn  *time.Time         `json:"edited_on"`
+	Replies   []*CommentResponse `json:"replies,omitempty"`
+}
+
+// User ...
+type User struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// NewCommentResponse hides the text of moderated comments
+func NewCommentResponse(comment *domain.Comment) *CommentResponse {
+	response := &CommentResponse{
+		ID:        comment.ID,
+		ReviewID:  comment.ReviewID,
+		ParentID:  comment.ParentID,
+		User:      User{ID: comment.UserID},
+		Text:      comment.Text,
+		Hidden:    comment.Hidden,
+		CreatedOn: comment.CreatedOn,
+		EditedOn:  comment.EditedOn,
+	}
+	if comment.Hidden {
+		response.Text = ""
+	}
+	if !comment.IsReply() {
+		response.Replies = []*CommentResponse{}
+	}
+	return response
+}
@@ -0,0 +1,22 @@
+package cascade
+
+import (
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/internal/comments/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe removes the comments of deleted reviews, books and users
+func Subscribe(bus eventbus.Bus, repository domain.CommentRepository) {
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByBookID(event.(*bookDomain.BookDeleted).BookID)
+	})
+	bus.Subscribe(bookReviewDomain.ReviewDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByReviewID(event.(*bookReviewDomain.ReviewDeleted).ReviewID)
+	})
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUserID(event.(*userDomain.UserDeleted).UserID)
+	})
+}
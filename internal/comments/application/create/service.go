@@ -0,0 +1,54 @@
+package create
+
+import (
+	"errors"
+
+	bookReviewDomain "something/internal/bookreviews/domain"
+	"something/internal/comments/domain"
+)
+
+// Service ...
+type Service interface {
+	CreateComment(*CommentCommand) error
+}
+
+type service struct {
+	repository           domain.CommentRepository
+	bookReviewRepository bookReviewDomain.BookReviewRepository
+}
+
+// NewService ...
+func NewService(
+	repository domain.CommentRepository,
+	bookReviewRepository bookReviewDomain.BookReviewRepository,
+) Service {
+	return &service{repository: repository, bookReviewRepository: bookReviewRepository}
+}
+
+// CreateComment adds the comment to the review, replies are only allowed to
+// top level comments of the same review
+func (s *service) CreateComment(command *CommentCommand) error {
+	review, err := s.bookReviewRepository.FindByID(command.ReviewID)
+	if err != nil {
+		return err
+	}
+	if existing, _ := s.repository.FindByID(command.ID); existing != nil {
+		return errors.New("comment already exists")
+	}
+	if command.ParentID != "" {
+		parent, _ := s.repository.FindByID(command.ParentID)
+		if parent == nil || parent.ReviewID != command.ReviewID {
+			return errors.New("parent comment not found")
+		}
+		if parent.IsReply() {
+			return errors.New("replies can't be nested")
+		}
+	}
+
+	comment, err := domain.NewComment(
+		command.ID, review.BookID, command.ReviewID, command.ParentID, command.UserID, command.Text)
+	if err != nil {
+		return err
+	}
+	return s.repository.Save(comment)
+}
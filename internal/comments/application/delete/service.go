@@ -0,0 +1,34 @@
+package delete
+
+import (
+	"errors"
+
+	"something/internal/comments/domain"
+)
+
+// Service ...
+type Service interface {
+	DeleteComment(id, userID string, moderator bool) error
+}
+
+type service struct {
+	repository domain.CommentRepository
+}
+
+// NewService ...
+func NewService(repository domain.CommentRepository) Service {
+	return &service{repository: repository}
+}
+
+// DeleteComment removes the comment with its replies, authors delete their
+// own comments and moderators any of them
+func (s *service) DeleteComment(id, userID string, moderator bool) error {
+	comment, err := s.repository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID && !moderator {
+		return errors.New("forbidden")
+	}
+	return s.repository.Delete(id)
+}
@@ -0,0 +1,76 @@
+package find
+
+import (
+	"something/internal/comments/application"
+	"something/internal/comments/domain"
+)
+
+// PAGE Default pagination page
+const PAGE int = 1
+
+// PERPAGE Default number of top level comments per page
+const PERPAGE int = 20
+
+// Criteria ...
+type Criteria struct {
+	ReviewID string
+	Page     int
+	PerPage  int
+}
+
+// Service ...
+type Service interface {
+	FindReviewComments(*Criteria) ([]*application.CommentResponse, error)
+	CountComments(reviewIDs []string) (map[string]int, error)
+}
+
+type service struct {
+	repository domain.CommentRepository
+}
+
+// NewService ...
+func NewService(repository domain.CommentRepository) Service {
+	return &service{repository: repository}
+}
+
+// FindReviewComments returns a page of top level comments, oldest first,
+// each one with all its replies
+func (s *service) FindReviewComments(criteria *Criteria) ([]*application.CommentResponse, error) {
+	if criteria.Page <= 0 {
+		criteria.Page = PAGE
+	}
+	if criteria.PerPage <= 0 || criteria.PerPage > 100 {
+		criteria.PerPage = PERPAGE
+	}
+
+	comments, err := s.repository.Find(
+		domain.NewCommentCriteria(criteria.ReviewID, criteria.Page, criteria.PerPage))
+	if err != nil {
+		return nil, err
+	}
+
+	response := []*application.CommentResponse{}
+	threads := map[string]*application.CommentResponse{}
+	parentIDs := []string{}
+	for _, comment := range comments {
+		thread := application.NewCommentResponse(comment)
+		threads[comment.ID] = thread
+		parentIDs = append(parentIDs, comment.ID)
+		response = append(response, thread)
+	}
+
+	replies, err := s.repository.FindReplies(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		if thread, ok := threads[reply.ParentID]; ok {
+			thread.Replies = append(thread.Replies, application.NewCommentResponse(reply))
+		}
+	}
+	return response, nil
+}
+
+func (s *service) CountComments(reviewIDs []string) (map[string]int, error) {
+	return s.repository.CountByReviewIDs(reviewIDs)
+}
@@ -0,0 +1,17 @@
+package update
+
+import validation "github.com/go-ozzo/ozzo-validation"
+
+// CommentCommand ...
+type CommentCommand struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Text   string `json:"text"`
+}
+
+// Validate ...
+func (c CommentCommand) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Text, validation.Required, validation.Length(1, 1000)),
+	)
+}
@@ -0,0 +1,48 @@
+package update
+
+import (
+	"errors"
+
+	"something/internal/comments/domain"
+)
+
+// Service ...
+type Service interface {
+	UpdateComment(*CommentCommand) error
+	HideComment(id string, hidden bool) error
+}
+
+type service struct {
+	repository domain.CommentRepository
+}
+
+// NewService ...
+func NewService(repository domain.CommentRepository) Service {
+	return &service{repository: repository}
+}
+
+// UpdateComment edits the text, only the author can edit a comment
+func (s *service) UpdateComment(command *CommentCommand) error {
+	comment, err := s.repository.FindByID(command.ID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != command.UserID {
+		return errors.New("forbidden")
+	}
+	if err := comment.Edit(command.Text); err != nil {
+		return err
+	}
+	return s.repository.Update(comment)
+}
+
+// HideComment is the staff moderation, hidden comments keep their place in
+// the thread without showing the text
+func (s *service) HideComment(id string, hidden bool) error {
+	comment, err := s.repository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	comment.Hidden = hidden
+	return s.repository.Update(comment)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Comment is a reply to a book review or, one level deep, to a comment
+type Comment struct {
+	ID        string
+	BookID    string
+	ReviewID  string
+	ParentID  string
+	UserID    string
+	Text      string
+	Hidden    bool
+	CreatedOn time.Time
+	EditedOn  *time.Time
+}
+
+// NewComment ...
+func NewComment(id, bookID, reviewID, parentID, userID, text string) (*Comment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("comment text is required")
+	}
+	return &Comment{
+		ID:        id,
+		BookID:    bookID,
+		ReviewID:  reviewID,
+		ParentID:  parentID,
+		UserID:    userID,
+		Text:      text,
+		CreatedOn: time.Now().UTC(),
+	}, nil
+}
+
+// IsReply ...
+func (c *Comment) IsReply() bool {
+	return c.ParentID != ""
+}
+
+// Edit changes the text of the comment
+func (c *Comment) Edit(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("comment text is required")
+	}
+	editedOn := time.Now().UTC()
+	c.Text = text
+	c.EditedOn = &editedOn
+	return nil
+}
@@ -0,0 +1,17 @@
+package domain
+
+// CommentCriteria selects a page of the top level comments of a review
+type CommentCriteria struct {
+	ReviewID string
+	Page     int64
+	PerPage  int64
+}
+
+// NewCommentCriteria ...
+func NewCommentCriteria(reviewID string, page, perPage int) *CommentCriteria {
+	return &CommentCriteria{
+		ReviewID: reviewID,
+		Page:     int64(page),
+		PerPage:  int64(perPage),
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+// CommentRepository ...
+type CommentRepository interface {
+	Find(*CommentCriteria) ([]*Comment, error)
+	FindByID(string) (*Comment, error)
+	FindReplies(parentIDs []string) ([]*Comment, error)
+	CountByReviewIDs([]string) (map[string]int, error)
+	Save(*Comment) error
+	Update(*Comment) error
+	Delete(string) error
+	DeleteByReviewID(string) error
+	DeleteByBookID(string) error
+	DeleteByUserID(string) error
+}
@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"errors"
+	"sort"
+
+	"something/internal/comments/domain"
+)
+
+type repository struct {
+	comments map[string]*domain.Comment
+}
+
+var (
+	commentInstance *repository
+)
+
+// NewInMemoryCommentRepository ...
+func NewInMemoryCommentRepository() domain.CommentRepository {
+	commentInstance = &repository{
+		comments: make(map[string]*domain.Comment),
+	}
+	return commentInstance
+}
+
+func (r *repository) Find(criteria *domain.CommentCriteria) ([]*domain.Comment, error) {
+	comments := r.filter(func(comment *domain.Comment) bool {
+		return comment.ReviewID == criteria.ReviewID && !comment.IsReply()
+	})
+	start := (criteria.Page - 1) * criteria.PerPage
+	if start >= int64(len(comments)) {
+		return []*domain.Comment{}, nil
+	}
+	end := start + criteria.PerPage
+	if end > int64(len(comments)) {
+		end = int64(len(comments))
+	}
+	return comments[start:end], nil
+}
+
+func (r *repository) FindByID(id string) (*domain.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, errors.New("comment not found")
+	}
+	return comment, nil
+}
+
+func (r *repository) FindReplies(parentIDs []string) ([]*domain.Comment, error) {
+	parents := map[string]bool{}
+	for _, id := range parentIDs {
+		parents[id] = true
+	}
+	return r.filter(func(comment *domain.Comment) bool {
+		return parents[comment.ParentID]
+	}), nil
+}
+
+func (r *repository) CountByReviewIDs(reviewIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, id := range reviewIDs {
+		counts[id] = 0
+	}
+	for _, comment := range r.comments {
+		if _, ok := counts[comment.ReviewID]; ok {
+			counts[comment.ReviewID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *repository) Save(comment *domain.Comment) error {
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *repository) Update(comment *domain.Comment) error {
+	if _, ok := r.comments[comment.ID]; !ok {
+		return errors.New("comment not found")
+	}
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *repository) Delete(id string) error {
+	if _, ok := r.comments[id]; !ok {
+		return errors.New("comment not found")
+	}
+	r.deleteWhere(func(comment *domain.Comment) bool {
+		return comment.ID == id || comment.ParentID == id
+	})
+	return nil
+}
+
+func (r *repository) DeleteByReviewID(reviewID string) error {
+	r.deleteWhere(func(comment *domain.Comment) bool {
+		return comment.ReviewID == reviewID
+	})
+	return nil
+}
+
+func (r *repository) DeleteByBookID(bookID string) error {
+	r.deleteWhere(func(comment *domain.Comment) bool {
+		return comment.BookID == bookID
+	})
+	return nil
+}
+
+func (r *repository) DeleteByUserID(userID string) error {
+	owned := map[string]bool{}
+	for id, comment := range r.comments {
+		if comment.UserID == userID {
+			owned[id] = true
+		}
+	}
+	r.deleteWhere(func(comment *domain.Comment) bool {
+		return owned[comment.ID] || owned[comment.ParentID]
+	})
+	return nil
+}
+
+// filter returns the matching comments oldest first
+func (r *repository) filter(match func(*domain.Comment) bool) []*domain.Comment {
+	comments := []*domain.Comment{}
+	for _, comment := range r.comments {
+		if match(comment) {
+			comments = append(comments, comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].CreatedOn.Equal(comments[j].CreatedOn) {
+			return comments[i].ID < comments[j].ID
+		}
+		return comments[i].CreatedOn.Before(comments[j].CreatedOn)
+	})
+	return comments
+}
+
+func (r *repository) deleteWhere(match func(*domain.Comment) bool) {
+	for id, comment := range r.comments {
+		if match(comment) {
+			delete(r.comments, id)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"something/internal/comments/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepository struct {
+	con *mongo.Collection
+}
+
+var oldestFirst = bson.D{
+	primitive.E{Key: "createdon", Value: 1},
+	primitive.E{Key: "id", Value: 1},
+}
+
+// NewMongoCommentRepository ...
+func NewMongoCommentRepository(m *mongo.Database) domain.CommentRepository {
+	con := m.Collection("comments")
+	_, err := con.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{primitive.E{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			primitive.E{Key: "reviewid", Value: 1},
+			primitive.E{Key: "parentid", Value: 1},
+			primitive.E{Key: "createdon", Value: 1},
+		}},
+		{Keys: bson.D{primitive.E{Key: "parentid", Value: 1}}},
+		{Keys: bson.D{primitive.E{Key: "bookid", Value: 1}}},
+		{Keys: bson.D{primitive.E{Key: "userid", Value: 1}}},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoRepository{con: con}
+}
+
+func (r *mongoRepository) Find(criteria *domain.CommentCriteria) ([]*domain.Comment, error) {
+	findOptions := options.Find().
+		SetSort(oldestFirst).
+		SetSkip((criteria.Page - 1) * criteria.PerPage).
+		SetLimit(criteria.PerPage)
+	return r.find(bson.D{
+		primitive.E{Key: "reviewid", Value: criteria.ReviewID},
+		primitive.E{Key: "parentid", Value: ""},
+	}, findOptions)
+}
+
+func (r *mongoRepository) FindByID(id string) (*domain.Comment, error) {
+	var result *domain.Comment
+	err := r.con.FindOne(
+		context.TODO(),
+		bson.D{primitive.E{Key: "id", Value: id}},
+		options.FindOne()).Decode(&result)
+	if result == nil {
+		log.Println(err)
+		return nil, errors.New("comment not found")
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) FindReplies(parentIDs []string) ([]*domain.Comment, error) {
+	if len(parentIDs) == 0 {
+		return []*domain.Comment{}, nil
+	}
+	return r.find(
+		bson.M{"parentid": bson.M{"$in": parentIDs}},
+		options.Find().SetSort(oldestFirst))
+}
+
+func (r *mongoRepository) CountByReviewIDs(reviewIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, id := range reviewIDs {
+		counts[id] = 0
+	}
+	if len(reviewIDs) == 0 {
+		return counts, nil
+	}
+	cur, err := r.con.Aggregate(context.TODO(), mongo.Pipeline{
+		bson.D{primitive.E{Key: "$match", Value: bson.M{"reviewid": bson.M{"$in": reviewIDs}}}},
+		bson.D{primitive.E{Key: "$group", Value: bson.M{"_id": "$reviewid", "total": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		log.Println(err)
+		return counts, err
+	}
+	var results []struct {
+		ID    string `bson:"_id"`
+		Total int    `bson:"total"`
+	}
+	if err = cur.All(context.TODO(), &results); err != nil {
+		log.Println(err)
+		return counts, err
+	}
+	for _, result := range results {
+		counts[result.ID] = result.Total
+	}
+	return counts, nil
+}
+
+func (r *mongoRepository) Save(comment *domain.Comment) error {
+	_, err := r.con.InsertOne(context.TODO(), comment)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) Update(comment *domain.Comment) error {
+	result, err := r.con.ReplaceOne(
+		context.TODO(),
+		bson.D{primitive.E{Key: "id", Value: comment.ID}},
+		comment)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("comment not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) Delete(id string) error {
+	result, err := r.con.DeleteMany(context.TODO(), bson.M{
+		"$or": bson.A{bson.M{"id": id}, bson.M{"parentid": id}},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("comment not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByReviewID(reviewID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "reviewid", Value: reviewID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByBookID(bookID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "bookid", Value: bookID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+// DeleteByUserID removes the comments of the user with the replies they got
+func (r *mongoRepository) DeleteByUserID(userID string) error {
+	ids, err := r.con.Distinct(context.TODO(), "id", bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	_, err = r.con.DeleteMany(context.TODO(), bson.M{
+		"$or": bson.A{bson.M{"userid": userID}, bson.M{"parentid": bson.M{"$in": ids}}},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) find(filter interface{}, findOptions *options.FindOptions) ([]*domain.Comment, error) {
+	comments := []*domain.Comment{}
+	cur, err := r.con.Find(context.TODO(), filter, findOptions)
+	if err != nil {
+		log.Println(err)
+		return comments, err
+	}
+	if err = cur.All(context.TODO(), &comments); err != nil {
+		log.Println(err)
+		return comments, err
+	}
+	return comments, nil
+}
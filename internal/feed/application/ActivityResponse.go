@@ -0,0 +1,55 @@
+package application
+
+import (
+	"time"
+
+	"something/internal/feed/domain"
+)
+
+// ActivityResponse ...
+type ActivityResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	User      User      `json:"user"`
+	BookID    string    `json:"book_id"`
+	ReviewID  string    `json:"review_id,omitempty"`
+	Rating    float64   `json:"rating,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// User ...
+type User struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// FeedResponse is a page of the feed, NextCursor is empty on the last page
+type FeedResponse struct {
+	Data       []*ActivityResponse `json:"data"`
+	NextCursor string              `json:"next_cursor"`
+}
+
+// NewActivityResponse ...
+func NewActivityResponse(activity *domain.Activity) *ActivityResponse {
+	return &ActivityResponse{
+		ID:        activity.ID,
+		Type:      activity.Type,
+		User:      User{ID: activity.UserID},
+		BookID:    activity.BookID,
+		ReviewID:  activity.ReviewID,
+		Rating:    activity.Rating,
+		Status:    activity.Status,
+		CreatedOn: activity.CreatedOn,
+	}
+}
+
+// NewActivitiesResponse ...
+func NewActivitiesResponse(activities []*domain.Activity) []*ActivityResponse {
+	activitiesResponse := []*ActivityResponse{}
+	for _, activity := range activities {
+		activitiesResponse = append(activitiesResponse, NewActivityResponse(activity))
+	}
+	return activitiesResponse
+}
@@ -0,0 +1,9 @@
+package find
+
+// Criteria ...
+type Criteria struct {
+	UserID string
+	// Cursor is the next_cursor of the previous page, empty for the first one
+	Cursor string
+	Limit  int
+}
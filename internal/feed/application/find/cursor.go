@@ -0,0 +1,35 @@
+package find
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"something/internal/feed/domain"
+)
+
+const cursorSeparator = "|"
+
+// encodeCursor returns an opaque cursor pointing after the given activity
+func encodeCursor(activity *domain.Activity) string {
+	raw := strconv.FormatInt(activity.CreatedOn.UnixNano(), 10) + cursorSeparator + activity.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), cursorSeparator, 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	return time.Unix(0, nanos).UTC(), parts[1], nil
+}
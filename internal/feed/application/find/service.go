@@ -0,0 +1,73 @@
+package find
+
+import (
+	"time"
+
+	"something/internal/feed/application"
+	"something/internal/feed/domain"
+	userFollowDomain "something/internal/userfollow/domain"
+)
+
+// LIMIT Default number of activities per page
+const LIMIT int = 20
+
+// MAXLIMIT Maximum number of activities per page
+const MAXLIMIT int = 100
+
+// Service ...
+type Service interface {
+	Feed(criteria *Criteria) (*application.FeedResponse, error)
+}
+
+type service struct {
+	repository           domain.ActivityRepository
+	userFollowRepository userFollowDomain.UserFollowRepository
+}
+
+// NewService ...
+func NewService(repository domain.ActivityRepository, userFollowRepository userFollowDomain.UserFollowRepository) Service {
+	return &service{repository: repository, userFollowRepository: userFollowRepository}
+}
+
+func (s *service) Feed(criteria *Criteria) (*application.FeedResponse, error) {
+	if criteria.Limit <= 0 || criteria.Limit > MAXLIMIT {
+		criteria.Limit = LIMIT
+	}
+
+	var beforeCreatedOn time.Time
+	var beforeID string
+	if criteria.Cursor != "" {
+		var err error
+		beforeCreatedOn, beforeID, err = decodeCursor(criteria.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	following, err := s.userFollowRepository.FindFollowing(criteria.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(following) == 0 {
+		return &application.FeedResponse{Data: []*application.ActivityResponse{}}, nil
+	}
+	userIDs := make([]string, 0, len(following))
+	for _, follow := range following {
+		userIDs = append(userIDs, follow.To)
+	}
+
+	// Ask for one more activity to know if there is a next page
+	activities, err := s.repository.Find(
+		domain.NewActivityCriteria(userIDs, beforeCreatedOn, beforeID, criteria.Limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &application.FeedResponse{}
+	if len(activities) > criteria.Limit {
+		activities = activities[:criteria.Limit]
+		response.NextCursor = encodeCursor(activities[len(activities)-1])
+	}
+	response.Data = application.NewActivitiesResponse(activities)
+	return response, nil
+}
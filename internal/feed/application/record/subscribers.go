@@ -0,0 +1,37 @@
+package record
+
+import (
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/internal/feed/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe records the activities shown in the feed. Saves and removals
+// both run synchronously, an activity saved in background could land after
+// the removal of its review or user and stay in the feed forever.
+func Subscribe(bus eventbus.Bus, repository domain.ActivityRepository) {
+	bus.Subscribe(bookReviewDomain.ReviewCreatedEvent, func(event eventbus.Event) error {
+		e := event.(*bookReviewDomain.ReviewCreated)
+		return repository.Save(
+			domain.NewReviewActivity(e.UserID, e.BookID, e.ReviewID, e.Rating, e.OccurredOn()))
+	})
+	bus.Subscribe(userDomain.InterestChangedEvent, func(event eventbus.Event) error {
+		e := event.(*userDomain.InterestChanged)
+		if !domain.IsFeedStatus(e.Status) {
+			return nil
+		}
+		return repository.Save(
+			domain.NewStatusActivity(e.UserID, e.BookID, e.Status, e.OccurredOn()))
+	})
+	bus.Subscribe(bookReviewDomain.ReviewDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByReviewID(event.(*bookReviewDomain.ReviewDeleted).ReviewID)
+	})
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUserID(event.(*userDomain.UserDeleted).UserID)
+	})
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByBookID(event.(*bookDomain.BookDeleted).BookID)
+	})
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/twinj/uuid"
+)
+
+// Activity types
+const (
+	ReviewPosted  = "review_posted"
+	StatusChanged = "status_changed"
+)
+
+// FeedStatuses are the reading statuses shared in the feed, adding a book
+// to the pending list isn't news for the followers
+var FeedStatuses = []string{"reading", "done"}
+
+// IsFeedStatus reports whether a change to the status is shown in the feed
+func IsFeedStatus(status string) bool {
+	for _, feedStatus := range FeedStatuses {
+		if feedStatus == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Activity is an entry of the feed shown to the followers of UserID
+type Activity struct {
+	ID        string
+	Type      string
+	UserID    string
+	BookID    string
+	ReviewID  string
+	Rating    float64
+	Status    string
+	CreatedOn time.Time
+}
+
+// NewReviewActivity ...
+func NewReviewActivity(userID, bookID, reviewID string, rating float64, createdOn time.Time) *Activity {
+	return &Activity{
+		ID:        uuid.NewV4().String(),
+		Type:      ReviewPosted,
+		UserID:    userID,
+		BookID:    bookID,
+		ReviewID:  reviewID,
+		Rating:    rating,
+		CreatedOn: createdOn,
+	}
+}
+
+// NewStatusActivity ...
+func NewStatusActivity(userID, bookID, status string, createdOn time.Time) *Activity {
+	return &Activity{
+		ID:        uuid.NewV4().String(),
+		Type:      StatusChanged,
+		UserID:    userID,
+		BookID:    bookID,
+		Status:    status,
+		CreatedOn: createdOn,
+	}
+}
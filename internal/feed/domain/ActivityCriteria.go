@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// ActivityCriteria selects the activities of UserIDs newest first. When
+// BeforeID is set only activities older than the (BeforeCreatedOn, BeforeID)
+// cursor are returned.
+type ActivityCriteria struct {
+	UserIDs         []string
+	BeforeCreatedOn time.Time
+	BeforeID        string
+	Limit           int64
+}
+
+// NewActivityCriteria ...
+func NewActivityCriteria(userIDs []string, beforeCreatedOn time.Time, beforeID string, limit int) *ActivityCriteria {
+	return &ActivityCriteria{
+		UserIDs:         userIDs,
+		BeforeCreatedOn: beforeCreatedOn,
+		BeforeID:        beforeID,
+		Limit:           int64(limit),
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+// ActivityRepository ...
+type ActivityRepository interface {
+	Find(*ActivityCriteria) ([]*Activity, error)
+	Save(*Activity) error
+	DeleteByReviewID(string) error
+	DeleteByUserID(string) error
+	DeleteByBookID(string) error
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"something/internal/feed/domain"
+)
+
+// Activities are saved by asynchronous subscribers, so unlike the other
+// in-memory repositories this one is guarded by a mutex
+type repository struct {
+	mu         sync.RWMutex
+	activities map[string]*domain.Activity
+}
+
+var (
+	activityInstance *repository
+)
+
+// NewInMemoryActivityRepository ...
+func NewInMemoryActivityRepository() domain.ActivityRepository {
+	activityInstance = &repository{
+		activities: make(map[string]*domain.Activity),
+	}
+	return activityInstance
+}
+
+func (r *repository) Find(criteria *domain.ActivityCriteria) ([]*domain.Activity, error) {
+	users := make(map[string]bool, len(criteria.UserIDs))
+	for _, userID := range criteria.UserIDs {
+		users[userID] = true
+	}
+
+	r.mu.RLock()
+	activities := []*domain.Activity{}
+	for _, activity := range r.activities {
+		if !users[activity.UserID] {
+			continue
+		}
+		if criteria.BeforeID != "" && !before(activity, criteria) {
+			continue
+		}
+		activities = append(activities, activity)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(activities, func(i, j int) bool {
+		if activities[i].CreatedOn.Equal(activities[j].CreatedOn) {
+			return activities[i].ID > activities[j].ID
+		}
+		return activities[i].CreatedOn.After(activities[j].CreatedOn)
+	})
+	if criteria.Limit > 0 && int64(len(activities)) > criteria.Limit {
+		activities = activities[:criteria.Limit]
+	}
+	return activities, nil
+}
+
+func before(activity *domain.Activity, criteria *domain.ActivityCriteria) bool {
+	if activity.CreatedOn.Equal(criteria.BeforeCreatedOn) {
+		return activity.ID < criteria.BeforeID
+	}
+	return activity.CreatedOn.Before(criteria.BeforeCreatedOn)
+}
+
+func (r *repository) Save(activity *domain.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activities[activity.ID] = activity
+	return nil
+}
+
+func (r *repository) DeleteByReviewID(reviewID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, activity := range r.activities {
+		if activity.ReviewID == reviewID {
+			delete(r.activities, id)
+		}
+	}
+	return nil
+}
+
+func (r *repository) DeleteByUserID(userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, activity := range r.activities {
+		if activity.UserID == userID {
+			delete(r.activities, id)
+		}
+	}
+	return nil
+}
+
+func (r *repository) DeleteByBookID(bookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, activity := range r.activities {
+		if activity.BookID == bookID {
+			delete(r.activities, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"log"
+
+	"something/internal/feed/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepository struct {
+	con *mongo.Collection
+}
+
+// NewMongoActivityRepository ...
+func NewMongoActivityRepository(m *mongo.Database) domain.ActivityRepository {
+	con := m.Collection("feed_activities")
+	_, err := con.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{
+			primitive.E{Key: "userid", Value: 1},
+			primitive.E{Key: "createdon", Value: -1},
+			primitive.E{Key: "id", Value: -1},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoRepository{con: con}
+}
+
+func (r *mongoRepository) Find(criteria *domain.ActivityCriteria) ([]*domain.Activity, error) {
+	activities := []*domain.Activity{}
+
+	query := bson.M{"userid": bson.M{"$in": criteria.UserIDs}}
+	if criteria.BeforeID != "" {
+		query["$or"] = []interface{}{
+			bson.M{"createdon": bson.M{"$lt": criteria.BeforeCreatedOn}},
+			bson.M{"createdon": criteria.BeforeCreatedOn, "id": bson.M{"$lt": criteria.BeforeID}},
+		}
+	}
+	findOptions := options.Find().SetSort(bson.D{
+		primitive.E{Key: "createdon", Value: -1},
+		primitive.E{Key: "id", Value: -1},
+	})
+	if criteria.Limit > 0 {
+		findOptions.SetLimit(criteria.Limit)
+	}
+
+	cur, err := r.con.Find(context.TODO(), query, findOptions)
+	if err != nil {
+		log.Println(err)
+		return activities, err
+	}
+	if err = cur.All(context.TODO(), &activities); err != nil {
+		log.Println(err)
+		return activities, err
+	}
+	return activities, nil
+}
+
+func (r *mongoRepository) Save(activity *domain.Activity) error {
+	_, err := r.con.InsertOne(context.TODO(), activity)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByReviewID(reviewID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "reviewid", Value: reviewID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByUserID(userID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByBookID(bookID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "bookid", Value: bookID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"time"
+
+	"something/internal/readinglog/domain"
+)
+
+// ReadingLogResponse ...
+type ReadingLogResponse struct {
+	BookID      string          `json:"book_id"`
+	Status      string          `json:"status"`
+	Pages       int             `json:"pages"`
+	CurrentPage int             `json:"current_page"`
+	Percentage  float64         `json:"percentage"`
+	TimesRead   int             `json:"times_read"`
+	Reads       []*ReadResponse `json:"reads"`
+	UpdatedOn   time.Time       `json:"updated_on"`
+}
+
+// ReadResponse ...
+type ReadResponse struct {
+	StartedOn   time.Time          `json:"started_on"`
+	FinishedOn  *time.Time         `json:"finished_on"`
+	AbandonedOn *time.Time         `json:"abandoned_on"`
+	CurrentPage int                `json:"current_page"`
+	Sessions    []*SessionResponse `json:"sessions"`
+}
+
+// SessionResponse ...
+type SessionResponse struct {
+	Date      time.Time `json:"date"`
+	FromPage  int       `json:"from_page"`
+	ToPage    int       `json:"to_page"`
+	PagesRead int       `json:"pages_read"`
+	Minutes   int       `json:"minutes"`
+}
+
+// NewReadingLogResponse ...
+func NewReadingLogResponse(readingLog *domain.ReadingLog) *ReadingLogResponse {
+	response := &ReadingLogResponse{
+		BookID:     readingLog.BookID,
+		Status:     readingLog.Status(),
+		Pages:      readingLog.Pages,
+		Percentage: readingLog.Percentage(),
+		TimesRead:  readingLog.TimesRead(),
+		Reads:      []*ReadResponse{},
+		UpdatedOn:  readingLog.UpdatedOn,
+	}
+	if read := readingLog.LastRead(); read != nil {
+		response.CurrentPage = read.CurrentPage
+	}
+	for _, read := range readingLog.Reads {
+		readResponse := &ReadResponse{
+			StartedOn:   read.StartedOn,
+			FinishedOn:  read.FinishedOn,
+			AbandonedOn: read.AbandonedOn,
+			CurrentPage: read.CurrentPage,
+			Sessions:    []*SessionResponse{},
+		}
+		for _, session := range read.Sessions {
+			readResponse.Sessions = append(readResponse.Sessions, &SessionResponse{
+				Date:      session.Date,
+				FromPage:  session.FromPage,
+				ToPage:    session.ToPage,
+				PagesRead: session.ToPage - session.FromPage,
+				Minutes:   session.Minutes,
+			})
+		}
+		response.Reads = append(response.Reads, readResponse)
+	}
+	return response
+}
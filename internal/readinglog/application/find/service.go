@@ -0,0 +1,28 @@
+package find
+
+import (
+	"something/internal/readinglog/application"
+	"something/internal/readinglog/domain"
+)
+
+// Service ...
+type Service interface {
+	FindReadingLog(userID, bookID string) (*application.ReadingLogResponse, error)
+}
+
+type service struct {
+	repository domain.ReadingLogRepository
+}
+
+// NewService ...
+func NewService(repository domain.ReadingLogRepository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) FindReadingLog(userID, bookID string) (*application.ReadingLogResponse, error) {
+	readingLog, err := s.repository.Find(userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewReadingLogResponse(readingLog), nil
+}
@@ -0,0 +1,56 @@
+package status
+
+import (
+	bookDomain "something/internal/books/domain"
+	"something/internal/readinglog/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe keeps the reading logs in step with the user interests, so a
+// status set through PATCH /user/interests/:book_id starts, finishes or
+// queues a read. Logs are removed with the interest, the user or the book.
+func Subscribe(bus eventbus.Bus, repository domain.ReadingLogRepository, bookRepository bookDomain.BookRepository) {
+	bus.Subscribe(userDomain.InterestChangedEvent, func(event eventbus.Event) error {
+		e := event.(*userDomain.InterestChanged)
+		readingLog, _ := repository.Find(e.UserID, e.BookID)
+		if readingLog == nil {
+			book, err := bookRepository.FindByID(e.BookID)
+			if err != nil {
+				return err
+			}
+			readingLog = domain.NewReadingLog(e.UserID, e.BookID, book.Pages)
+		}
+		if readingLog.Status() == e.Status {
+			return nil
+		}
+
+		date := e.OccurredOn().UTC()
+		if read := readingLog.LastRead(); read != nil && date.Before(read.EndedOn()) {
+			date = read.EndedOn()
+		}
+		var err error
+		switch e.Status {
+		case domain.StatusReading:
+			err = readingLog.Start(date)
+		case domain.StatusDone:
+			err = readingLog.Finish(date)
+		default:
+			readingLog.Queue(date)
+		}
+		if err != nil {
+			return err
+		}
+		return repository.Save(readingLog)
+	})
+	bus.Subscribe(userDomain.InterestRemovedEvent, func(event eventbus.Event) error {
+		e := event.(*userDomain.InterestRemoved)
+		return repository.Delete(e.UserID, e.BookID)
+	})
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUserID(event.(*userDomain.UserDeleted).UserID)
+	})
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByBookID(event.(*bookDomain.BookDeleted).BookID)
+	})
+}
@@ -0,0 +1,30 @@
+package track
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ProgressCommand sets the current page, given as a page or as a percentage
+// of the book
+type ProgressCommand struct {
+	UserID     string     `json:"user_id"`
+	BookID     string     `json:"book_id"`
+	Page       *int       `json:"page"`
+	Percentage *float64   `json:"percentage"`
+	Date       *time.Time `json:"date"`
+}
+
+// Validate ...
+func (p ProgressCommand) Validate() error {
+	if p.Page == nil && p.Percentage == nil {
+		return errors.New("page or percentage is required")
+	}
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Page, validation.Min(0)),
+		validation.Field(&p.Percentage, validation.Min(0.0), validation.Max(100.0)),
+		validation.Field(&p.Date, notInFuture()),
+	)
+}
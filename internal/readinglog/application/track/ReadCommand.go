@@ -0,0 +1,32 @@
+package track
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ReadCommand starts or finishes a read, Date defaults to now
+type ReadCommand struct {
+	UserID string     `json:"user_id"`
+	BookID string     `json:"book_id"`
+	Date   *time.Time `json:"date"`
+}
+
+// Validate ...
+func (r ReadCommand) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Date, notInFuture()),
+	)
+}
+
+func notInFuture() validation.Rule {
+	return validation.Max(time.Now().UTC()).Error("must not be in the future")
+}
+
+func dateOrNow(date *time.Time) time.Time {
+	if date == nil {
+		return time.Now().UTC()
+	}
+	return date.UTC()
+}
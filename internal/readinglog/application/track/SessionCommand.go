@@ -0,0 +1,34 @@
+package track
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// SessionCommand logs a reading session ending at ToPage or Percentage,
+// FromPage defaults to the current page
+type SessionCommand struct {
+	UserID     string     `json:"user_id"`
+	BookID     string     `json:"book_id"`
+	FromPage   *int       `json:"from_page"`
+	ToPage     *int       `json:"to_page"`
+	Percentage *float64   `json:"percentage"`
+	Minutes    int        `json:"minutes"`
+	Date       *time.Time `json:"date"`
+}
+
+// Validate ...
+func (s SessionCommand) Validate() error {
+	if s.ToPage == nil && s.Percentage == nil {
+		return errors.New("to_page or percentage is required")
+	}
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.FromPage, validation.Min(0)),
+		validation.Field(&s.ToPage, validation.Min(0)),
+		validation.Field(&s.Percentage, validation.Min(0.0), validation.Max(100.0)),
+		validation.Field(&s.Minutes, validation.Min(0), validation.Max(24*60)),
+		validation.Field(&s.Date, notInFuture()),
+	)
+}
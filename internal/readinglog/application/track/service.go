@@ -0,0 +1,106 @@
+package track
+
+import (
+	bookDomain "something/internal/books/domain"
+	"something/internal/readinglog/application"
+	"something/internal/readinglog/domain"
+	"something/internal/users/application/update"
+)
+
+// Service ...
+type Service interface {
+	StartRead(*ReadCommand) (*application.ReadingLogResponse, error)
+	FinishRead(*ReadCommand) (*application.ReadingLogResponse, error)
+	UpdateProgress(*ProgressCommand) (*application.ReadingLogResponse, error)
+	LogSession(*SessionCommand) (*application.ReadingLogResponse, error)
+}
+
+type service struct {
+	repository     domain.ReadingLogRepository
+	bookRepository bookDomain.BookRepository
+	interests      update.Service
+}
+
+// NewService ...
+func NewService(
+	repository domain.ReadingLogRepository,
+	bookRepository bookDomain.BookRepository,
+	interests update.Service,
+) Service {
+	return &service{repository: repository, bookRepository: bookRepository, interests: interests}
+}
+
+func (s *service) StartRead(command *ReadCommand) (*application.ReadingLogResponse, error) {
+	return s.track(command.UserID, command.BookID, func(readingLog *domain.ReadingLog) error {
+		return readingLog.Start(dateOrNow(command.Date))
+	})
+}
+
+func (s *service) FinishRead(command *ReadCommand) (*application.ReadingLogResponse, error) {
+	return s.track(command.UserID, command.BookID, func(readingLog *domain.ReadingLog) error {
+		return readingLog.Finish(dateOrNow(command.Date))
+	})
+}
+
+func (s *service) UpdateProgress(command *ProgressCommand) (*application.ReadingLogResponse, error) {
+	return s.track(command.UserID, command.BookID, func(readingLog *domain.ReadingLog) error {
+		page := 0
+		if command.Page != nil {
+			page = *command.Page
+		} else {
+			page = readingLog.PageAt(*command.Percentage)
+		}
+		return readingLog.UpdateProgress(page, dateOrNow(command.Date))
+	})
+}
+
+func (s *service) LogSession(command *SessionCommand) (*application.ReadingLogResponse, error) {
+	return s.track(command.UserID, command.BookID, func(readingLog *domain.ReadingLog) error {
+		toPage := 0
+		if command.ToPage != nil {
+			toPage = *command.ToPage
+		} else {
+			toPage = readingLog.PageAt(*command.Percentage)
+		}
+		fromPage := 0
+		if command.FromPage != nil {
+			fromPage = *command.FromPage
+		} else if read := readingLog.CurrentRead(); read != nil {
+			fromPage = read.CurrentPage
+		}
+		return readingLog.LogSession(fromPage, toPage, command.Minutes, dateOrNow(command.Date))
+	})
+}
+
+// track applies change to the reading log of the book, creating it on the
+// first use, and keeps the user interest status in sync with the log
+func (s *service) track(
+	userID, bookID string,
+	change func(*domain.ReadingLog) error,
+) (*application.ReadingLogResponse, error) {
+	book, err := s.bookRepository.FindByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	readingLog, _ := s.repository.Find(userID, bookID)
+	if readingLog == nil {
+		readingLog = domain.NewReadingLog(userID, bookID, book.Pages)
+	}
+	readingLog.Pages = book.Pages
+	if err = change(readingLog); err != nil {
+		return nil, err
+	}
+	if err = s.repository.Save(readingLog); err != nil {
+		return nil, err
+	}
+
+	err = s.interests.UpdateUserInterests(&update.UserInterestsCommand{
+		UserID: userID,
+		BookID: bookID,
+		Status: readingLog.Status(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return application.NewReadingLogResponse(readingLog), nil
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Reading statuses, the same ones stored in the user interests
+const (
+	StatusPending = "pending"
+	StatusReading = "reading"
+	StatusDone    = "done"
+)
+
+// ReadingLog is the reading history of a book by a user, every read of the
+// book is a Read so re-reads keep the previous ones
+type ReadingLog struct {
+	UserID    string
+	BookID    string
+	Pages     int
+	Reads     []*Read
+	Queued    bool
+	CreatedOn time.Time
+	UpdatedOn time.Time
+}
+
+// Read is a read-through of the book, it ends finished or abandoned
+type Read struct {
+	StartedOn   time.Time
+	FinishedOn  *time.Time
+	AbandonedOn *time.Time
+	CurrentPage int
+	Sessions    []*Session
+}
+
+// Session is a sitting in which pages FromPage to ToPage were read
+type Session struct {
+	Date     time.Time
+	FromPage int
+	ToPage   int
+	Minutes  int
+}
+
+// NewReadingLog ...
+func NewReadingLog(userID, bookID string, pages int) *ReadingLog {
+	now := time.Now().UTC()
+	return &ReadingLog{
+		UserID:    userID,
+		BookID:    bookID,
+		Pages:     pages,
+		Reads:     []*Read{},
+		CreatedOn: now,
+		UpdatedOn: now,
+	}
+}
+
+// Status derives the interest status: reading while a read is unfinished,
+// done after finishing one and pending before starting or when queued again
+func (l *ReadingLog) Status() string {
+	if l.CurrentRead() != nil {
+		return StatusReading
+	}
+	if l.Queued || l.TimesRead() == 0 {
+		return StatusPending
+	}
+	return StatusDone
+}
+
+// CurrentRead returns the unfinished read, nil if there is none
+func (l *ReadingLog) CurrentRead() *Read {
+	if len(l.Reads) == 0 {
+		return nil
+	}
+	last := l.Reads[len(l.Reads)-1]
+	if last.FinishedOn != nil || last.AbandonedOn != nil {
+		return nil
+	}
+	return last
+}
+
+// LastRead returns the latest read, finished or not
+func (l *ReadingLog) LastRead() *Read {
+	if len(l.Reads) == 0 {
+		return nil
+	}
+	return l.Reads[len(l.Reads)-1]
+}
+
+// TimesRead is the number of finished reads
+func (l *ReadingLog) TimesRead() int {
+	times := 0
+	for _, read := range l.Reads {
+		if read.FinishedOn != nil {
+			times++
+		}
+	}
+	return times
+}
+
+// Percentage of the book read in the latest read
+func (l *ReadingLog) Percentage() float64 {
+	read := l.LastRead()
+	if read == nil || l.Pages <= 0 {
+		return 0
+	}
+	return math.Round(float64(read.CurrentPage)/float64(l.Pages)*1000) / 10
+}
+
+// PageAt converts a percentage of the book into a page
+func (l *ReadingLog) PageAt(percentage float64) int {
+	return int(math.Round(percentage * float64(l.Pages) / 100))
+}
+
+// Start begins a new read, a re-read when the book was already finished
+func (l *ReadingLog) Start(date time.Time) error {
+	if l.CurrentRead() != nil {
+		return errors.New("book is already being read")
+	}
+	if last := l.LastRead(); last != nil && date.Before(last.EndedOn()) {
+		return errors.New("a read can't start before the previous one ended")
+	}
+	l.Reads = append(l.Reads, &Read{StartedOn: date.UTC(), Sessions: []*Session{}})
+	l.Queued = false
+	l.UpdatedOn = time.Now().UTC()
+	return nil
+}
+
+// Queue marks the book as pending to be read, abandoning the unfinished read
+func (l *ReadingLog) Queue(date time.Time) {
+	if read := l.CurrentRead(); read != nil {
+		abandonedOn := date.UTC()
+		if abandonedOn.Before(read.StartedOn) {
+			abandonedOn = read.StartedOn
+		}
+		read.AbandonedOn = &abandonedOn
+	}
+	l.Queued = true
+	l.UpdatedOn = time.Now().UTC()
+}
+
+// UpdateProgress moves the current page of the unfinished read, starting
+// one if needed. Reaching the last page finishes the read.
+func (l *ReadingLog) UpdateProgress(page int, date time.Time) error {
+	if err := l.validPage(page); err != nil {
+		return err
+	}
+	read, err := l.ensureRead(date)
+	if err != nil {
+		return err
+	}
+	read.CurrentPage = page
+	l.UpdatedOn = time.Now().UTC()
+	if l.Pages > 0 && page == l.Pages {
+		return l.Finish(date)
+	}
+	return nil
+}
+
+// LogSession records a reading session and moves the current page to its end
+func (l *ReadingLog) LogSession(fromPage, toPage, minutes int, date time.Time) error {
+	if err := l.validPage(toPage); err != nil {
+		return err
+	}
+	if fromPage < 0 || fromPage > toPage {
+		return errors.New("from page must be between 0 and to page")
+	}
+	read, err := l.ensureRead(date)
+	if err != nil {
+		return err
+	}
+	if date.Before(read.StartedOn) {
+		return errors.New("session date is before the read started")
+	}
+	read.Sessions = append(read.Sessions, &Session{
+		Date:     date.UTC(),
+		FromPage: fromPage,
+		ToPage:   toPage,
+		Minutes:  minutes,
+	})
+	return l.UpdateProgress(toPage, date)
+}
+
+// Finish ends the unfinished read, starting and finishing one if needed
+func (l *ReadingLog) Finish(date time.Time) error {
+	read, err := l.ensureRead(date)
+	if err != nil {
+		return err
+	}
+	if date.Before(read.StartedOn) {
+		return errors.New("a read can't finish before it started")
+	}
+	finishedOn := date.UTC()
+	read.FinishedOn = &finishedOn
+	if l.Pages > 0 {
+		read.CurrentPage = l.Pages
+	}
+	l.UpdatedOn = time.Now().UTC()
+	return nil
+}
+
+func (l *ReadingLog) ensureRead(date time.Time) (*Read, error) {
+	if read := l.CurrentRead(); read != nil {
+		return read, nil
+	}
+	if err := l.Start(date); err != nil {
+		return nil, err
+	}
+	return l.CurrentRead(), nil
+}
+
+func (l *ReadingLog) validPage(page int) error {
+	if page < 0 || (l.Pages > 0 && page > l.Pages) {
+		return errors.New("page out of range")
+	}
+	return nil
+}
+
+// EndedOn is the finish or abandon date of the read, zero while unfinished
+func (r *Read) EndedOn() time.Time {
+	if r.FinishedOn != nil {
+		return *r.FinishedOn
+	}
+	if r.AbandonedOn != nil {
+		return *r.AbandonedOn
+	}
+	return time.Time{}
+}
@@ -0,0 +1,11 @@
+package domain
+
+// ReadingLogRepository ...
+type ReadingLogRepository interface {
+	Find(userID, bookID string) (*ReadingLog, error)
+	FindByUserID(string) ([]*ReadingLog, error)
+	Save(*ReadingLog) error
+	Delete(userID, bookID string) error
+	DeleteByUserID(string) error
+	DeleteByBookID(string) error
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"errors"
+
+	"something/internal/readinglog/domain"
+)
+
+type repository struct {
+	readingLogs map[string]*domain.ReadingLog
+}
+
+var (
+	readingLogInstance *repository
+)
+
+// NewInMemoryReadingLogRepository ...
+func NewInMemoryReadingLogRepository() domain.ReadingLogRepository {
+	readingLogInstance = &repository{
+		readingLogs: make(map[string]*domain.ReadingLog),
+	}
+	return readingLogInstance
+}
+
+func key(userID, bookID string) string {
+	return userID + "/" + bookID
+}
+
+func (r *repository) Find(userID, bookID string) (*domain.ReadingLog, error) {
+	readingLog, ok := r.readingLogs[key(userID, bookID)]
+	if !ok {
+		return nil, errors.New("reading log not found")
+	}
+	return readingLog, nil
+}
+
+func (r *repository) FindByUserID(userID string) ([]*domain.ReadingLog, error) {
+	readingLogs := []*domain.ReadingLog{}
+	for _, readingLog := range r.readingLogs {
+		if readingLog.UserID == userID {
+			readingLogs = append(readingLogs, readingLog)
+		}
+	}
+	return readingLogs, nil
+}
+
+func (r *repository) Save(readingLog *domain.ReadingLog) error {
+	r.readingLogs[key(readingLog.UserID, readingLog.BookID)] = readingLog
+	return nil
+}
+
+func (r *repository) Delete(userID, bookID string) error {
+	delete(r.readingLogs, key(userID, bookID))
+	return nil
+}
+
+func (r *repository) DeleteByUserID(userID string) error {
+	for id, readingLog := range r.readingLogs {
+		if readingLog.UserID == userID {
+			delete(r.readingLogs, id)
+		}
+	}
+	return nil
+}
+
+func (r *repository) DeleteByBookID(bookID string) error {
+	for id, readingLog := range r.readingLogs {
+		if readingLog.BookID == bookID {
+			delete(r.readingLogs, id)
+		}
+	}
+	return nil
+}
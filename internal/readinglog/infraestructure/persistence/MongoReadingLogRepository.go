@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"something/internal/readinglog/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepository struct {
+	con *mongo.Collection
+}
+
+// NewMongoReadingLogRepository ...
+func NewMongoReadingLogRepository(m *mongo.Database) domain.ReadingLogRepository {
+	con := m.Collection("reading_logs")
+	_, err := con.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{
+			primitive.E{Key: "userid", Value: 1},
+			primitive.E{Key: "bookid", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoRepository{con: con}
+}
+
+func (r *mongoRepository) Find(userID, bookID string) (*domain.ReadingLog, error) {
+	var result *domain.ReadingLog
+	err := r.con.FindOne(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "userid", Value: userID},
+			primitive.E{Key: "bookid", Value: bookID},
+		},
+		options.FindOne()).Decode(&result)
+	if result == nil {
+		log.Println(err)
+		return nil, errors.New("reading log not found")
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) FindByUserID(userID string) ([]*domain.ReadingLog, error) {
+	readingLogs := []*domain.ReadingLog{}
+	cur, err := r.con.Find(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return readingLogs, err
+	}
+	if err = cur.All(context.TODO(), &readingLogs); err != nil {
+		log.Println(err)
+		return readingLogs, err
+	}
+	return readingLogs, nil
+}
+
+func (r *mongoRepository) Save(readingLog *domain.ReadingLog) error {
+	_, err := r.con.ReplaceOne(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "userid", Value: readingLog.UserID},
+			primitive.E{Key: "bookid", Value: readingLog.BookID},
+		},
+		readingLog,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) Delete(userID, bookID string) error {
+	_, err := r.con.DeleteOne(context.TODO(), bson.D{
+		primitive.E{Key: "userid", Value: userID},
+		primitive.E{Key: "bookid", Value: bookID},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByUserID(userID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByBookID(bookID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "bookid", Value: bookID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"math"
+
+	bookDomain "something/internal/books/domain"
+	"something/internal/helpers"
+	"something/internal/recommendations/domain"
+)
+
+// RecommendationResponse ...
+type RecommendationResponse struct {
+	BookID  string   `json:"book_id"`
+	Title   string   `json:"title"`
+	Author  string   `json:"author"`
+	Genre   string   `json:"genre"`
+	Rating  float64  `json:"rating"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// NewRecommendationResponse ...
+func NewRecommendationResponse(recommendation *domain.Recommendation, book *bookDomain.Book) *RecommendationResponse {
+	return &RecommendationResponse{
+		BookID:  book.ID,
+		Title:   book.Title,
+		Author:  book.Author,
+		Genre:   book.Genre,
+		Rating:  helpers.Round(book.Rating, 0.5),
+		Score:   math.Round(recommendation.Score*100) / 100,
+		Reasons: recommendation.Reasons,
+	}
+}
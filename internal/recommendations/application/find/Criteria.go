@@ -0,0 +1,7 @@
+package find
+
+// Criteria ...
+type Criteria struct {
+	UserID string
+	Limit  int
+}
@@ -0,0 +1,318 @@
+package find
+
+import (
+	"sort"
+	"strings"
+
+	bookReviewDomain "something/internal/bookreviews/domain"
+	bookDomain "something/internal/books/domain"
+	"something/internal/recommendations/application"
+	"something/internal/recommendations/domain"
+	userFollowDomain "something/internal/userfollow/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// LIMIT Default number of recommendations
+const LIMIT int = 20
+
+// MAXLIMIT Maximum number of recommendations
+const MAXLIMIT int = 50
+
+// Weight of every signal in the final score
+const (
+	genreWeight          = 1.0
+	authorWeight         = 1.5
+	followingWeight      = 1.0
+	similarReadersWeight = 2.0
+)
+
+const (
+	// Reviews at or above this rating count as a recommendation
+	highRating = 4.0
+	// Affinity uses the most read genres and authors only
+	topAffinities = 3
+	// Books fetched for every genre and author
+	candidatesPerAffinity = 50
+	// Readers with the most similar ratings used for collaborative filtering
+	maxNeighbours = 20
+	// Most recent reviews of the user compared with other readers
+	maxSampledBooks = 50
+	// Reviews of other readers sampled to find the neighbours
+	maxCoReviews int64 = 1000
+	// Co-rated books needed for a neighbour similarity to count in full
+	minCoRated = 3
+	// Widest possible difference between two ratings (0.5 to 5)
+	maxRatingDistance = 4.5
+)
+
+// Interest weights, finished books say more about taste than ongoing ones
+var statusWeight = map[string]float64{
+	"done":    1.0,
+	"reading": 0.5,
+}
+
+// Service ...
+type Service interface {
+	Recommend(criteria *Criteria) ([]*application.RecommendationResponse, error)
+}
+
+type service struct {
+	bookRepository       bookDomain.BookRepository
+	bookReviewRepository bookReviewDomain.BookReviewRepository
+	userRepository       userDomain.UserRepository
+	userFollowRepository userFollowDomain.UserFollowRepository
+}
+
+// NewService ...
+func NewService(
+	bookRepository bookDomain.BookRepository,
+	bookReviewRepository bookReviewDomain.BookReviewRepository,
+	userRepository userDomain.UserRepository,
+	userFollowRepository userFollowDomain.UserFollowRepository,
+) Service {
+	return &service{
+		bookRepository:       bookRepository,
+		bookReviewRepository: bookReviewRepository,
+		userRepository:       userRepository,
+		userFollowRepository: userFollowRepository,
+	}
+}
+
+func (s *service) Recommend(criteria *Criteria) ([]*application.RecommendationResponse, error) {
+	if criteria.Limit <= 0 || criteria.Limit > MAXLIMIT {
+		criteria.Limit = LIMIT
+	}
+
+	user, err := s.userRepository.FindByID(criteria.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userReviews, err := s.bookReviewRepository.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Books the user already knows are never recommended
+	known := map[string]bool{}
+	for bookID := range user.Interests {
+		known[bookID] = true
+	}
+	for _, review := range userReviews {
+		known[review.BookID] = true
+	}
+
+	recommendations := domain.Recommendations{}
+	if err = s.addAffinity(recommendations, user); err != nil {
+		return nil, err
+	}
+	if err = s.addFollowing(recommendations, user.ID); err != nil {
+		return nil, err
+	}
+	if err = s.addSimilarReaders(recommendations, user.ID, userReviews); err != nil {
+		return nil, err
+	}
+	for bookID := range known {
+		delete(recommendations, bookID)
+	}
+
+	top := recommendations.Top(0)
+	bookIDs := make([]string, 0, len(top))
+	for _, recommendation := range top {
+		bookIDs = append(bookIDs, recommendation.BookID)
+	}
+	books, err := s.booksByID(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []*application.RecommendationResponse{}
+	for _, recommendation := range top {
+		book, ok := books[recommendation.BookID]
+		if !ok {
+			continue
+		}
+		response = append(response, application.NewRecommendationResponse(recommendation, book))
+		if len(response) == criteria.Limit {
+			break
+		}
+	}
+	return response, nil
+}
+
+// addAffinity scores books sharing genre or author with the books the user
+// is reading or has read, proportionally to how often they appear
+func (s *service) addAffinity(recommendations domain.Recommendations, user *userDomain.User) error {
+	bookIDs := []string{}
+	for bookID, status := range user.Interests {
+		if _, ok := statusWeight[status]; ok {
+			bookIDs = append(bookIDs, bookID)
+		}
+	}
+	books, err := s.booksByID(bookIDs)
+	if err != nil {
+		return err
+	}
+
+	genres := map[string]float64{}
+	authors := map[string]float64{}
+	total := 0.0
+	for bookID, book := range books {
+		weight := statusWeight[user.Interests[bookID]]
+		genres[book.Genre] += weight
+		authors[book.Author] += weight
+		total += weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	for _, genre := range top(genres, topAffinities) {
+		books, err := s.bookRepository.Find(bookDomain.NewBookCriteria(
+			1, candidatesPerAffinity, "", genre, "", 0, bookDomain.SortRatingDesc))
+		if err != nil {
+			return err
+		}
+		for _, book := range books {
+			if strings.EqualFold(book.Genre, genre) {
+				recommendations.Add(book.ID, domain.ReasonGenre, genreWeight*genres[genre]/total)
+			}
+		}
+	}
+	for _, author := range top(authors, topAffinities) {
+		books, err := s.bookRepository.Find(bookDomain.NewBookCriteria(
+			1, candidatesPerAffinity, "", "", author, 0, bookDomain.SortRatingDesc))
+		if err != nil {
+			return err
+		}
+		for _, book := range books {
+			if strings.EqualFold(book.Author, author) {
+				recommendations.Add(book.ID, domain.ReasonAuthor, authorWeight*authors[author]/total)
+			}
+		}
+	}
+	return nil
+}
+
+// addFollowing scores the books highly rated by the users followed
+func (s *service) addFollowing(recommendations domain.Recommendations, userID string) error {
+	following, err := s.userFollowRepository.FindFollowing(userID)
+	if err != nil {
+		return err
+	}
+	if len(following) == 0 {
+		return nil
+	}
+	followedIDs := make([]string, 0, len(following))
+	for _, follow := range following {
+		followedIDs = append(followedIDs, follow.To)
+	}
+	reviews, err := s.bookReviewRepository.FindByUserIDs(followedIDs, highRating)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		recommendations.Add(review.BookID, domain.ReasonFollowing, followingWeight*review.Rating/5)
+	}
+	return nil
+}
+
+// addSimilarReaders finds the readers whose ratings agree the most with the
+// user ones on co-rated books and scores the books they rated highly
+func (s *service) addSimilarReaders(
+	recommendations domain.Recommendations,
+	userID string,
+	userReviews []*bookReviewDomain.BookReview,
+) error {
+	// Only the most recent reviews of the user and a bounded sample of
+	// co-reviews are compared, fetched in one query
+	sort.Slice(userReviews, func(i, j int) bool {
+		return userReviews[i].CreatedOn.After(userReviews[j].CreatedOn)
+	})
+	ratings := map[string]float64{}
+	bookIDs := []string{}
+	for _, userReview := range userReviews {
+		if len(bookIDs) == maxSampledBooks {
+			break
+		}
+		ratings[userReview.BookID] = userReview.Rating
+		bookIDs = append(bookIDs, userReview.BookID)
+	}
+	if len(bookIDs) == 0 {
+		return nil
+	}
+	coReviews, err := s.bookReviewRepository.FindCoReviews(bookIDs, userID, maxCoReviews)
+	if err != nil {
+		return err
+	}
+
+	agreement := map[string]float64{}
+	coRated := map[string]int{}
+	for _, review := range coReviews {
+		distance := ratings[review.BookID] - review.Rating
+		if distance < 0 {
+			distance = -distance
+		}
+		agreement[review.UserID] += 1 - distance/maxRatingDistance
+		coRated[review.UserID]++
+	}
+
+	similarity := map[string]float64{}
+	for readerID, sum := range agreement {
+		// Damp readers sharing only a couple of books with the user
+		confidence := float64(coRated[readerID]) / minCoRated
+		if confidence > 1 {
+			confidence = 1
+		}
+		similarity[readerID] = sum / float64(coRated[readerID]) * confidence
+	}
+
+	neighbours := top(similarity, maxNeighbours)
+	if len(neighbours) == 0 {
+		return nil
+	}
+	reviews, err := s.bookReviewRepository.FindByUserIDs(neighbours, highRating)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		recommendations.Add(review.BookID, domain.ReasonSimilarReaders,
+			similarReadersWeight*similarity[review.UserID]*review.Rating/5)
+	}
+	return nil
+}
+
+// booksByID loads the books in a single query, indexed by ID
+func (s *service) booksByID(ids []string) (map[string]*bookDomain.Book, error) {
+	books := map[string]*bookDomain.Book{}
+	if len(ids) == 0 {
+		return books, nil
+	}
+	found, err := s.bookRepository.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, book := range found {
+		books[book.ID] = book
+	}
+	return books, nil
+}
+
+// top returns the n keys with the highest positive weight
+func top(weights map[string]float64, n int) []string {
+	keys := make([]string, 0, len(weights))
+	for key, weight := range weights {
+		if weight > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if weights[keys[i]] == weights[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return weights[keys[i]] > weights[keys[j]]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
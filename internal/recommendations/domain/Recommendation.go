@@ -0,0 +1,58 @@
+package domain
+
+import "sort"
+
+// Recommendation reasons
+const (
+	ReasonGenre          = "genre"
+	ReasonAuthor         = "author"
+	ReasonFollowing      = "following"
+	ReasonSimilarReaders = "similar_readers"
+)
+
+// Recommendation is a book suggested to a user with the signals that scored it
+type Recommendation struct {
+	BookID  string
+	Score   float64
+	Reasons []string
+}
+
+// Recommendations accumulates scores per book
+type Recommendations map[string]*Recommendation
+
+// Add sums score to the recommendation of bookID and records the reason once
+func (r Recommendations) Add(bookID, reason string, score float64) {
+	if score <= 0 {
+		return
+	}
+	recommendation, ok := r[bookID]
+	if !ok {
+		recommendation = &Recommendation{BookID: bookID}
+		r[bookID] = recommendation
+	}
+	recommendation.Score += score
+	for _, existing := range recommendation.Reasons {
+		if existing == reason {
+			return
+		}
+	}
+	recommendation.Reasons = append(recommendation.Reasons, reason)
+}
+
+// Top returns the best scored recommendations, ties broken by book ID
+func (r Recommendations) Top(limit int) []*Recommendation {
+	top := make([]*Recommendation, 0, len(r))
+	for _, recommendation := range r {
+		top = append(top, recommendation)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Score == top[j].Score {
+			return top[i].BookID < top[j].BookID
+		}
+		return top[i].Score > top[j].Score
+	})
+	if limit > 0 && len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"something/internal/shelves/domain"
+	"time"
+)
+
+// ShelfResponse ...
+type ShelfResponse struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Public    bool                 `json:"public"`
+	Default   bool                 `json:"default"`
+	Status    string               `json:"status,omitempty"`
+	Books     []*ShelfBookResponse `json:"books"`
+	CreatedOn time.Time            `json:"created_on"`
+	UpdatedOn time.Time            `json:"updated_on"`
+}
+
+// ShelfBookResponse ...
+type ShelfBookResponse struct {
+	BookID   string    `json:"book_id"`
+	Position int       `json:"position"`
+	AddedOn  time.Time `json:"added_on"`
+}
+
+// NewShelfResponse ...
+func NewShelfResponse(shelf *domain.Shelf) *ShelfResponse {
+	books := []*ShelfBookResponse{}
+	for i, book := range shelf.Books {
+		books = append(books, &ShelfBookResponse{BookID: book.BookID, Position: i, AddedOn: book.AddedOn})
+	}
+	return &ShelfResponse{
+		ID:        shelf.ID,
+		Name:      shelf.Name,
+		Public:    shelf.Public,
+		Default:   shelf.IsDefault(),
+		Status:    shelf.Status,
+		Books:     books,
+		CreatedOn: shelf.CreatedOn,
+		UpdatedOn: shelf.UpdatedOn,
+	}
+}
+
+// NewShelvesResponse ...
+func NewShelvesResponse(shelves []*domain.Shelf) []*ShelfResponse {
+	shelvesResponse := []*ShelfResponse{}
+	for _, shelf := range shelves {
+		shelvesResponse = append(shelvesResponse, NewShelfResponse(shelf))
+	}
+	return shelvesResponse
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// UserShelves returns the shelves of the user, default shelves first. The
+// default shelves are created on registration, or on first use with the
+// books of the user interests for older users. A concurrent request may
+// create them first, its shelves are used then.
+func UserShelves(
+	repository domain.ShelfRepository,
+	userRepository userDomain.UserRepository,
+	userID string,
+) ([]*domain.Shelf, error) {
+	user, err := userRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	shelves, err := repository.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := map[string]*domain.Shelf{}
+	custom := []*domain.Shelf{}
+	for _, shelf := range shelves {
+		if shelf.IsDefault() {
+			defaults[shelf.Status] = shelf
+		} else {
+			custom = append(custom, shelf)
+		}
+	}
+
+	result := []*domain.Shelf{}
+	for _, status := range domain.DefaultStatuses {
+		shelf, ok := defaults[status]
+		if !ok {
+			shelf = domain.NewDefaultShelf(userID, status)
+			bookIDs := []string{}
+			for bookID, interest := range user.Interests {
+				if interest == status {
+					bookIDs = append(bookIDs, bookID)
+				}
+			}
+			sort.Strings(bookIDs)
+			for _, bookID := range bookIDs {
+				shelf.Add(bookID, -1)
+			}
+			if err := repository.Save(shelf); err != nil {
+				if err.Error() != "default shelf already exists" {
+					return nil, err
+				}
+				if shelf, err = findDefault(repository, userID, status); err != nil {
+					return nil, err
+				}
+			}
+		}
+		result = append(result, shelf)
+	}
+	return append(result, custom...), nil
+}
+
+func findDefault(repository domain.ShelfRepository, userID, status string) (*domain.Shelf, error) {
+	shelves, err := repository.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, shelf := range shelves {
+		if shelf.Status == status {
+			return shelf, nil
+		}
+	}
+	return nil, errors.New("shelf not found")
+}
+
+// NameTaken reports if another shelf of the list is already called name
+func NameTaken(shelves []*domain.Shelf, id, name string) bool {
+	name = strings.TrimSpace(name)
+	for _, shelf := range shelves {
+		if shelf.ID != id && strings.EqualFold(shelf.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package books
+
+import validation "github.com/go-ozzo/ozzo-validation"
+
+// ShelfBookCommand places a book in a shelf, at the end when Position is
+// not set
+type ShelfBookCommand struct {
+	ShelfID  string `json:"shelf_id"`
+	UserID   string `json:"user_id"`
+	BookID   string `json:"book_id"`
+	Position *int   `json:"position"`
+}
+
+// Validate ...
+func (s ShelfBookCommand) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Position, validation.Min(0)),
+	)
+}
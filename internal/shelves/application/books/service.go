@@ -0,0 +1,101 @@
+package books
+
+import (
+	"errors"
+
+	bookDomain "something/internal/books/domain"
+	"something/internal/shelves/domain"
+	userDelete "something/internal/users/application/delete"
+	userUpdate "something/internal/users/application/update"
+)
+
+// Service ...
+type Service interface {
+	AddBook(*ShelfBookCommand) error
+	RemoveBook(shelfID, userID, bookID string) error
+}
+
+type service struct {
+	repository      domain.ShelfRepository
+	bookRepository  bookDomain.BookRepository
+	interests       userUpdate.Service
+	interestsDelete userDelete.Service
+}
+
+// NewService ...
+func NewService(
+	repository domain.ShelfRepository,
+	bookRepository bookDomain.BookRepository,
+	interests userUpdate.Service,
+	interestsDelete userDelete.Service,
+) Service {
+	return &service{
+		repository:      repository,
+		bookRepository:  bookRepository,
+		interests:       interests,
+		interestsDelete: interestsDelete,
+	}
+}
+
+// AddBook places the book in the shelf. Default shelves change the user
+// interest, which moves the book out of the other default shelves.
+func (s *service) AddBook(command *ShelfBookCommand) error {
+	shelf, err := s.find(command.ShelfID, command.UserID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.bookRepository.FindByID(command.BookID); err != nil {
+		return err
+	}
+
+	if shelf.IsDefault() {
+		err = s.interests.UpdateUserInterests(&userUpdate.UserInterestsCommand{
+			UserID: command.UserID,
+			BookID: command.BookID,
+			Status: shelf.Status,
+		})
+		if err != nil {
+			return err
+		}
+		if command.Position == nil {
+			return nil
+		}
+		if shelf, err = s.repository.FindByID(shelf.ID); err != nil {
+			return err
+		}
+	}
+
+	position := -1
+	if command.Position != nil {
+		position = *command.Position
+	}
+	shelf.Add(command.BookID, position)
+	return s.repository.Save(shelf)
+}
+
+// RemoveBook takes the book out of the shelf, for default shelves it
+// removes the user interest
+func (s *service) RemoveBook(shelfID, userID, bookID string) error {
+	shelf, err := s.find(shelfID, userID)
+	if err != nil {
+		return err
+	}
+	if shelf.Position(bookID) < 0 {
+		return errors.New("book not in shelf")
+	}
+	if shelf.IsDefault() {
+		return s.interestsDelete.DeleteUserInterests(userID, bookID)
+	}
+	if err := shelf.Remove(bookID); err != nil {
+		return err
+	}
+	return s.repository.Save(shelf)
+}
+
+func (s *service) find(shelfID, userID string) (*domain.Shelf, error) {
+	shelf, err := s.repository.FindByID(shelfID)
+	if err != nil || shelf.UserID != userID {
+		return nil, errors.New("shelf not found")
+	}
+	return shelf, nil
+}
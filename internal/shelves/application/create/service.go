@@ -0,0 +1,42 @@
+package create
+
+import (
+	"errors"
+
+	"something/internal/shelves/application"
+	"something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// Service ...
+type Service interface {
+	CreateShelf(*ShelfCommand) error
+}
+
+type service struct {
+	repository     domain.ShelfRepository
+	userRepository userDomain.UserRepository
+}
+
+// NewService ...
+func NewService(repository domain.ShelfRepository, userRepository userDomain.UserRepository) Service {
+	return &service{repository: repository, userRepository: userRepository}
+}
+
+func (s *service) CreateShelf(command *ShelfCommand) error {
+	if existing, _ := s.repository.FindByID(command.ID); existing != nil {
+		return errors.New("shelf already exists")
+	}
+	shelves, err := application.UserShelves(s.repository, s.userRepository, command.UserID)
+	if err != nil {
+		return err
+	}
+	if application.NameTaken(shelves, command.ID, command.Name) {
+		return errors.New("shelf name already exists")
+	}
+	shelf, err := domain.NewShelf(command.ID, command.UserID, command.Name, command.Public)
+	if err != nil {
+		return err
+	}
+	return s.repository.Save(shelf)
+}
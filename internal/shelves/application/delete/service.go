@@ -0,0 +1,32 @@
+package delete
+
+import (
+	"errors"
+
+	"something/internal/shelves/domain"
+)
+
+// Service ...
+type Service interface {
+	DeleteShelf(id, userID string) error
+}
+
+type service struct {
+	repository domain.ShelfRepository
+}
+
+// NewService ...
+func NewService(repository domain.ShelfRepository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) DeleteShelf(id, userID string) error {
+	shelf, err := s.repository.FindByID(id)
+	if err != nil || shelf.UserID != userID {
+		return errors.New("shelf not found")
+	}
+	if shelf.IsDefault() {
+		return errors.New("default shelves can't be deleted")
+	}
+	return s.repository.Delete(id)
+}
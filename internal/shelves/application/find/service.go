@@ -0,0 +1,53 @@
+package find
+
+import (
+	"errors"
+
+	"something/internal/shelves/application"
+	"something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// Service ...
+type Service interface {
+	FindUserShelves(userID, viewerID string) ([]*application.ShelfResponse, error)
+	FindShelf(id, viewerID string) (*application.ShelfResponse, error)
+}
+
+type service struct {
+	repository     domain.ShelfRepository
+	userRepository userDomain.UserRepository
+}
+
+// NewService ...
+func NewService(repository domain.ShelfRepository, userRepository userDomain.UserRepository) Service {
+	return &service{repository: repository, userRepository: userRepository}
+}
+
+// FindUserShelves lists the shelves of the user, the private ones only when
+// the viewer is the owner
+func (s *service) FindUserShelves(userID, viewerID string) ([]*application.ShelfResponse, error) {
+	shelves, err := application.UserShelves(s.repository, s.userRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+	visible := []*domain.Shelf{}
+	for _, shelf := range shelves {
+		if shelf.Public || shelf.UserID == viewerID {
+			visible = append(visible, shelf)
+		}
+	}
+	return application.NewShelvesResponse(visible), nil
+}
+
+// FindShelf returns the shelf, private shelves are not found for other users
+func (s *service) FindShelf(id, viewerID string) (*application.ShelfResponse, error) {
+	shelf, err := s.repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !shelf.Public && shelf.UserID != viewerID {
+		return nil, errors.New("shelf not found")
+	}
+	return application.NewShelfResponse(shelf), nil
+}
@@ -0,0 +1,63 @@
+package status
+
+import (
+	bookDomain "something/internal/books/domain"
+	"something/internal/shelves/application"
+	"something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe creates the default shelves of new users, keeps them in step
+// with the user interests and removes deleted users and books from the shelves
+func Subscribe(bus eventbus.Bus, repository domain.ShelfRepository, userRepository userDomain.UserRepository) {
+	bus.Subscribe(userDomain.UserRegisteredEvent, func(event eventbus.Event) error {
+		_, err := application.UserShelves(repository, userRepository, event.(*userDomain.UserRegistered).UserID)
+		return err
+	})
+	bus.Subscribe(userDomain.InterestChangedEvent, func(event eventbus.Event) error {
+		e := event.(*userDomain.InterestChanged)
+		return placeBook(repository, userRepository, e.UserID, e.BookID, e.Status)
+	})
+	bus.Subscribe(userDomain.InterestRemovedEvent, func(event eventbus.Event) error {
+		e := event.(*userDomain.InterestRemoved)
+		return placeBook(repository, userRepository, e.UserID, e.BookID, "")
+	})
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUserID(event.(*userDomain.UserDeleted).UserID)
+	})
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		return repository.RemoveBook(event.(*bookDomain.BookDeleted).BookID)
+	})
+}
+
+// placeBook leaves the book only in the default shelf of status, in none
+// when status is empty
+func placeBook(
+	repository domain.ShelfRepository,
+	userRepository userDomain.UserRepository,
+	userID, bookID, status string,
+) error {
+	shelves, err := application.UserShelves(repository, userRepository, userID)
+	if err != nil {
+		return err
+	}
+	for _, shelf := range shelves {
+		if !shelf.IsDefault() {
+			continue
+		}
+		inShelf := shelf.Position(bookID) >= 0
+		switch {
+		case shelf.Status == status && !inShelf:
+			shelf.Add(bookID, -1)
+		case shelf.Status != status && inShelf:
+			shelf.Remove(bookID)
+		default:
+			continue
+		}
+		if err := repository.Save(shelf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package update
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ShelfCommand ...
+type ShelfCommand struct {
+	ID     string  `json:"id"`
+	UserID string  `json:"user_id"`
+	Name   *string `json:"name"`
+	Public *bool   `json:"public"`
+}
+
+// Validate ...
+func (s ShelfCommand) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Length(1, 45)),
+	)
+}
@@ -0,0 +1,47 @@
+package update
+
+import (
+	"errors"
+
+	"something/internal/shelves/application"
+	"something/internal/shelves/domain"
+	userDomain "something/internal/users/domain"
+)
+
+// Service ...
+type Service interface {
+	UpdateShelf(*ShelfCommand) error
+}
+
+type service struct {
+	repository     domain.ShelfRepository
+	userRepository userDomain.UserRepository
+}
+
+// NewService ...
+func NewService(repository domain.ShelfRepository, userRepository userDomain.UserRepository) Service {
+	return &service{repository: repository, userRepository: userRepository}
+}
+
+func (s *service) UpdateShelf(command *ShelfCommand) error {
+	shelf, err := s.repository.FindByID(command.ID)
+	if err != nil || shelf.UserID != command.UserID {
+		return errors.New("shelf not found")
+	}
+	if command.Name != nil && *command.Name != shelf.Name {
+		shelves, err := application.UserShelves(s.repository, s.userRepository, command.UserID)
+		if err != nil {
+			return err
+		}
+		if application.NameTaken(shelves, shelf.ID, *command.Name) {
+			return errors.New("shelf name already exists")
+		}
+		if err := shelf.Rename(*command.Name); err != nil {
+			return err
+		}
+	}
+	if command.Public != nil {
+		shelf.SetPublic(*command.Public)
+	}
+	return s.repository.Save(shelf)
+}
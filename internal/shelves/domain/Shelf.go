@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/twinj/uuid"
+)
+
+// Default shelves, one for each interest status
+const (
+	StatusPending = "pending"
+	StatusReading = "reading"
+	StatusDone    = "done"
+)
+
+// DefaultStatuses in the order their shelves are listed
+var DefaultStatuses = []string{StatusReading, StatusPending, StatusDone}
+
+// Shelf is a named and ordered list of books of a user. Default shelves
+// hold the books of an interest status and can't be renamed or deleted.
+type Shelf struct {
+	ID        string
+	UserID    string
+	Name      string
+	Public    bool
+	Status    string
+	Books     []*ShelfBook
+	CreatedOn time.Time
+	UpdatedOn time.Time
+}
+
+// ShelfBook is a book placed in a shelf
+type ShelfBook struct {
+	BookID  string
+	AddedOn time.Time
+}
+
+// NewShelf ...
+func NewShelf(id, userID, name string, public bool) (*Shelf, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("shelf name is required")
+	}
+	now := time.Now().UTC()
+	return &Shelf{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		Public:    public,
+		Books:     []*ShelfBook{},
+		CreatedOn: now,
+		UpdatedOn: now,
+	}, nil
+}
+
+// NewDefaultShelf creates the public shelf of an interest status
+func NewDefaultShelf(userID, status string) *Shelf {
+	shelf, _ := NewShelf(uuid.NewV4().String(), userID, status, true)
+	shelf.Status = status
+	return shelf
+}
+
+// IsDefault ...
+func (s *Shelf) IsDefault() bool {
+	return s.Status != ""
+}
+
+// Rename ...
+func (s *Shelf) Rename(name string) error {
+	if s.IsDefault() {
+		return errors.New("default shelves can't be renamed")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("shelf name is required")
+	}
+	s.Name = name
+	s.UpdatedOn = time.Now().UTC()
+	return nil
+}
+
+// SetPublic ...
+func (s *Shelf) SetPublic(public bool) {
+	s.Public = public
+	s.UpdatedOn = time.Now().UTC()
+}
+
+// Position of the book in the shelf, -1 if it isn't in it
+func (s *Shelf) Position(bookID string) int {
+	for i, book := range s.Books {
+		if book.BookID == bookID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add places the book at position, moving it when it is already in the
+// shelf. Positions out of range append the book at the end.
+func (s *Shelf) Add(bookID string, position int) {
+	book := &ShelfBook{BookID: bookID, AddedOn: time.Now().UTC()}
+	if current := s.Position(bookID); current >= 0 {
+		book = s.Books[current]
+		s.Books = append(s.Books[:current], s.Books[current+1:]...)
+	}
+	if position < 0 || position > len(s.Books) {
+		position = len(s.Books)
+	}
+	s.Books = append(s.Books, nil)
+	copy(s.Books[position+1:], s.Books[position:])
+	s.Books[position] = book
+	s.UpdatedOn = time.Now().UTC()
+}
+
+// Remove takes the book out of the shelf
+func (s *Shelf) Remove(bookID string) error {
+	position := s.Position(bookID)
+	if position < 0 {
+		return errors.New("book not in shelf")
+	}
+	s.Books = append(s.Books[:position], s.Books[position+1:]...)
+	s.UpdatedOn = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,11 @@
+package domain
+
+// ShelfRepository ...
+type ShelfRepository interface {
+	FindByID(string) (*Shelf, error)
+	FindByUserID(string) ([]*Shelf, error)
+	Save(*Shelf) error
+	Delete(string) error
+	DeleteByUserID(string) error
+	RemoveBook(string) error
+}
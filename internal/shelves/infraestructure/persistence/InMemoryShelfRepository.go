@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"errors"
+	"sort"
+
+	"something/internal/shelves/domain"
+)
+
+type repository struct {
+	shelves map[string]*domain.Shelf
+}
+
+var (
+	shelfInstance *repository
+)
+
+// NewInMemoryShelfRepository ...
+func NewInMemoryShelfRepository() domain.ShelfRepository {
+	shelfInstance = &repository{
+		shelves: make(map[string]*domain.Shelf),
+	}
+	return shelfInstance
+}
+
+func (r *repository) FindByID(id string) (*domain.Shelf, error) {
+	shelf, ok := r.shelves[id]
+	if !ok {
+		return nil, errors.New("shelf not found")
+	}
+	return shelf, nil
+}
+
+func (r *repository) FindByUserID(userID string) ([]*domain.Shelf, error) {
+	shelves := []*domain.Shelf{}
+	for _, shelf := range r.shelves {
+		if shelf.UserID == userID {
+			shelves = append(shelves, shelf)
+		}
+	}
+	sort.Slice(shelves, func(i, j int) bool {
+		return shelves[i].CreatedOn.Before(shelves[j].CreatedOn)
+	})
+	return shelves, nil
+}
+
+func (r *repository) Save(shelf *domain.Shelf) error {
+	// Same rule as the unique (userid, status) index of the mongo repository
+	for _, existing := range r.shelves {
+		if shelf.IsDefault() && existing.ID != shelf.ID &&
+			existing.UserID == shelf.UserID && existing.Status == shelf.Status {
+			return errors.New("default shelf already exists")
+		}
+	}
+	r.shelves[shelf.ID] = shelf
+	return nil
+}
+
+func (r *repository) Delete(id string) error {
+	if _, ok := r.shelves[id]; !ok {
+		return errors.New("shelf not found")
+	}
+	delete(r.shelves, id)
+	return nil
+}
+
+func (r *repository) DeleteByUserID(userID string) error {
+	for id, shelf := range r.shelves {
+		if shelf.UserID == userID {
+			delete(r.shelves, id)
+		}
+	}
+	return nil
+}
+
+func (r *repository) RemoveBook(bookID string) error {
+	for _, shelf := range r.shelves {
+		shelf.Remove(bookID)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"something/internal/shelves/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRepository struct {
+	con *mongo.Collection
+}
+
+// NewMongoShelfRepository ...
+func NewMongoShelfRepository(m *mongo.Database) domain.ShelfRepository {
+	con := m.Collection("shelves")
+	_, err := con.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{primitive.E{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{primitive.E{Key: "userid", Value: 1}, primitive.E{Key: "createdon", Value: 1}}},
+		{Keys: bson.D{primitive.E{Key: "books.bookid", Value: 1}}},
+		{
+			// One default shelf per status, custom shelves have no status
+			Keys: bson.D{primitive.E{Key: "userid", Value: 1}, primitive.E{Key: "status", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(
+				bson.M{"status": bson.M{"$gt": ""}}),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoRepository{con: con}
+}
+
+func (r *mongoRepository) FindByID(id string) (*domain.Shelf, error) {
+	var result *domain.Shelf
+	err := r.con.FindOne(
+		context.TODO(),
+		bson.D{primitive.E{Key: "id", Value: id}},
+		options.FindOne()).Decode(&result)
+	if result == nil {
+		log.Println(err)
+		return nil, errors.New("shelf not found")
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) FindByUserID(userID string) ([]*domain.Shelf, error) {
+	shelves := []*domain.Shelf{}
+	cur, err := r.con.Find(
+		context.TODO(),
+		bson.D{primitive.E{Key: "userid", Value: userID}},
+		options.Find().SetSort(bson.D{primitive.E{Key: "createdon", Value: 1}}))
+	if err != nil {
+		log.Println(err)
+		return shelves, err
+	}
+	if err = cur.All(context.TODO(), &shelves); err != nil {
+		log.Println(err)
+		return shelves, err
+	}
+	return shelves, nil
+}
+
+func (r *mongoRepository) Save(shelf *domain.Shelf) error {
+	_, err := r.con.ReplaceOne(
+		context.TODO(),
+		bson.D{primitive.E{Key: "id", Value: shelf.ID}},
+		shelf,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Println(err)
+		if isDuplicateKey(err) {
+			return errors.New("default shelf already exists")
+		}
+		return err
+	}
+	return nil
+}
+
+// isDuplicateKey reports whether the write broke a unique index, which for
+// shelves means a second default shelf of the same status
+func isDuplicateKey(err error) bool {
+	writeException, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+	for _, writeError := range writeException.WriteErrors {
+		if writeError.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *mongoRepository) Delete(id string) error {
+	result, err := r.con.DeleteOne(context.TODO(), bson.D{primitive.E{Key: "id", Value: id}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("shelf not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) DeleteByUserID(userID string) error {
+	_, err := r.con.DeleteMany(context.TODO(), bson.D{primitive.E{Key: "userid", Value: userID}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) RemoveBook(bookID string) error {
+	_, err := r.con.UpdateMany(
+		context.TODO(),
+		bson.D{primitive.E{Key: "books.bookid", Value: bookID}},
+		bson.M{"$pull": bson.M{"books": bson.M{"bookid": bookID}}})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
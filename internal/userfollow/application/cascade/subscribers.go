@@ -0,0 +1,14 @@
+package cascade
+
+import (
+	"something/internal/userfollow/domain"
+	userDomain "something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe removes the follows from and to deleted users
+func Subscribe(bus eventbus.Bus, repository domain.UserFollowRepository) {
+	bus.Subscribe(userDomain.UserDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteByUser(event.(*userDomain.UserDeleted).UserID)
+	})
+}
@@ -2,6 +2,7 @@ package followers
 
 import (
 	"something/internal/userfollow/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -12,11 +13,12 @@ type Service interface {
 
 type service struct {
 	repository domain.UserFollowRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repo domain.UserFollowRepository) Service {
-	return &service{repository: repo}
+func NewService(repo domain.UserFollowRepository, bus eventbus.Bus) Service {
+	return &service{repository: repo, bus: bus}
 }
 
 func (s *service) Follow(from, to string) error {
@@ -25,6 +27,7 @@ func (s *service) Follow(from, to string) error {
 	if err != nil {
 		return err
 	}
+	s.bus.Publish(domain.NewUserFollowed(userFollow))
 	return nil
 }
 
@@ -34,5 +37,6 @@ func (s *service) Unfollow(from, to string) error {
 	if err != nil {
 		return err
 	}
+	s.bus.Publish(domain.NewUserUnfollowed(userFollow))
 	return nil
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "something/pkg/eventbus"
+
+// User follow event names
+const (
+	UserFollowedEvent   = "user.followed"
+	UserUnfollowedEvent = "user.unfollowed"
+)
+
+// UserFollowed ...
+type UserFollowed struct {
+	eventbus.BaseEvent
+	From string
+	To   string
+}
+
+// NewUserFollowed ...
+func NewUserFollowed(u *UserFollow) *UserFollowed {
+	return &UserFollowed{BaseEvent: eventbus.NewBaseEvent(), From: u.From, To: u.To}
+}
+
+// Name ...
+func (e *UserFollowed) Name() string { return UserFollowedEvent }
+
+// UserUnfollowed ...
+type UserUnfollowed struct {
+	eventbus.BaseEvent
+	From string
+	To   string
+}
+
+// NewUserUnfollowed ...
+func NewUserUnfollowed(u *UserFollow) *UserUnfollowed {
+	return &UserUnfollowed{BaseEvent: eventbus.NewBaseEvent(), From: u.From, To: u.To}
+}
+
+// Name ...
+func (e *UserUnfollowed) Name() string { return UserUnfollowedEvent }
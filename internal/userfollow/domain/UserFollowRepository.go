@@ -6,4 +6,5 @@ type UserFollowRepository interface {
 	FindFollowers(string) ([]*UserFollow, error)
 	Follow(*UserFollow) error
 	Unfollow(*UserFollow) error
+	DeleteByUser(string) error
 }
@@ -67,3 +67,15 @@ func (r *repository) Unfollow(u *domain.UserFollow) error {
 	r.followers = r.followers[:len(r.followers)-1]
 	return nil
 }
+
+func (r *repository) DeleteByUser(id string) error {
+	var followers []*domain.UserFollow
+	for _, follow := range r.followers {
+		if follow == nil || follow.From == id || follow.To == id {
+			continue
+		}
+		followers = append(followers, follow)
+	}
+	r.followers = followers
+	return nil
+}
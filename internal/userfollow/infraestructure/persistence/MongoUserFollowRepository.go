@@ -74,3 +74,19 @@ func (r *mongoRepository) Unfollow(u *domain.UserFollow) error {
 	}
 	return nil
 }
+
+func (r *mongoRepository) DeleteByUser(id string) error {
+	_, err := r.con.DeleteMany(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "$or", Value: []interface{}{
+				bson.D{primitive.E{Key: "from", Value: id}},
+				bson.D{primitive.E{Key: "to", Value: id}},
+			}},
+		})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ type UserResponse struct {
 	Username  string            `json:"username"`
 	Email     string            `json:"email"`
 	Role      string            `json:"role"`
+	Verified  bool              `json:"verified"`
 	Interests map[string]string `json:"interests"`
 	CreatedOn time.Time         `json:"created_on"`
 }
@@ -24,6 +25,7 @@ func NewUserResponse(User *domain.User) *UserResponse {
 		Username:  User.Username,
 		Email:     User.Email,
 		Role:      User.Role,
+		Verified:  User.Verified,
 		Interests: User.Interests,
 		CreatedOn: User.CreatedOn,
 	}
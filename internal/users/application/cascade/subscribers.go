@@ -0,0 +1,14 @@
+package cascade
+
+import (
+	bookDomain "something/internal/books/domain"
+	"something/internal/users/domain"
+	"something/pkg/eventbus"
+)
+
+// Subscribe removes deleted books from the users interests
+func Subscribe(bus eventbus.Bus, repository domain.UserRepository) {
+	bus.Subscribe(bookDomain.BookDeletedEvent, func(event eventbus.Event) error {
+		return repository.DeleteBookInterests(event.(*bookDomain.BookDeleted).BookID)
+	})
+}
@@ -4,8 +4,19 @@ import (
 	"errors"
 	"something/internal/users/domain"
 	"something/pkg/crypto"
+	"something/pkg/eventbus"
+	"something/pkg/lockout"
+	"time"
 )
 
+// RegistrationPolicy limits the registrations coming from the same IP
+var RegistrationPolicy = lockout.Policy{
+	MaxAttempts: 10,
+	BaseLockout: time.Minute,
+	MaxLockout:  time.Hour * 6,
+	Window:      time.Hour,
+}
+
 // Service ...
 type Service interface {
 	CreateUser(*UserCommand) error
@@ -14,11 +25,12 @@ type Service interface {
 type service struct {
 	repository domain.UserRepository
 	cryptoRepo crypto.Crypto
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.UserRepository, cryptoInstance crypto.Crypto) Service {
-	return &service{repository: repository, cryptoRepo: cryptoInstance}
+func NewService(repository domain.UserRepository, cryptoInstance crypto.Crypto, bus eventbus.Bus) Service {
+	return &service{repository: repository, cryptoRepo: cryptoInstance, bus: bus}
 }
 
 func (s *service) CreateUser(command *UserCommand) error {
@@ -41,6 +53,7 @@ func (s *service) CreateUser(command *UserCommand) error {
 	if err != nil {
 		return err
 	}
+	s.bus.Publish(domain.NewUserRegistered(user))
 	return nil
 }
 
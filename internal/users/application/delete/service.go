@@ -3,6 +3,7 @@ package delete
 import (
 	"errors"
 	"something/internal/users/domain"
+	"something/pkg/eventbus"
 )
 
 // Service ...
@@ -13,23 +14,32 @@ type Service interface {
 
 type service struct {
 	repository domain.UserRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.UserRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.UserRepository, bus eventbus.Bus) Service {
+	return &service{repository: repository, bus: bus}
 }
 
 func (s *service) DeleteUserByID(id string) error {
-	review, _ := s.repository.FindByID(id)
-	if review == nil {
+	user, _ := s.repository.FindByID(id)
+	if user == nil {
 		return errors.New("user not found")
 	}
 	err := s.repository.Delete(id)
-	return err
+	if err != nil {
+		return err
+	}
+	s.bus.Publish(domain.NewUserDeleted(user))
+	return nil
 }
 
 func (s *service) DeleteUserInterests(userID, bookID string) error {
 	err := s.repository.DeleteInterest(userID, bookID)
-	return err
+	if err != nil {
+		return err
+	}
+	s.bus.Publish(domain.NewInterestRemoved(userID, bookID))
+	return nil
 }
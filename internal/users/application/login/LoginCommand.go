@@ -9,6 +9,7 @@ import (
 type Command struct {
 	Email    string `json:"email,omitempty"`
 	Password string `json:"password,omitempty"`
+	IP       string `json:"-"`
 }
 
 // Validate ...
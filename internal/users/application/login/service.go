@@ -5,8 +5,38 @@ import (
 	"something/internal/users/application"
 	"something/internal/users/domain"
 	"something/pkg/crypto"
+	"something/pkg/lockout"
+	"strings"
+	"sync"
+	"time"
 )
 
+// AccountPolicy locks out the accounts receiving too many wrong passwords
+var AccountPolicy = lockout.Policy{
+	MaxAttempts: 5,
+	BaseLockout: time.Second * 30,
+	MaxLockout:  time.Hour,
+	Window:      time.Hour * 24,
+}
+
+// IPPolicy locks out the IPs trying too many passwords, whatever the account
+var IPPolicy = lockout.Policy{
+	MaxAttempts: 20,
+	BaseLockout: time.Minute,
+	MaxLockout:  time.Hour * 6,
+	Window:      time.Hour * 24,
+}
+
+// LockedError is returned while the account or the IP of a login is locked
+// out because of the previous failures
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return "too many attempts, try again later"
+}
+
 // Service ...
 type Service interface {
 	Login(*Command) (*application.UserResponse, error)
@@ -15,21 +45,107 @@ type Service interface {
 type service struct {
 	repository domain.UserRepository
 	cryptoRepo crypto.Crypto
+	accounts   lockout.Limiter
+	ips        lockout.Limiter
+
+	dummyOnce sync.Once
+	dummyHash string
 }
 
 // NewService ...
-func NewService(repository domain.UserRepository, cryptoInstance crypto.Crypto) Service {
-	return &service{repository: repository, cryptoRepo: cryptoInstance}
+func NewService(
+	repository domain.UserRepository,
+	cryptoInstance crypto.Crypto,
+	accounts lockout.Limiter,
+	ips lockout.Limiter) Service {
+	return &service{
+		repository: repository,
+		cryptoRepo: cryptoInstance,
+		accounts:   accounts,
+		ips:        ips,
+	}
 }
 
+// Login checks the credentials. Unknown emails and wrong passwords get the
+// same error, take the same time and count as failures alike, so logins
+// can't be used to find out which emails are registered.
 func (s *service) Login(c *Command) (*application.UserResponse, error) {
-	user, err := s.repository.FindByEmail(c.Email)
-	if err != nil {
+	email := strings.TrimSpace(strings.ToLower(c.Email))
+	if err := s.check(email, c.IP); err != nil {
 		return nil, err
 	}
-	valid := s.cryptoRepo.CompareHashAndText(c.Password, user.Password)
-	if !valid {
-		return nil, errors.New("invalid email or password")
+
+	user, err := s.repository.FindByEmail(email)
+	if err != nil && err.Error() != "email not found" {
+		return nil, err
+	}
+	if user == nil {
+		s.cryptoRepo.CompareHashAndText(c.Password, s.dummy())
+		return nil, s.fail(email, c.IP)
+	}
+	if !s.cryptoRepo.CompareHashAndText(c.Password, user.Password) {
+		return nil, s.fail(email, c.IP)
+	}
+
+	if err := s.succeed(email, c.IP); err != nil {
+		return nil, err
 	}
 	return application.NewUserResponse(user), nil
 }
+
+// check returns a LockedError while the account or the IP is locked out
+func (s *service) check(email, ip string) error {
+	accountLockout, err := s.accounts.Check(email)
+	if err != nil {
+		return err
+	}
+	ipLockout, err := s.ips.Check(ip)
+	if err != nil {
+		return err
+	}
+	if retryAfter := maxDuration(accountLockout, ipLockout); retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// fail records the failed login, it returns a LockedError when the failure
+// locks out the account or the IP
+func (s *service) fail(email, ip string) error {
+	accountLockout, err := s.accounts.Fail(email)
+	if err != nil {
+		return err
+	}
+	ipLockout, err := s.ips.Fail(ip)
+	if err != nil {
+		return err
+	}
+	if retryAfter := maxDuration(accountLockout, ipLockout); retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return errors.New("invalid email or password")
+}
+
+// succeed forgets the failures of the account and the IP, users behind a
+// shared IP aren't locked out by the typos of the others
+func (s *service) succeed(email, ip string) error {
+	if err := s.accounts.Succeed(email); err != nil {
+		return err
+	}
+	return s.ips.Succeed(ip)
+}
+
+// dummy returns a hash to compare the passwords of unknown emails with
+func (s *service) dummy() string {
+	s.dummyOnce.Do(func() {
+		s.dummyHash, _ = s.cryptoRepo.Hash("dummy-password-for-unknown-emails")
+	})
+	return s.dummyHash
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+package password
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+// ChangeCommand ...
+type ChangeCommand struct {
+	UserID          string `json:"-"`
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// Validate ...
+func (c ChangeCommand) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CurrentPassword, validation.Required),
+		validation.Field(&c.NewPassword,
+			validation.Required,
+			validation.Length(8, 64),
+			validation.NotIn(c.CurrentPassword).Error("must be different from the current password"),
+		),
+	)
+}
+
+// ForgotCommand ...
+type ForgotCommand struct {
+	Email string `json:"email"`
+}
+
+// Validate ...
+func (c ForgotCommand) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Email, validation.Required, is.Email),
+	)
+}
+
+// ResetCommand ...
+type ResetCommand struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// Validate ...
+func (c ResetCommand) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Token, validation.Required),
+		validation.Field(&c.NewPassword, validation.Required, validation.Length(8, 64)),
+	)
+}
@@ -0,0 +1,170 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"something/internal/users/application"
+	"something/internal/users/domain"
+	"something/pkg/crypto"
+	"something/pkg/mail"
+	"something/pkg/session"
+	"strings"
+	"time"
+)
+
+// ResetParams configures the reset tokens, URL is the page of the client
+// where the token is used (the token is appended as a query parameter),
+// when empty the email carries the bare token
+type ResetParams struct {
+	TokenTTL time.Duration
+	URL      string
+}
+
+// DefaultResetParams ...
+var DefaultResetParams = ResetParams{TokenTTL: time.Hour}
+
+// Service ...
+type Service interface {
+	Change(*ChangeCommand) (*application.UserResponse, error)
+	Forgot(*ForgotCommand) error
+	Reset(*ResetCommand) (*application.UserResponse, error)
+}
+
+type service struct {
+	repository domain.UserRepository
+	cryptoRepo crypto.Crypto
+	store      session.Store
+	sender     mail.Sender
+	params     ResetParams
+}
+
+// NewService ...
+func NewService(
+	repository domain.UserRepository,
+	cryptoInstance crypto.Crypto,
+	store session.Store,
+	sender mail.Sender,
+	params ResetParams) Service {
+	return &service{
+		repository: repository,
+		cryptoRepo: cryptoInstance,
+		store:      store,
+		sender:     sender,
+		params:     params,
+	}
+}
+
+// Change replaces the password of the user after checking the current one
+func (s *service) Change(c *ChangeCommand) (*application.UserResponse, error) {
+	user, err := s.repository.FindByID(c.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.cryptoRepo.CompareHashAndText(c.CurrentPassword, user.Password) {
+		return nil, errors.New("invalid current password")
+	}
+	if err := s.updatePassword(user.ID, c.NewPassword); err != nil {
+		return nil, err
+	}
+	return application.NewUserResponse(user), nil
+}
+
+// Forgot mails a reset token to the owner of the email. Unknown emails are
+// ignored so the response doesn't tell which emails are registered. Only
+// the last token sent to a user is valid.
+func (s *service) Forgot(c *ForgotCommand) error {
+	user, err := s.repository.FindByEmail(strings.TrimSpace(strings.ToLower(c.Email)))
+	if err != nil {
+		if err.Error() == "email not found" {
+			return nil
+		}
+		return err
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+	if previous, err := s.store.Get(userResetKey(user.ID)); err == nil {
+		s.store.Delete(previous)
+	}
+	key := resetKey(token)
+	if err := s.store.Set(key, user.ID, s.params.TokenTTL); err != nil {
+		return err
+	}
+	if err := s.store.Set(userResetKey(user.ID), key, s.params.TokenTTL); err != nil {
+		return err
+	}
+
+	link := token
+	if s.params.URL != "" {
+		link = s.params.URL + "?token=" + token
+	}
+	return s.sender.Send(&mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse this to choose a new password, it expires in %s:\n\n%s\n\n"+
+				"If you didn't ask for it you can ignore this email.",
+			user.Name, s.params.TokenTTL, link),
+	})
+}
+
+// Reset sets the new password of the user owning the reset token, the
+// token can't be used again
+func (s *service) Reset(c *ResetCommand) (*application.UserResponse, error) {
+	key := resetKey(c.Token)
+	userID, err := s.store.Get(key)
+	if err != nil {
+		if err == session.ErrNotFound {
+			return nil, errors.New("invalid or expired token")
+		}
+		return nil, err
+	}
+	deleted, err := s.store.Delete(key)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		// Used by a concurrent request
+		return nil, errors.New("invalid or expired token")
+	}
+	s.store.Delete(userResetKey(userID))
+	user, err := s.repository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updatePassword(user.ID, c.NewPassword); err != nil {
+		return nil, err
+	}
+	return application.NewUserResponse(user), nil
+}
+
+func (s *service) updatePassword(userID, password string) error {
+	hash, err := s.cryptoRepo.Hash(password)
+	if err != nil {
+		return err
+	}
+	return s.repository.UpdatePassword(userID, hash)
+}
+
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// resetKey is the store key of a reset token, only its hash is saved
+func resetKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "password-reset++" + hex.EncodeToString(sum[:])
+}
+
+func userResetKey(userID string) string {
+	return "password-reset-user++" + userID
+}
@@ -0,0 +1,25 @@
+package roles
+
+import (
+	"something/internal/users/domain"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// RoleCommand ...
+type RoleCommand struct {
+	UserID  string `json:"-"`
+	AdminID string `json:"-"`
+	Role    string `json:"role"`
+}
+
+// Validate ...
+func (r RoleCommand) Validate() error {
+	roles := []interface{}{}
+	for _, role := range domain.Roles() {
+		roles = append(roles, role)
+	}
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Role, validation.Required, validation.In(roles...)),
+	)
+}
@@ -0,0 +1,36 @@
+package roles
+
+import (
+	"errors"
+	"something/internal/users/domain"
+)
+
+// Service ...
+type Service interface {
+	AssignRole(*RoleCommand) error
+}
+
+type service struct {
+	repository domain.UserRepository
+}
+
+// NewService ...
+func NewService(repository domain.UserRepository) Service {
+	return &service{repository: repository}
+}
+
+// AssignRole changes the role of a user, tokens issued from then on carry
+// the new role. Admins can't change their own role so there is always one
+// left to undo mistakes.
+func (s *service) AssignRole(command *RoleCommand) error {
+	if domain.RolePermissions(command.Role) == nil {
+		return errors.New("role not found")
+	}
+	if command.UserID == command.AdminID {
+		return errors.New("can't change your own role")
+	}
+	if _, err := s.repository.FindByID(command.UserID); err != nil {
+		return err
+	}
+	return s.repository.UpdateRole(command.UserID, command.Role)
+}
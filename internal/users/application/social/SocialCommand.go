@@ -0,0 +1,26 @@
+package social
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// CallbackCommand is the redirection of the provider back to the API,
+// Error is set instead of Code when the user didn't authorize the login
+type CallbackCommand struct {
+	Provider string `json:"-"`
+	State    string `form:"state"`
+	Code     string `form:"code"`
+	Error    string `form:"error"`
+}
+
+// Validate ...
+func (c CallbackCommand) Validate() error {
+	codeRules := []validation.Rule{}
+	if c.Error == "" {
+		codeRules = append(codeRules, validation.Required)
+	}
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.State, validation.Required),
+		validation.Field(&c.Code, codeRules...),
+	)
+}
@@ -0,0 +1,266 @@
+package social
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"something/internal/users/application"
+	"something/internal/users/domain"
+	"something/pkg/eventbus"
+	"something/pkg/oidc"
+	"something/pkg/session"
+	"strings"
+	"time"
+
+	"github.com/twinj/uuid"
+)
+
+// StateTTL is the time the user has to log in the provider
+const StateTTL = time.Minute * 10
+
+// maxUsernameLength keeps room for the suffix making usernames unique
+const maxUsernameLength = 40
+
+// Service logs users in with OpenID Connect providers
+type Service interface {
+	// Begin returns the URL of the provider login page
+	Begin(provider string) (string, error)
+	// Callback finishes the login, the user is created on the first one
+	Callback(*CallbackCommand) (*application.UserResponse, error)
+}
+
+type service struct {
+	repository domain.UserRepository
+	store      session.Store
+	bus        eventbus.Bus
+	providers  map[string]oidc.Provider
+}
+
+// login is kept in the store between Begin and Callback, under the state
+type login struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+// NewService ...
+func NewService(
+	repository domain.UserRepository,
+	store session.Store,
+	bus eventbus.Bus,
+	providers ...oidc.Provider) Service {
+	s := &service{
+		repository: repository,
+		store:      store,
+		bus:        bus,
+		providers:  map[string]oidc.Provider{},
+	}
+	for _, provider := range providers {
+		s.providers[provider.Name()] = provider
+	}
+	return s
+}
+
+func (s *service) Begin(name string) (string, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return "", errors.New("provider not found")
+	}
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return "", err
+	}
+	value, err := json.Marshal(&login{Provider: name, Verifier: verifier, Nonce: nonce})
+	if err != nil {
+		return "", err
+	}
+	if err := s.store.Set(stateKey(state), string(value), StateTTL); err != nil {
+		return "", err
+	}
+	return provider.AuthCodeURL(state, nonce, oidc.Challenge(verifier))
+}
+
+func (s *service) Callback(c *CallbackCommand) (*application.UserResponse, error) {
+	provider, ok := s.providers[c.Provider]
+	if !ok {
+		return nil, errors.New("provider not found")
+	}
+	l, err := s.consume(c.State)
+	if err != nil {
+		return nil, err
+	}
+	if l.Provider != c.Provider {
+		return nil, errors.New("invalid or expired state")
+	}
+	if c.Error != "" {
+		return nil, errors.New("login denied by the provider")
+	}
+	claims, err := provider.Exchange(c.Code, l.Verifier, l.Nonce)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("identity could not be verified")
+	}
+
+	identity := domain.Identity{Provider: c.Provider, Subject: claims.Subject}
+	user, err := s.repository.FindByIdentity(identity.Provider, identity.Subject)
+	if err == nil {
+		return application.NewUserResponse(user), nil
+	}
+	if err.Error() != "identity not found" {
+		return nil, err
+	}
+	if claims.Email == "" {
+		return nil, errors.New("the provider didn't share the email")
+	}
+	user, err = s.link(identity, claims)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewUserResponse(user), nil
+}
+
+// consume returns the login started with the state, a state is only used
+// once
+func (s *service) consume(state string) (*login, error) {
+	invalid := errors.New("invalid or expired state")
+	value, err := s.store.Get(stateKey(state))
+	if err == session.ErrNotFound {
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.store.Delete(stateKey(state))
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, invalid
+	}
+	l := &login{}
+	if err := json.Unmarshal([]byte(value), l); err != nil {
+		return nil, invalid
+	}
+	return l, nil
+}
+
+// link adds the identity to the user owning the email, or creates the
+// user. Existing accounts are only linked when both the provider and the
+// account verified the email, otherwise whoever registered the email
+// first could take over the other account.
+func (s *service) link(identity domain.Identity, claims *oidc.Claims) (*domain.User, error) {
+	email := strings.TrimSpace(strings.ToLower(claims.Email))
+	user, err := s.repository.FindByEmail(email)
+	if err == nil {
+		if !claims.EmailVerified || !user.Verified {
+			return nil, errors.New("email already in use")
+		}
+		if err := s.repository.AddIdentity(user.ID, identity); err != nil {
+			return nil, err
+		}
+		user.Identities = append(user.Identities, identity)
+		return user, nil
+	}
+	if err.Error() != "email not found" {
+		return nil, err
+	}
+
+	username, err := s.username(claims)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = username
+	}
+	// Without password the user logs in through the provider, or sets one
+	// with the password reset
+	user, err = domain.NewUser(uuid.NewV4().String(), name, username, email, "")
+	if err != nil {
+		return nil, err
+	}
+	user.Verified = claims.EmailVerified
+	user.Identities = []domain.Identity{identity}
+	if err := s.repository.Save(user); err != nil {
+		return nil, err
+	}
+	s.bus.Publish(domain.NewUserRegistered(user))
+	return user, nil
+}
+
+// username returns a free username made from the preferred username, the
+// email or the name of the user, numbered when it is already in use
+func (s *service) username(claims *oidc.Claims) (string, error) {
+	base := ""
+	for _, candidate := range []string{
+		claims.PreferredUsername,
+		strings.SplitN(claims.Email, "@", 2)[0],
+		claims.Name,
+	} {
+		if base = sanitizeUsername(candidate); base != "" {
+			break
+		}
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	username := base
+	for i := 2; i <= 100; i++ {
+		_, err := s.repository.FindByUsername(username)
+		if err != nil {
+			if err.Error() == "username not found" {
+				return username, nil
+			}
+			return "", err
+		}
+		username = fmt.Sprintf("%s%d", base, i)
+	}
+	// Too many users share the name, a random suffix makes it unique
+	suffix, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	return base + "-" + suffix[:8], nil
+}
+
+// sanitizeUsername keeps the lowercase letters, digits, dots, dashes and
+// underscores of the value
+func sanitizeUsername(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('.')
+		}
+		if b.Len() == maxUsernameLength {
+			break
+		}
+	}
+	return strings.Trim(b.String(), ".-_")
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func stateKey(state string) string {
+	return "oidc-state++" + state
+}
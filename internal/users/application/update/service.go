@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"something/internal/users/domain"
+	"something/pkg/eventbus"
 	"strings"
 )
 
@@ -15,11 +16,12 @@ type Service interface {
 
 type service struct {
 	repository domain.UserRepository
+	bus        eventbus.Bus
 }
 
 // NewService ...
-func NewService(repository domain.UserRepository) Service {
-	return &service{repository: repository}
+func NewService(repository domain.UserRepository, bus eventbus.Bus) Service {
+	return &service{repository: repository, bus: bus}
 }
 
 func (s *service) UpdateUserByID(user *UserCommand) error {
@@ -51,10 +53,22 @@ func (s *service) UpdateUserByID(user *UserCommand) error {
 }
 
 func (s *service) UpdateUserInterests(interestCommand *UserInterestsCommand) error {
+	previousStatus := ""
+	if user, _ := s.repository.FindByID(interestCommand.UserID); user != nil {
+		previousStatus = user.Interests[interestCommand.BookID]
+	}
 	err := s.repository.UpdateInterests(
 		interestCommand.UserID,
 		interestCommand.BookID,
 		interestCommand.Status,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if previousStatus == interestCommand.Status {
+		return nil
+	}
+	s.bus.Publish(domain.NewInterestChanged(
+		interestCommand.UserID, interestCommand.BookID, interestCommand.Status, previousStatus))
+	return nil
 }
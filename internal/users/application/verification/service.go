@@ -0,0 +1,169 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+	"something/internal/users/application"
+	"something/internal/users/domain"
+	"something/pkg/eventbus"
+	"something/pkg/mail"
+	"something/pkg/session"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/twinj/uuid"
+)
+
+// Params configures the verification tokens. Tokens are signed with
+// Secret, URL is the endpoint confirming them (the token is appended as
+// a query parameter), when empty the email carries the bare token.
+type Params struct {
+	Secret   string
+	TokenTTL time.Duration
+	URL      string
+}
+
+// DefaultTokenTTL ...
+const DefaultTokenTTL = time.Hour * 24
+
+// Service ...
+type Service interface {
+	Send(userID string) error
+	Verify(token string) (*application.UserResponse, error)
+}
+
+type service struct {
+	repository domain.UserRepository
+	store      session.Store
+	sender     mail.Sender
+	params     Params
+}
+
+// NewService ...
+func NewService(
+	repository domain.UserRepository,
+	store session.Store,
+	sender mail.Sender,
+	params Params) Service {
+	return &service{
+		repository: repository,
+		store:      store,
+		sender:     sender,
+		params:     params,
+	}
+}
+
+// Subscribe sends the verification email to the registered users, the
+// users registered through a provider verifying the email don't get it
+func Subscribe(bus eventbus.Bus, s Service) {
+	bus.SubscribeAsync(domain.UserRegisteredEvent, func(event eventbus.Event) error {
+		err := s.Send(event.(*domain.UserRegistered).UserID)
+		if err != nil && err.Error() == "user already verified" {
+			return nil
+		}
+		return err
+	})
+}
+
+// Send mails a verification token to the user, only the last token sent
+// is valid
+func (s *service) Send(userID string) error {
+	user, err := s.repository.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Verified {
+		return errors.New("user already verified")
+	}
+
+	verifyUUID := uuid.NewV4().String()
+	claims := jwt.MapClaims{}
+	claims["verify_uuid"] = verifyUUID
+	claims["user_id"] = user.ID
+	claims["email"] = user.Email
+	claims["exp"] = time.Now().Add(s.params.TokenTTL).Unix()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.params.Secret))
+	if err != nil {
+		return err
+	}
+
+	if previous, err := s.store.Get(userVerifyKey(user.ID)); err == nil {
+		s.store.Delete(previous)
+	}
+	if err := s.store.Set(verifyKey(verifyUUID), user.ID, s.params.TokenTTL); err != nil {
+		return err
+	}
+	if err := s.store.Set(userVerifyKey(user.ID), verifyKey(verifyUUID), s.params.TokenTTL); err != nil {
+		return err
+	}
+
+	link := token
+	if s.params.URL != "" {
+		link = s.params.URL + "?token=" + token
+	}
+	return s.sender.Send(&mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nConfirm your email to start reviewing and following, "+
+				"it expires in %s:\n\n%s\n",
+			user.Name, s.params.TokenTTL, link),
+	})
+}
+
+// Verify marks as verified the user the token was sent to, the token
+// can't be used again
+func (s *service) Verify(token string) (*application.UserResponse, error) {
+	invalid := errors.New("invalid or expired token")
+
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return []byte(s.params.Secret), nil
+	})
+	if err != nil {
+		return nil, invalid
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return nil, invalid
+	}
+	verifyUUID, _ := claims["verify_uuid"].(string)
+	userID, _ := claims["user_id"].(string)
+	email, _ := claims["email"].(string)
+
+	deleted, err := s.store.Delete(verifyKey(verifyUUID))
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, invalid
+	}
+	s.store.Delete(userVerifyKey(userID))
+
+	user, err := s.repository.FindByID(userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	// The email changed after sending the token
+	if user.Email != email {
+		return nil, invalid
+	}
+	if err := s.repository.UpdateVerified(user.ID, true); err != nil {
+		return nil, err
+	}
+	user.Verified = true
+	return application.NewUserResponse(user), nil
+}
+
+func verifyKey(verifyUUID string) string {
+	return "verify++" + verifyUUID
+}
+
+func userVerifyKey(userID string) string {
+	return "verify-user++" + userID
+}
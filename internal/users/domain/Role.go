@@ -0,0 +1,49 @@
+package domain
+
+// Permissions checked by the API
+const (
+	PermissionBooksWrite      = "books:write"
+	PermissionReviewsModerate = "reviews:moderate"
+	PermissionUsersAdmin      = "users:admin"
+)
+
+// Roles, new users get RoleDefault
+const (
+	RoleDefault   = "default"
+	RoleModerator = "moderator"
+	RoleStaff     = "staff"
+	RoleAdmin     = "admin"
+)
+
+// rolePermissions are the permissions granted to every role
+var rolePermissions = map[string][]string{
+	RoleDefault:   {},
+	RoleModerator: {PermissionReviewsModerate},
+	RoleStaff:     {PermissionBooksWrite, PermissionReviewsModerate},
+	RoleAdmin:     {PermissionBooksWrite, PermissionReviewsModerate, PermissionUsersAdmin},
+}
+
+// Roles returns the name of every role
+func Roles() []string {
+	return []string{RoleDefault, RoleModerator, RoleStaff, RoleAdmin}
+}
+
+// RolePermissions returns the permissions of the role, none for unknown roles
+func RolePermissions(role string) []string {
+	return rolePermissions[role]
+}
+
+// HasPermission reports whether the role grants the permission
+func HasPermission(role, permission string) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the user role grants the permission
+func (u *User) Can(permission string) bool {
+	return HasPermission(u.Role, permission)
+}
@@ -7,14 +7,17 @@ import (
 
 // User ...
 type User struct {
-	ID        string
-	Name      string
-	Username  string
-	Email     string
-	Password  string
-	Role      string
-	Interests map[string]string
-	CreatedOn time.Time
+	ID       string
+	Name     string
+	Username string
+	Email    string
+	Password string
+	Role     string
+	Verified bool
+	// Identities are the accounts of external providers linked to the user
+	Identities []Identity
+	Interests  map[string]string
+	CreatedOn  time.Time
 }
 
 // NewUser ...
@@ -25,8 +28,15 @@ func NewUser(id, name, username, email, password string) (*User, error) {
 		Username:  strings.TrimSpace(strings.ToLower(username)),
 		Email:     strings.TrimSpace(strings.ToLower(email)),
 		Password:  password,
-		Role:      "default",
+		Role:      RoleDefault,
 		Interests: map[string]string{},
 		CreatedOn: time.Now().UTC(),
 	}, nil
 }
+
+// Identity is the account of a user in an OpenID Connect provider, Subject
+// is the provider user identifier
+type Identity struct {
+	Provider string
+	Subject  string
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "something/pkg/eventbus"
+
+// User event names
+const (
+	UserRegisteredEvent  = "user.registered"
+	UserDeletedEvent     = "user.deleted"
+	InterestChangedEvent = "user.interest_changed"
+	InterestRemovedEvent = "user.interest_removed"
+)
+
+// UserRegistered ...
+type UserRegistered struct {
+	eventbus.BaseEvent
+	UserID   string
+	Username string
+	Email    string
+}
+
+// NewUserRegistered ...
+func NewUserRegistered(user *User) *UserRegistered {
+	return &UserRegistered{
+		BaseEvent: eventbus.NewBaseEvent(),
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+	}
+}
+
+// Name ...
+func (e *UserRegistered) Name() string { return UserRegisteredEvent }
+
+// UserDeleted ...
+type UserDeleted struct {
+	eventbus.BaseEvent
+	UserID string
+}
+
+// NewUserDeleted ...
+func NewUserDeleted(user *User) *UserDeleted {
+	return &UserDeleted{BaseEvent: eventbus.NewBaseEvent(), UserID: user.ID}
+}
+
+// Name ...
+func (e *UserDeleted) Name() string { return UserDeletedEvent }
+
+// InterestChanged is published when a user sets the reading status of a book
+type InterestChanged struct {
+	eventbus.BaseEvent
+	UserID         string
+	BookID         string
+	Status         string
+	PreviousStatus string
+}
+
+// NewInterestChanged ...
+func NewInterestChanged(userID, bookID, status, previousStatus string) *InterestChanged {
+	return &InterestChanged{
+		BaseEvent:      eventbus.NewBaseEvent(),
+		UserID:         userID,
+		BookID:         bookID,
+		Status:         status,
+		PreviousStatus: previousStatus,
+	}
+}
+
+// Name ...
+func (e *InterestChanged) Name() string { return InterestChangedEvent }
+
+// InterestRemoved ...
+type InterestRemoved struct {
+	eventbus.BaseEvent
+	UserID string
+	BookID string
+}
+
+// NewInterestRemoved ...
+func NewInterestRemoved(userID, bookID string) *InterestRemoved {
+	return &InterestRemoved{BaseEvent: eventbus.NewBaseEvent(), UserID: userID, BookID: bookID}
+}
+
+// Name ...
+func (e *InterestRemoved) Name() string { return InterestRemovedEvent }
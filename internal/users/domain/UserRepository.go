@@ -6,9 +6,15 @@ type UserRepository interface {
 	FindByID(string) (*User, error)
 	FindByEmail(string) (*User, error)
 	FindByUsername(string) (*User, error)
+	FindByIdentity(provider, subject string) (*User, error)
 	Update(*User) error
 	UpdateInterests(string, string, string) error
+	UpdateRole(userID, role string) error
+	UpdatePassword(userID, password string) error
+	UpdateVerified(userID string, verified bool) error
+	AddIdentity(userID string, identity Identity) error
 	Save(*User) error
 	Delete(string) error
 	DeleteInterest(string, string) error
+	DeleteBookInterests(string) error
 }
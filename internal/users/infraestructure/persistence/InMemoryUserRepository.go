@@ -68,6 +68,17 @@ func (r *repository) FindByUsername(username string) (*domain.User, error) {
 	return user, nil
 }
 
+func (r *repository) FindByIdentity(provider, subject string) (*domain.User, error) {
+	for _, u := range r.users {
+		for _, identity := range u.Identities {
+			if identity.Provider == provider && identity.Subject == subject {
+				return u, nil
+			}
+		}
+	}
+	return nil, errors.New("identity not found")
+}
+
 func (r *repository) Update(user *domain.User) error {
 	r.users[user.ID] = user
 	return nil
@@ -78,6 +89,47 @@ func (r *repository) UpdateInterests(userID, bookID, status string) error {
 	return nil
 }
 
+func (r *repository) UpdateRole(userID, role string) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+	user.Role = role
+	return nil
+}
+
+func (r *repository) UpdatePassword(userID, password string) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+	user.Password = password
+	return nil
+}
+
+func (r *repository) UpdateVerified(userID string, verified bool) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+	user.Verified = verified
+	return nil
+}
+
+func (r *repository) AddIdentity(userID string, identity domain.Identity) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+	for _, existing := range user.Identities {
+		if existing == identity {
+			return nil
+		}
+	}
+	user.Identities = append(user.Identities, identity)
+	return nil
+}
+
 func (r *repository) Save(user *domain.User) error {
 	r.users[user.ID] = user
 	return nil
@@ -98,3 +150,10 @@ func (r *repository) DeleteInterest(userID, bookID string) error {
 	}
 	return nil
 }
+
+func (r *repository) DeleteBookInterests(bookID string) error {
+	for _, user := range r.users {
+		delete(user.Interests, bookID)
+	}
+	return nil
+}
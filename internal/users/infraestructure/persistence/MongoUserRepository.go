@@ -18,8 +18,18 @@ type mongoRepository struct {
 
 // NewMongoUsersRepository ...
 func NewMongoUsersRepository(m *mongo.Database) domain.UserRepository {
+	con := m.Collection("users")
+	// Accounts created before the email verification existed are verified
+	_, err := con.UpdateMany(
+		context.TODO(),
+		bson.M{"verified": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"verified": true}},
+	)
+	if err != nil {
+		log.Println(err)
+	}
 	return &mongoRepository{
-		con: m.Collection("users"),
+		con: con,
 	}
 }
 
@@ -106,6 +116,22 @@ func (r *mongoRepository) FindByUsername(username string) (*domain.User, error)
 	return user, nil
 }
 
+func (r *mongoRepository) FindByIdentity(provider, subject string) (*domain.User, error) {
+	var user *domain.User
+	err := r.con.FindOne(
+		context.TODO(),
+		bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}}},
+		options.FindOne()).Decode(&user)
+	if user == nil {
+		return nil, errors.New("identity not found")
+	}
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *mongoRepository) Update(user *domain.User) error {
 	_, err := r.con.UpdateOne(context.TODO(), bson.M{"id": user.ID}, bson.D{
 		{"$set", bson.D{
@@ -136,6 +162,62 @@ func (r *mongoRepository) UpdateInterests(userID, bookID, status string) error {
 	return nil
 }
 
+func (r *mongoRepository) UpdateRole(userID, role string) error {
+	result, err := r.con.UpdateOne(context.TODO(), bson.M{"id": userID}, bson.M{
+		"$set": bson.M{"role": role},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) UpdatePassword(userID, password string) error {
+	result, err := r.con.UpdateOne(context.TODO(), bson.M{"id": userID}, bson.M{
+		"$set": bson.M{"password": password},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) UpdateVerified(userID string, verified bool) error {
+	result, err := r.con.UpdateOne(context.TODO(), bson.M{"id": userID}, bson.M{
+		"$set": bson.M{"verified": verified},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (r *mongoRepository) AddIdentity(userID string, identity domain.Identity) error {
+	result, err := r.con.UpdateOne(context.TODO(), bson.M{"id": userID}, bson.M{
+		"$addToSet": bson.M{"identities": identity},
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 func (r *mongoRepository) Save(user *domain.User) error {
 	_, err := r.con.InsertOne(context.TODO(), user)
 	if err != nil {
@@ -166,3 +248,17 @@ func (r *mongoRepository) DeleteInterest(userID, bookID string) error {
 	}
 	return nil
 }
+
+func (r *mongoRepository) DeleteBookInterests(bookID string) error {
+	field := "interests." + bookID
+	_, err := r.con.UpdateMany(
+		context.TODO(),
+		bson.M{field: bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{field: ""}},
+	)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package eventbus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testEvent struct {
+	BaseEvent
+	name string
+}
+
+func (e *testEvent) Name() string { return e.name }
+
+func newTestEvent(name string) *testEvent {
+	return &testEvent{BaseEvent: NewBaseEvent(), name: name}
+}
+
+func TestEventBus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Event Bus Suite")
+}
+
+var _ = Describe("In memory bus", func() {
+	var bus Bus
+
+	BeforeEach(func() {
+		bus = NewInMemoryBus()
+	})
+
+	Context("When synchronous handlers are subscribed", func() {
+		It("runs them inside Publish in subscription order", func() {
+			calls := []string{}
+			bus.Subscribe("created", func(event Event) error {
+				calls = append(calls, "first "+event.Name())
+				return nil
+			})
+			bus.Subscribe("created", func(event Event) error {
+				calls = append(calls, "second "+event.Name())
+				return nil
+			})
+			bus.Subscribe("deleted", func(event Event) error {
+				calls = append(calls, "other "+event.Name())
+				return nil
+			})
+
+			bus.Publish(newTestEvent("created"))
+			Expect(calls).Should(Equal([]string{"first created", "second created"}))
+		})
+		It("keeps running the handlers after one fails", func() {
+			calls := 0
+			bus.Subscribe("created", func(event Event) error {
+				calls++
+				return errors.New("handler failed")
+			})
+			bus.Subscribe("created", func(event Event) error {
+				calls++
+				return nil
+			})
+
+			bus.Publish(newTestEvent("created"), newTestEvent("created"))
+			Expect(calls).Should(Equal(4))
+		})
+		It("ignores events without handlers", func() {
+			Expect(func() { bus.Publish(newTestEvent("unknown")) }).ShouldNot(Panic())
+		})
+	})
+
+	Context("When asynchronous handlers are subscribed", func() {
+		It("runs them in background until Wait", func() {
+			release := make(chan struct{})
+			var mu sync.Mutex
+			calls := 0
+			for i := 0; i < 3; i++ {
+				bus.SubscribeAsync("created", func(event Event) error {
+					<-release
+					mu.Lock()
+					defer mu.Unlock()
+					calls++
+					return nil
+				})
+			}
+
+			// Publish returns while the handlers are blocked
+			bus.Publish(newTestEvent("created"))
+			mu.Lock()
+			Expect(calls).Should(Equal(0))
+			mu.Unlock()
+
+			close(release)
+			bus.Wait()
+			Expect(calls).Should(Equal(3))
+		})
+		It("doesn't let failures reach the publisher or the other handlers", func() {
+			var mu sync.Mutex
+			calls := 0
+			bus.SubscribeAsync("created", func(event Event) error {
+				return errors.New("handler failed")
+			})
+			bus.SubscribeAsync("created", func(event Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				return nil
+			})
+
+			bus.Publish(newTestEvent("created"))
+			bus.Wait()
+			Expect(calls).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Event ...
+type Event interface {
+	Name() string
+	OccurredOn() time.Time
+}
+
+// BaseEvent implements the common parts of Event, embed it in domain events
+type BaseEvent struct {
+	occurredOn time.Time
+}
+
+// NewBaseEvent ...
+func NewBaseEvent() BaseEvent {
+	return BaseEvent{occurredOn: time.Now().UTC()}
+}
+
+// OccurredOn ...
+func (e BaseEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// Handler ...
+type Handler func(Event) error
+
+// Bus dispatches domain events published by the application services
+type Bus interface {
+	// Publish runs the synchronous handlers in subscription order,
+	// asynchronous handlers run in background. Events are published once
+	// the change is saved, so handler errors are logged instead of failing
+	// the change.
+	Publish(events ...Event)
+	// Subscribe registers a handler executed inside Publish
+	Subscribe(name string, handler Handler)
+	// SubscribeAsync registers a handler executed in its own goroutine,
+	// errors are only logged
+	SubscribeAsync(name string, handler Handler)
+	// Wait blocks until the running asynchronous handlers finish
+	Wait()
+}
+
+type inMemoryBus struct {
+	mu       sync.RWMutex
+	sync     map[string][]Handler
+	async    map[string][]Handler
+	inFlight sync.WaitGroup
+}
+
+// NewInMemoryBus ...
+func NewInMemoryBus() Bus {
+	return &inMemoryBus{
+		sync:  make(map[string][]Handler),
+		async: make(map[string][]Handler),
+	}
+}
+
+func (b *inMemoryBus) Publish(events ...Event) {
+	for _, event := range events {
+		b.mu.RLock()
+		syncHandlers := b.sync[event.Name()]
+		asyncHandlers := b.async[event.Name()]
+		b.mu.RUnlock()
+
+		for _, handler := range syncHandlers {
+			if err := handler(event); err != nil {
+				log.Printf("Error handling %s: %s", event.Name(), err.Error())
+			}
+		}
+		for _, handler := range asyncHandlers {
+			b.inFlight.Add(1)
+			go func(handler Handler, event Event) {
+				defer b.inFlight.Done()
+				if err := handler(event); err != nil {
+					log.Printf("Error handling %s: %s", event.Name(), err.Error())
+				}
+			}(handler, event)
+		}
+	}
+}
+
+func (b *inMemoryBus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync[name] = append(b.sync[name], handler)
+}
+
+func (b *inMemoryBus) SubscribeAsync(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async[name] = append(b.async[name], handler)
+}
+
+func (b *inMemoryBus) Wait() {
+	b.inFlight.Wait()
+}
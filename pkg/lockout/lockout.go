@@ -0,0 +1,83 @@
+package lockout
+
+import "time"
+
+// Policy sets when a key gets locked out: after MaxAttempts failures in a
+// row it is locked for BaseLockout, every further failure doubles it up
+// to MaxLockout. Failures are forgotten after Window without new ones.
+type Policy struct {
+	MaxAttempts int64
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	Window      time.Duration
+}
+
+// Lockout returns how long a key with the given failures stays locked
+func (p Policy) Lockout(failures int64) time.Duration {
+	if failures < p.MaxAttempts {
+		return 0
+	}
+	lockout := p.BaseLockout
+	for i := p.MaxAttempts; i < failures && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		return p.MaxLockout
+	}
+	return lockout
+}
+
+// Store keeps the failed attempts and lockouts of every key
+type Store interface {
+	// Fail records a failed attempt and returns the failures in a row,
+	// the count expires after window without failures
+	Fail(key string, window time.Duration) (int64, error)
+	// Lock locks the key for the given time
+	Lock(key string, ttl time.Duration) error
+	// Locked returns how long the key stays locked, zero when it isn't
+	Locked(key string) (time.Duration, error)
+	// Reset forgets the failures and the lockout of the key
+	Reset(key string) error
+}
+
+// Limiter locks out the keys (IPs, accounts ...) failing too many times
+type Limiter interface {
+	// Check returns how long the key stays locked, zero when it isn't
+	Check(key string) (time.Duration, error)
+	// Fail records a failed attempt and returns the lockout it caused
+	Fail(key string) (time.Duration, error)
+	// Succeed forgets the failures of the key
+	Succeed(key string) error
+}
+
+type limiter struct {
+	store  Store
+	policy Policy
+	prefix string
+}
+
+// NewLimiter returns a Limiter applying the policy, prefix separates the
+// keys of limiters sharing a store
+func NewLimiter(store Store, policy Policy, prefix string) Limiter {
+	return &limiter{store: store, policy: policy, prefix: prefix}
+}
+
+func (l *limiter) Check(key string) (time.Duration, error) {
+	return l.store.Locked(l.prefix + key)
+}
+
+func (l *limiter) Fail(key string) (time.Duration, error) {
+	failures, err := l.store.Fail(l.prefix+key, l.policy.Window)
+	if err != nil {
+		return 0, err
+	}
+	lockout := l.policy.Lockout(failures)
+	if lockout == 0 {
+		return 0, nil
+	}
+	return lockout, l.store.Lock(l.prefix+key, lockout)
+}
+
+func (l *limiter) Succeed(key string) error {
+	return l.store.Reset(l.prefix + key)
+}
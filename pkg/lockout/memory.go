@@ -0,0 +1,91 @@
+package lockout
+
+import (
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	failures    int64
+	expires     time.Time
+	lockedUntil time.Time
+}
+
+// NewMemoryStore returns a goroutine safe Store kept in process memory
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries:   make(map[string]*memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryStore) Fail(key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+	entry, ok := s.entries[key]
+	if !ok || now.After(entry.expires) {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+	entry.failures++
+	entry.expires = now.Add(window)
+	return entry.failures, nil
+}
+
+func (s *memoryStore) Lock(key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryEntry{expires: now}
+		s.entries[key] = entry
+	}
+	entry.lockedUntil = now.Add(ttl)
+	if entry.expires.Before(entry.lockedUntil) {
+		entry.expires = entry.lockedUntil
+	}
+	return nil
+}
+
+func (s *memoryStore) Locked(key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return 0, nil
+	}
+	if remaining := time.Until(entry.lockedUntil); remaining > 0 {
+		return remaining, nil
+	}
+	return 0, nil
+}
+
+func (s *memoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep drops expired entries, the caller must hold the lock
+func (s *memoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
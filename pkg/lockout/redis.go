@@ -0,0 +1,58 @@
+package lockout
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a Store shared by every instance using the Redis
+// server, counters are updated atomically
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Fail(key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(failuresKey(key))
+		pipe.PExpire(failuresKey(key), window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (s *redisStore) Lock(key string, ttl time.Duration) error {
+	return s.client.Set(lockKey(key), "1", ttl).Err()
+}
+
+func (s *redisStore) Locked(key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(lockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// Negative values mean the key doesn't exist or has no expiration
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *redisStore) Reset(key string) error {
+	return s.client.Del(failuresKey(key), lockKey(key)).Err()
+}
+
+func failuresKey(key string) string {
+	return "lockout:failures:" + key
+}
+
+func lockKey(key string) string {
+	return "lockout:lock:" + key
+}
@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/twinj/uuid"
+)
+
+type fileSender struct {
+	dir string
+}
+
+// NewFileSender returns a Sender saving every email as a .eml file in dir,
+// an outbox to read the emails sent while running locally or in tests
+func NewFileSender(dir string) (Sender, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &fileSender{dir: dir}, nil
+}
+
+func (s *fileSender) Send(message *Message) error {
+	now := time.Now().UTC()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), uuid.NewV4().String())
+	content := fmt.Sprintf("Date: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		now.Format(time.RFC1123Z), headerValue(message.To), headerValue(message.Subject), message.Body)
+	return ioutil.WriteFile(filepath.Join(s.dir, name), []byte(content), 0644)
+}
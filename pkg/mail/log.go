@@ -0,0 +1,23 @@
+package mail
+
+import "log"
+
+type logSender struct {
+	logger *log.Logger
+}
+
+// NewLogSender returns a Sender writing the emails to the given logger,
+// the standard one when nil. Meant for local development.
+func NewLogSender(logger *log.Logger) Sender {
+	return &logSender{logger: logger}
+}
+
+func (s *logSender) Send(message *Message) error {
+	format := "Mail to %s\nSubject: %s\n\n%s\n"
+	if s.logger == nil {
+		log.Printf(format, message.To, message.Subject, message.Body)
+		return nil
+	}
+	s.logger.Printf(format, message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,13 @@
+package mail
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails to the users
+type Sender interface {
+	Send(message *Message) error
+}
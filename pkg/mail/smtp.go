@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender returns a Sender delivering the emails through an SMTP
+// server, the connection is only authenticated when username is set
+func NewSMTPSender(host string, port int, username, password, from string) Sender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpSender{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (s *smtpSender) Send(message *Message) error {
+	to := headerValue(message.To)
+	content := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\n"+
+			"Content-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		headerValue(s.from), to, headerValue(message.Subject), message.Body)
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(content))
+}
+
+// headerValue drops the line breaks that would let a value add headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
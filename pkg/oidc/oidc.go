@@ -0,0 +1,327 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "something/pkg/redisjwt"
+
+	gojwt "github.com/dgrijalva/jwt-go"
+)
+
+// keysRefreshInterval is the minimum time between two JWKS downloads, keys
+// are fetched again when an ID token is signed with an unknown key
+const keysRefreshInterval = time.Minute
+
+// Config identifies the client in an OpenID Connect provider. Issuer is
+// the provider URL, its configuration is discovered from
+// Issuer/.well-known/openid-configuration. Without ClientSecret the client
+// is public and relies on PKCE alone.
+type Config struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// DefaultScopes ...
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// Claims are the claims of a verified ID token identifying the user
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// Provider runs the authorization code flow with PKCE (RFC 7636) against
+// an OpenID Connect provider
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns the URL sending the user to log in the provider
+	AuthCodeURL(state, nonce, challenge string) (string, error)
+	// Exchange redeems the authorization code and returns the claims of
+	// the ID token once its signature, issuer, audience, expiration and
+	// nonce are verified
+	Exchange(code, verifier, nonce string) (*Claims, error)
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	config Config
+	client *http.Client
+
+	mu          sync.Mutex
+	discovery   *discovery
+	keys        map[string]*jwt.JWK
+	keysFetched time.Time
+}
+
+// NewProvider returns a Provider discovering its endpoints on first use,
+// a nil client uses a client with a 10 seconds timeout
+func NewProvider(config Config, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 10}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultScopes
+	}
+	return &provider{config: config, client: client}
+}
+
+func (p *provider) Name() string {
+	return p.config.Name
+}
+
+func (p *provider) AuthCodeURL(state, nonce, challenge string) (string, error) {
+	d, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+func (p *provider) Exchange(code, verifier, nonce string) (*Claims, error) {
+	d, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", p.config.ClientID)
+	req, err := http.NewRequest(http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.fetch(req, &tokens)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%s token endpoint: %s %s", p.config.Name, tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("%s token endpoint: no id_token returned", p.config.Name)
+	}
+	return p.verify(d, tokens.IDToken, nonce)
+}
+
+// verify checks the ID token as required by OpenID Connect Core 3.1.3.7
+func (p *provider) verify(d *discovery, idToken, nonce string) (*Claims, error) {
+	token, err := gojwt.Parse(idToken, func(token *gojwt.Token) (interface{}, error) {
+		alg := token.Method.Alg()
+		if alg != gojwt.SigningMethodRS256.Alg() && alg != jwt.SigningMethodEdDSA.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.key(d, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.Alg != "" && key.Alg != alg {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.PublicKey()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s id_token: %s", p.config.Name, err)
+	}
+	claims, ok := token.Claims.(gojwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("%s id_token: invalid token", p.config.Name)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%s id_token: missing expiration", p.config.Name)
+	}
+	if iss, _ := claims["iss"].(string); iss != d.Issuer {
+		return nil, fmt.Errorf("%s id_token: unexpected issuer %q", p.config.Name, iss)
+	}
+	if !p.audienced(claims) {
+		return nil, fmt.Errorf("%s id_token: unexpected audience", p.config.Name)
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%s id_token: nonce mismatch", p.config.Name)
+	}
+
+	result := &Claims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.PreferredUsername, _ = claims["preferred_username"].(string)
+	// Some providers send the flag as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+	if result.Subject == "" {
+		return nil, fmt.Errorf("%s id_token: missing subject", p.config.Name)
+	}
+	return result, nil
+}
+
+// audienced reports whether the token was issued to the client, aud is a
+// string or a list and azp names the client when there are several
+func (p *provider) audienced(claims gojwt.MapClaims) bool {
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []interface{}:
+		for _, value := range aud {
+			if s, ok := value.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	found := false
+	for _, aud := range audiences {
+		if aud == p.config.ClientID {
+			found = true
+		}
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.config.ClientID {
+		return false
+	}
+	return found
+}
+
+// discover fetches the provider configuration, it is kept once found
+func (p *provider) discover() (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	req, err := http.NewRequest(http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	d := &discovery{}
+	status, err := p.fetch(req, d)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%s discovery: unexpected status %d", p.config.Name, status)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("%s discovery: issuer %q doesn't match %q", p.config.Name, d.Issuer, p.config.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("%s discovery: missing endpoints", p.config.Name)
+	}
+	p.discovery = d
+	return d, nil
+}
+
+// key returns the provider key with the kid, the keys are downloaded again
+// when it isn't known. Tokens without kid are accepted when the provider
+// publishes a single key.
+func (p *provider) key(d *discovery, kid string) (*jwt.JWK, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key := p.lookup(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetched) < keysRefreshInterval {
+		return nil, fmt.Errorf("unknown key: %s", kid)
+	}
+	req, err := http.NewRequest(http.MethodGet, d.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	jwks := &jwt.JWKS{}
+	status, err := p.fetch(req, jwks)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%s jwks: unexpected status %d", p.config.Name, status)
+	}
+	p.keys = map[string]*jwt.JWK{}
+	for _, key := range jwks.Keys {
+		if key.Use == "" || key.Use == "sig" {
+			p.keys[key.Kid] = key
+		}
+	}
+	p.keysFetched = time.Now()
+	if key := p.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key: %s", kid)
+}
+
+// lookup finds a downloaded key, the caller must hold the lock
+func (p *provider) lookup(kid string) *jwt.JWK {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+// fetch decodes the JSON response of the request into v
+func (p *provider) fetch(req *http.Request, v interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, errors.New("invalid JSON response from " + req.URL.Host)
+	}
+	return resp.StatusCode, nil
+}
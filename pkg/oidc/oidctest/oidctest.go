@@ -0,0 +1,218 @@
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"something/pkg/oidc"
+	jwt "something/pkg/redisjwt"
+
+	gojwt "github.com/dgrijalva/jwt-go"
+)
+
+// User is the account logging in the stub provider
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// Provider is a local OpenID Connect provider for tests and development.
+// It authorizes right away the user given to Login, requires PKCE and
+// signs the ID tokens with an Ed25519 key published in its JWKS.
+type Provider struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	keys  jwt.KeySet
+	mu    sync.Mutex
+	user  *User
+	codes map[string]*grant
+}
+
+type grant struct {
+	user        User
+	redirectURI string
+	challenge   string
+	nonce       string
+}
+
+// NewProvider starts a stub provider, Close stops it
+func NewProvider(clientID, clientSecret string) *Provider {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	key, err := jwt.NewKey("stub", private, time.Time{})
+	if err != nil {
+		panic(err)
+	}
+	keys, err := jwt.NewKeySet(key)
+	if err != nil {
+		panic(err)
+	}
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		keys:         keys,
+		codes:        map[string]*grant{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+// Config returns the client configuration of the provider
+func (p *Provider) Config(name, redirectURL string) oidc.Config {
+	return oidc.Config{
+		Name:         name,
+		Issuer:       p.URL,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  redirectURL,
+	}
+}
+
+// Login sets the user authorizing the next requests, with nil the user
+// denies them
+func (p *Provider) Login(user *User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"jwks_uri":                              p.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwt.SigningMethodEdDSA.Alg()},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() || query.Get("client_id") != p.ClientID {
+		http.Error(w, "invalid client or redirect_uri", http.StatusBadRequest)
+		return
+	}
+	params := redirect.Query()
+	params.Set("state", query.Get("state"))
+
+	p.mu.Lock()
+	user := p.user
+	switch {
+	case query.Get("response_type") != "code":
+		params.Set("error", "unsupported_response_type")
+	case query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256":
+		params.Set("error", "invalid_request")
+	case user == nil:
+		params.Set("error", "access_denied")
+	default:
+		code := random()
+		p.codes[code] = &grant{
+			user:        *user,
+			redirectURI: redirect.String(),
+			challenge:   query.Get("code_challenge"),
+			nonce:       query.Get("nonce"),
+		}
+		params.Set("code", code)
+	}
+	p.mu.Unlock()
+
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	code := r.PostFormValue("code")
+	g, ok := p.codes[code]
+	// Codes are single use
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if r.PostFormValue("grant_type") != "authorization_code" || !ok ||
+		g.redirectURI != r.PostFormValue("redirect_uri") ||
+		oidc.Challenge(r.PostFormValue("code_verifier")) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken, err := p.keys.Sign(gojwt.MapClaims{
+		"iss":                p.URL,
+		"sub":                g.user.Subject,
+		"aud":                p.ClientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Minute * 5).Unix(),
+		"nonce":              g.nonce,
+		"email":              g.user.Email,
+		"email_verified":     g.user.EmailVerified,
+		"name":               g.user.Name,
+		"preferred_username": g.user.PreferredUsername,
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": random(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, p.keys.JWKS())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func random() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
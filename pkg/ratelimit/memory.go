@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	bucket
+	full time.Time
+}
+
+// NewMemoryStore returns a goroutine safe Store kept in process memory,
+// every instance of the API has its own buckets
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets:   make(map[string]*memoryBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryStore) Take(key string, quota Quota) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		s.buckets[key] = b
+	}
+	result := b.take(quota, now)
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep drops the buckets already full, they are the same as new ones.
+// The caller must hold the lock.
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
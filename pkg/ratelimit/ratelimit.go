@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quota is a token bucket holding up to Limit requests, refilled at Limit
+// requests per Period
+type Quota struct {
+	Limit  int64
+	Period time.Duration
+}
+
+// ParseQuota reads quotas written as "<limit>/<period>", like "100/1m"
+func ParseQuota(value string) (Quota, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Quota{}, errors.New("quota must look like <limit>/<period>")
+	}
+	limit, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil || limit <= 0 {
+		return Quota{}, errors.New("quota limit must be a positive number")
+	}
+	period, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || period <= 0 {
+		return Quota{}, errors.New("quota period must be a positive duration")
+	}
+	return Quota{Limit: limit, Period: period}, nil
+}
+
+// interval is the time the bucket takes to get one token back
+func (q Quota) interval() time.Duration {
+	return q.Period / time.Duration(q.Limit)
+}
+
+// Result of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token when not allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets, taking a token must be atomic
+type Store interface {
+	Take(key string, quota Quota) (*Result, error)
+}
+
+// bucket is the state of a token bucket at a given time
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take refills the bucket up to now and tries to take a token from it,
+// shared by the stores keeping the state in Go
+func (b *bucket) take(quota Quota, now time.Time) *Result {
+	interval := quota.interval()
+	if b.updated.IsZero() {
+		b.tokens = float64(quota.Limit)
+	} else if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(interval)
+		if b.tokens > float64(quota.Limit) {
+			b.tokens = float64(quota.Limit)
+		}
+	}
+	b.updated = now
+
+	result := &Result{Limit: quota.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(interval))
+	}
+	result.Remaining = int64(b.tokens)
+	result.Reset = time.Duration((float64(quota.Limit) - b.tokens) * float64(interval))
+	return result
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// takeScript refills and takes a token atomically. KEYS[1] is the bucket,
+// ARGV holds the limit, the refill interval and the current time, both
+// in microseconds. It returns allowed, remaining, reset and retry after,
+// the last two in microseconds.
+var takeScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil then
+  tokens = limit
+elseif now > updated then
+  tokens = math.min(limit, tokens + (now - updated) / interval)
+end
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) * interval)
+end
+local reset = math.ceil((limit - tokens) * interval)
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(math.max(now, updated or now)))
+redis.call("PEXPIRE", KEYS[1], math.max(1, math.ceil(reset / 1000)))
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a Store shared by every instance using the Redis
+// server, buckets expire once they are full again
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Take(key string, quota Quota) (*Result, error) {
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+	interval := int64(quota.interval() / time.Microsecond)
+	if interval < 1 {
+		interval = 1
+	}
+	values, err := takeScript.Run(s.client, []string{"ratelimit:" + key}, quota.Limit, interval, now).Result()
+	if err != nil {
+		return nil, err
+	}
+	reply := values.([]interface{})
+	return &Result{
+		Allowed:    reply[0].(int64) == 1,
+		Limit:      quota.Limit,
+		Remaining:  reply[1].(int64),
+		Reset:      time.Duration(reply[2].(int64)) * time.Microsecond,
+		RetryAfter: time.Duration(reply[3].(int64)) * time.Microsecond,
+	}, nil
+}
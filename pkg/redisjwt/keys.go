@@ -0,0 +1,212 @@
+package redisjwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// KeySet signs tokens with its current key and verifies them with the key
+// their header points to
+type KeySet interface {
+	Sign(claims jwt.MapClaims) (string, error)
+	Parse(tokenString string) (*jwt.Token, error)
+	// JWKS returns the public keys, it is empty for shared secrets
+	JWKS() *JWKS
+}
+
+type hmacKeySet struct {
+	secret []byte
+}
+
+// NewHMACKeySet returns a KeySet signing with HS256 and a shared secret
+func NewHMACKeySet(secret string) KeySet {
+	return &hmacKeySet{secret: []byte(secret)}
+}
+
+func (s *hmacKeySet) Sign(claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+func (s *hmacKeySet) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		//Make sure that the token method conform to "SigningMethodHMAC"
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	})
+}
+
+func (s *hmacKeySet) JWKS() *JWKS {
+	return &JWKS{Keys: []*JWK{}}
+}
+
+// Key is an asymmetric signing key, RSA keys sign with RS256 and Ed25519
+// keys with EdDSA. It signs the tokens from NotBefore until a newer key
+// takes over, and verifies them as long as it is in the set.
+type Key struct {
+	ID        string
+	NotBefore time.Time
+	method    jwt.SigningMethod
+	private   crypto.Signer
+}
+
+// NewKey ...
+func NewKey(id string, private crypto.Signer, notBefore time.Time) (*Key, error) {
+	if id == "" {
+		return nil, errors.New("key id is required")
+	}
+	key := &Key{ID: id, NotBefore: notBefore, private: private}
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		key.method = SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", id)
+	}
+	return key, nil
+}
+
+// Algorithm returns the JWS algorithm of the key
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// ParseKey reads a PEM encoded private key (PKCS #8, or PKCS #1 for RSA).
+// An optional "Not-Before" PEM header holds the RFC 3339 date the key
+// starts signing, without it the key is active right away.
+func ParseKey(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data found", id)
+	}
+	var private interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %s", id, err)
+	}
+	var notBefore time.Time
+	if value, ok := block.Headers["Not-Before"]; ok {
+		notBefore, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: invalid Not-Before: %s", id, err)
+		}
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", id)
+	}
+	return NewKey(id, signer, notBefore)
+}
+
+// LoadKeys reads every .pem file of the folder as a Key named after the
+// file. Keys are rotated by adding the next one with a future Not-Before
+// (so it is published before it signs) and removing the old one once the
+// tokens it signed have expired.
+func LoadKeys(dir string) ([]*Key, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	keys := []*Key{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseKey(strings.TrimSuffix(filepath.Base(file), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+type keySet struct {
+	keys []*Key
+	byID map[string]*Key
+	now  func() time.Time
+}
+
+// NewKeySet returns a KeySet signing with the newest key already active
+func NewKeySet(keys ...*Key) (KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+	set := &keySet{byID: map[string]*Key{}, now: time.Now}
+	for _, key := range keys {
+		if _, ok := set.byID[key.ID]; ok {
+			return nil, fmt.Errorf("duplicated key id %s", key.ID)
+		}
+		set.byID[key.ID] = key
+		set.keys = append(set.keys, key)
+	}
+	// Newest first
+	sort.SliceStable(set.keys, func(i, j int) bool {
+		return set.keys[i].NotBefore.After(set.keys[j].NotBefore)
+	})
+	return set, nil
+}
+
+// current returns the newest key already active
+func (s *keySet) current() (*Key, error) {
+	now := s.now()
+	for _, key := range s.keys {
+		if !key.NotBefore.After(now) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("no active signing key")
+}
+
+func (s *keySet) Sign(claims jwt.MapClaims) (string, error) {
+	key, err := s.current()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.private)
+}
+
+func (s *keySet) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.byID[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key: %v", token.Header["kid"])
+		}
+		// The algorithm comes from the key, never from the token
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.private.Public(), nil
+	})
+}
+
+func (s *keySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: []*JWK{}}
+	for _, key := range s.keys {
+		jwks.Keys = append(jwks.Keys, newJWK(key))
+	}
+	return jwks
+}
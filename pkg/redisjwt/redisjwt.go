@@ -1,9 +1,9 @@
 package redisjwt
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
-	"strconv"
+	"something/pkg/session"
 	"strings"
 	"time"
 
@@ -14,10 +14,13 @@ import (
 
 // TokenParams ...
 type TokenParams struct {
-	AccessSecret  string
-	RefreshSecret string
-	AccessTime    time.Duration
-	RefreshTime   time.Duration
+	// AccessKeys sign the access tokens, asymmetric keys let other
+	// services verify them with the published JWKS
+	AccessKeys KeySet
+	// RefreshKeys sign the refresh tokens, only verified by this service
+	RefreshKeys KeySet
+	AccessTime  time.Duration
+	RefreshTime time.Duration
 }
 
 // TokenDetails ...
@@ -37,44 +40,142 @@ type AccessDetails struct {
 	Role       string
 }
 
-type redisAuth struct {
-	client        *redis.Client
-	accessSecret  string
-	refreshSecret string
+// RefreshDetails ...
+type RefreshDetails struct {
+	RefreshUUID string
+	UserID      string
 }
 
-func (r *redisAuth) CreateAuth(userid uint64, td *TokenDetails) error {
+// AuthRepository stores the issued token UUIDs so they can be revoked
+type AuthRepository interface {
+	CreateAuth(userID string, td *TokenDetails) error
+	FetchAuth(authD *AccessDetails) (string, error)
+	DeleteAuth(givenUUID string) (int64, error)
+	DeleteTokens(authD *AccessDetails) error
+	// FetchRefresh returns the user of a refresh token that hasn't been
+	// revoked
+	FetchRefresh(refreshD *RefreshDetails) (string, error)
+	// RevokeSessions invalidates every token issued to the user so far,
+	// ttl must cover the lifetime of the longest lived token
+	RevokeSessions(userID string, ttl time.Duration) error
+}
+
+type auth struct {
+	store session.Store
+}
+
+// NewAuth returns an AuthRepository saving the token UUIDs in the given store
+func NewAuth(store session.Store) AuthRepository {
+	return &auth{store: store}
+}
+
+// NewRedisAuth ...
+func NewRedisAuth(client *redis.Client) AuthRepository {
+	return NewAuth(session.NewRedisStore(client))
+}
+
+// NewInMemoryAuth ...
+func NewInMemoryAuth() AuthRepository {
+	return NewAuth(session.NewMemoryStore())
+}
+
+func (a *auth) CreateAuth(userID string, td *TokenDetails) error {
 	at := time.Unix(td.AtExpires, 0) //converting Unix to UTC(to Time object)
 	rt := time.Unix(td.RtExpires, 0)
 	now := time.Now()
 
-	errAccess := r.client.Set(td.AccessUUID, strconv.Itoa(int(userid)), at.Sub(now)).Err()
+	generation, err := a.generation(userID)
+	if err != nil {
+		return err
+	}
+	if generation != "" {
+		// The generation must outlive the tokens issued with it
+		if err := a.store.Set(generationKey(userID), generation, rt.Sub(now)); err != nil {
+			return err
+		}
+	}
+	value := userID + refreshSeparator + generation
+
+	errAccess := a.store.Set(td.AccessUUID, value, at.Sub(now))
 	if errAccess != nil {
 		return errAccess
 	}
-	errRefresh := r.client.Set(td.RefreshUUID, strconv.Itoa(int(userid)), rt.Sub(now)).Err()
+	errRefresh := a.store.Set(td.RefreshUUID, value, rt.Sub(now))
 	if errRefresh != nil {
 		return errRefresh
 	}
 	return nil
 }
 
-func (r *redisAuth) FetchAuth(authD *AccessDetails) (string, error) {
-	userid, err := r.client.Get(authD.AccessUUID).Result()
+func (a *auth) FetchAuth(authD *AccessDetails) (string, error) {
+	return a.fetch(authD.AccessUUID)
+}
+
+func (a *auth) FetchRefresh(refreshD *RefreshDetails) (string, error) {
+	return a.fetch(refreshD.RefreshUUID)
+}
+
+func (a *auth) DeleteAuth(givenUUID string) (int64, error) {
+	return a.store.Delete(givenUUID)
+}
+
+func (a *auth) DeleteTokens(authD *AccessDetails) error {
+	_, err := a.store.Delete(authD.AccessUUID, RefreshUUID(authD.AccessUUID, authD.UserID))
+	return err
+}
+
+func (a *auth) RevokeSessions(userID string, ttl time.Duration) error {
+	return a.store.Set(generationKey(userID), uuid.NewV4().String(), ttl)
+}
+
+// fetch returns the user saved under the token UUID when the token belongs
+// to the current session generation of the user
+func (a *auth) fetch(givenUUID string) (string, error) {
+	value, err := a.store.Get(givenUUID)
 	if err != nil {
 		return "", err
 	}
-	return userid, nil
+	parts := strings.SplitN(value, refreshSeparator, 2)
+	userID, tokenGeneration := parts[0], ""
+	if len(parts) == 2 {
+		tokenGeneration = parts[1]
+	}
+	generation, err := a.generation(userID)
+	if err != nil {
+		return "", err
+	}
+	if generation != tokenGeneration {
+		return "", session.ErrNotFound
+	}
+	return userID, nil
 }
 
-func (r *redisAuth) DeleteAuth(givenUUID string) (int64, error) {
-	deleted, err := r.client.Del(givenUUID).Result()
-	if err != nil {
-		return 0, err
+// generation returns the session generation of the user, it is empty until
+// the sessions of the user are revoked for the first time
+func (a *auth) generation(userID string) (string, error) {
+	generation, err := a.store.Get(generationKey(userID))
+	if err == session.ErrNotFound {
+		return "", nil
 	}
-	return deleted, nil
+	return generation, err
 }
 
+func generationKey(userID string) string {
+	return "sessions" + refreshSeparator + userID
+}
+
+// RefreshUUID returns the refresh token UUID paired with the given access UUID
+func RefreshUUID(accessUUID, userID string) string {
+	return accessUUID + refreshSeparator + userID
+}
+
+// AccessUUID returns the access token UUID paired with the given refresh UUID
+func AccessUUID(refreshUUID string) string {
+	return strings.SplitN(refreshUUID, refreshSeparator, 2)[0]
+}
+
+const refreshSeparator = "++"
+
 // CreateToken ...
 func CreateToken(userid, role string, params *TokenParams) (*TokenDetails, error) {
 
@@ -84,7 +185,7 @@ func CreateToken(userid, role string, params *TokenParams) (*TokenDetails, error
 	td.AccessUUID = uuid.NewV4().String()
 
 	td.RtExpires = time.Now().Add(params.RefreshTime).Unix() // ex: time.Hour * 24 * 7
-	td.RefreshUUID = uuid.NewV4().String()
+	td.RefreshUUID = RefreshUUID(td.AccessUUID, userid)
 
 	var err error
 	//Creating Access Token
@@ -93,8 +194,7 @@ func CreateToken(userid, role string, params *TokenParams) (*TokenDetails, error
 	atClaims["access_uuid"] = td.AccessUUID
 	atClaims["user_id"] = userid
 	atClaims["exp"] = td.AtExpires
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
-	td.AccessToken, err = at.SignedString([]byte(params.AccessSecret))
+	td.AccessToken, err = params.AccessKeys.Sign(atClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +203,7 @@ func CreateToken(userid, role string, params *TokenParams) (*TokenDetails, error
 	rtClaims["refresh_uuid"] = td.RefreshUUID
 	rtClaims["user_id"] = userid
 	rtClaims["exp"] = td.RtExpires
-	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
-	td.RefreshToken, err = rt.SignedString([]byte(params.RefreshSecret))
+	td.RefreshToken, err = params.RefreshKeys.Sign(rtClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -112,48 +211,61 @@ func CreateToken(userid, role string, params *TokenParams) (*TokenDetails, error
 }
 
 // ExtractTokenMetadata ...
-func ExtractTokenMetadata(r *http.Request, accessSecret string) (*AccessDetails, error) {
-	token, err := VerifyToken(r, accessSecret)
+func ExtractTokenMetadata(r *http.Request, accessKeys KeySet) (*AccessDetails, error) {
+	token, err := VerifyToken(r, accessKeys)
 	if err != nil {
 		return nil, err
 	}
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
-		accessUUID, ok := claims["access_uuid"].(string)
-		if !ok {
-			return nil, err
-		}
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			return nil, err
-		}
-		role, ok := claims["role"].(string)
-		if !ok {
-			return nil, err
-		}
-		return &AccessDetails{
-			AccessUUID: accessUUID,
-			UserID:     userID,
-			Role:       role,
-		}, nil
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid access token")
+	}
+	accessUUID, ok := claims["access_uuid"].(string)
+	if !ok {
+		return nil, errors.New("invalid access token")
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, errors.New("invalid access token")
+	}
+	role, ok := claims["role"].(string)
+	if !ok {
+		return nil, errors.New("invalid access token")
 	}
-	return nil, err
+	return &AccessDetails{
+		AccessUUID: accessUUID,
+		UserID:     userID,
+		Role:       role,
+	}, nil
 }
 
-// VerifyToken ...
-func VerifyToken(r *http.Request, accessSecret string) (*jwt.Token, error) {
-	tokenString := ExtractToken(r)
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		//Make sure that the token method conform to "SigningMethodHMAC"
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(accessSecret), nil
-	})
+// ExtractRefreshMetadata ...
+func ExtractRefreshMetadata(refreshToken string, refreshKeys KeySet) (*RefreshDetails, error) {
+	token, err := refreshKeys.Parse(refreshToken)
 	if err != nil {
 		return nil, err
 	}
-	return token, nil
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid refresh token")
+	}
+	refreshUUID, ok := claims["refresh_uuid"].(string)
+	if !ok {
+		return nil, errors.New("invalid refresh token")
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, errors.New("invalid refresh token")
+	}
+	return &RefreshDetails{
+		RefreshUUID: refreshUUID,
+		UserID:      userID,
+	}, nil
+}
+
+// VerifyToken ...
+func VerifyToken(r *http.Request, accessKeys KeySet) (*jwt.Token, error) {
+	return accessKeys.Parse(ExtractToken(r))
 }
 
 // ExtractToken ...
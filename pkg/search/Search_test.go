@@ -0,0 +1,222 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestSearch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Search Suite")
+}
+
+// words returns "<prefix>0 <prefix>1 ... <prefix>n-1"
+func words(prefix string, n int) string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return strings.Join(list, " ")
+}
+
+var _ = Describe("Terms", func() {
+	DescribeTable("Distance",
+		func(a, b string, distance int) {
+			Expect(Distance(a, b)).Should(Equal(distance))
+			Expect(Distance(b, a)).Should(Equal(distance))
+		},
+		Entry("is 0 for equal words", "dune", "dune", 0),
+		Entry("counts every rune of an empty word", "abc", "", 3),
+		Entry("counts an adjacent transposition once", "tolkien", "tolkein", 1),
+		Entry("counts two transpositions twice", "abcd", "badc", 2),
+		Entry("doesn't edit a transposed pair again", "ca", "abc", 3),
+		Entry("counts insertions, deletions and substitutions", "kitten", "sitting", 3),
+		Entry("counts runes, not bytes", "señor", "senor", 1),
+		Entry("deletes a multibyte rune at once", "日本語", "日本", 1),
+	)
+
+	DescribeTable("MaxDistance",
+		func(term string, distance int) {
+			Expect(MaxDistance(term)).Should(Equal(distance))
+		},
+		Entry("tolerates no typo in short terms", "abc", 0),
+		Entry("tolerates one typo from 4 runes", "dune", 1),
+		Entry("tolerates one typo up to 7 runes", "hobbits", 1),
+		Entry("tolerates two typos from 8 runes", "silmarillion", 2),
+		Entry("measures multibyte terms in runes", "日本語", 0),
+		Entry("measures accented terms in runes", "naïve", 1),
+	)
+
+	DescribeTable("match",
+		func(term, word string, quality float64) {
+			Expect(match(term, word)).Should(Equal(quality))
+		},
+		Entry("scores exact matches the most", "dune", "dune", exactMatch),
+		Entry("matches prefixes of 3 runes", "hob", "hobbit", prefixMatch),
+		Entry("ignores shorter prefixes", "ho", "hobbit", 0.0),
+		Entry("matches a transposition", "dnue", "dune", fuzzyMatch),
+		Entry("matches one typo in terms of 4 to 7 runes", "arrakis", "arakis", fuzzyMatch),
+		Entry("rejects two typos in terms of 4 to 7 runes", "dnua", "dune", 0.0),
+		Entry("matches two typos from 8 runes", "silmarilon", "silmarillion", fuzzyMatch),
+		Entry("rejects typos in short terms", "cat", "bat", 0.0),
+		Entry("rejects words too long for the typos", "dune", "sandune", 0.0),
+		Entry("never matches an empty term", "", "dune", 0.0),
+	)
+
+	DescribeTable("FuzzyPattern",
+		func(term, text string, matches bool) {
+			pattern, err := regexp.Compile(FuzzyPattern(term))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pattern.MatchString(text)).Should(Equal(matches))
+		},
+		Entry("matches the term", "dune", "the dune saga", true),
+		Entry("matches words starting with the term", "dune", "dunes", true),
+		Entry("matches a deletion", "dune", "dne", true),
+		Entry("matches a substitution", "dune", "dume", true),
+		Entry("matches an insertion", "dune", "duune", true),
+		Entry("matches a transposition", "dune", "dnue", true),
+		Entry("rejects two typos", "dune", "dxxe", false),
+		Entry("only matches at word starts", "dune", "redune", false),
+		Entry("rejects typos in short terms", "cat", "bat", false),
+		Entry("matches a typo of a multibyte rune", "señor", "senor", true),
+		Entry("quotes metacharacters of short terms", "c++", "c++ primer", true),
+		Entry("doesn't repeat quoted metacharacters", "c++", "cc", false),
+		Entry("doesn't match anything with wildcards", ".*", "anything", false),
+		Entry("quotes metacharacters around typos", "a(b|c)d", "a(b|c)d", true),
+		Entry("doesn't interpret groups", "a(b|c)d", "abd", false),
+	)
+})
+
+var _ = Describe("Tokenize", func() {
+	It("returns no tokens for empty or punctuation only text", func() {
+		Expect(Tokenize("")).Should(BeEmpty())
+		Expect(Tokenize(" ,.-!? ")).Should(BeEmpty())
+	})
+	It("splits on anything but letters and digits", func() {
+		Expect(Tokenize("C++ 2nd-edition")).Should(Equal([]Token{
+			{Term: "c", Start: 0, End: 1},
+			{Term: "2nd", Start: 4, End: 7},
+			{Term: "edition", Start: 8, End: 15},
+		}))
+	})
+	It("lowers multibyte words and keeps their byte offsets", func() {
+		text := "Señor  Ñandú"
+		tokens := Tokenize(text)
+		Expect(tokens).Should(Equal([]Token{
+			{Term: "señor", Start: 0, End: 6},
+			{Term: "ñandú", Start: 8, End: 15},
+		}))
+		Expect(text[tokens[1].Start:tokens[1].End]).Should(Equal("Ñandú"))
+	})
+})
+
+var _ = Describe("Query", func() {
+	DescribeTable("NewQuery",
+		func(text string, terms []string) {
+			query := NewQuery(text)
+			Expect(query.Terms).Should(Equal(terms))
+			Expect(query.Empty()).Should(Equal(len(terms) == 0))
+		},
+		Entry("is empty for empty text", "", nil),
+		Entry("is empty for metacharacters only", ".*( )", nil),
+		Entry("keeps the words of metacharacters", "dune(", []string{"dune"}),
+		Entry("keeps unique terms", "Dune dune DUNE", []string{"dune"}),
+		Entry("keeps short terms", "a it", []string{"a", "it"}),
+		Entry("keeps up to MaxTerms terms", words("w", 12), strings.Fields(words("w", MaxTerms))),
+	)
+
+	DescribeTable("Match scores",
+		func(text string, score float64, fields ...Field) {
+			Expect(NewQuery(text).Match(fields...).Score).Should(Equal(score))
+		},
+		Entry("adds the weight of every term",
+			"dune messiah", 6.0, Field{Name: "title", Text: "Dune Messiah", Weight: 3}),
+		Entry("keeps the best field of each term",
+			"dune", 3.0,
+			Field{Name: "title", Text: "Dune", Weight: 3},
+			Field{Name: "description", Text: "dune dunes", Weight: 1}),
+		Entry("scores typos lower",
+			"dnue", 1.5, Field{Name: "title", Text: "Dune", Weight: 3}),
+		Entry("is 0 without matches",
+			"emma", 0.0, Field{Name: "title", Text: "Dune", Weight: 3}),
+	)
+
+	DescribeTable("Match highlights",
+		func(text string, field Field, highlight string) {
+			highlights := NewQuery(text).Match(field).Highlights
+			if highlight == "" {
+				Expect(highlights).ShouldNot(HaveKey(field.Name))
+			} else {
+				Expect(highlights).Should(HaveKeyWithValue(field.Name, highlight))
+			}
+		},
+		Entry("escapes the text around the matches",
+			"spice", Field{Name: "description", Text: "Politics and <b>spice</b>"},
+			"Politics and &lt;b&gt;<em>spice</em>&lt;/b&gt;"),
+		Entry("keeps the original case and multibyte runes",
+			"senor", Field{Name: "title", Text: "El Señor de los Anillos"},
+			"El <em>Señor</em> de los Anillos"),
+		Entry("leaves fields without matches out",
+			"emma", Field{Name: "title", Text: "Dune"}, ""),
+		Entry("keeps the whole text without snippet",
+			"w20", Field{Name: "description", Text: words("w", 30)},
+			strings.Replace(words("w", 30), "w20", "<em>w20</em>", 1)),
+		Entry("cuts the snippet around the first match",
+			"w20", Field{Name: "description", Text: words("w", 30), Snippet: true},
+			"…w14 w15 w16 w17 w18 w19 <em>w20</em> w21 w22 w23 w24 w25…"),
+		Entry("starts the snippet at the first word",
+			"w0", Field{Name: "description", Text: words("w", 30), Snippet: true},
+			"<em>w0</em> w1 w2 w3 w4 w5 w6 w7 w8 w9 w10 w11…"),
+		Entry("ends the snippet at the last word",
+			"w29", Field{Name: "description", Text: words("w", 30), Snippet: true},
+			"…w23 w24 w25 w26 w27 w28 <em>w29</em>"),
+		Entry("keeps short text whole in snippets",
+			"dune", Field{Name: "description", Text: "Dune, again", Snippet: true},
+			"<em>Dune</em>, again"),
+		Entry("cuts multibyte snippets on rune boundaries",
+			"ñ20", Field{Name: "description", Text: words("ñ", 30), Snippet: true},
+			"…ñ14 ñ15 ñ16 ñ17 ñ18 ñ19 <em>ñ20</em> ñ21 ñ22 ñ23 ñ24 ñ25…"),
+	)
+})
+
+var _ = Describe("Index", func() {
+	var index *Index
+
+	BeforeEach(func() {
+		index = NewIndex()
+		index.Add("hobbit", "The Hobbit", "J. R. R. Tolkien")
+		index.Add("dune", "Dune", "Frank Herbert", "dune dunes")
+	})
+
+	DescribeTable("Search",
+		func(text string, scores map[string]float64) {
+			Expect(index.Search(NewQuery(text))).Should(Equal(scores))
+		},
+		Entry("scores exact matches", "hobbit", map[string]float64{"hobbit": exactMatch}),
+		Entry("scores prefixes", "hob", map[string]float64{"hobbit": prefixMatch}),
+		Entry("scores typos", "hobbti", map[string]float64{"hobbit": fuzzyMatch}),
+		Entry("keeps the best match of a term", "dune", map[string]float64{"dune": exactMatch}),
+		Entry("adds the terms", "dune herbert hobbit",
+			map[string]float64{"dune": 2 * exactMatch, "hobbit": exactMatch}),
+		Entry("returns nothing without matches", "emma", map[string]float64{}),
+		Entry("returns nothing for an empty query", "", map[string]float64{}),
+	)
+
+	It("replaces the texts of a document added again", func() {
+		index.Add("hobbit", "Emma")
+		Expect(index.Search(NewQuery("hobbit"))).Should(BeEmpty())
+		Expect(index.Search(NewQuery("emma"))).Should(HaveKey("hobbit"))
+	})
+	It("removes documents", func() {
+		index.Remove("dune")
+		index.Remove("missing")
+		Expect(index.Search(NewQuery("dune"))).Should(BeEmpty())
+		Expect(index.Search(NewQuery("hobbit"))).Should(HaveLen(1))
+	})
+})
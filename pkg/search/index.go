@@ -0,0 +1,83 @@
+package search
+
+import "sync"
+
+// Index is an inverted index from words to document IDs
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]map[string]bool
+	words    map[string][]string
+}
+
+// NewIndex ...
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]bool),
+		words:    make(map[string][]string),
+	}
+}
+
+// Add indexes the texts of the document id, replacing previous ones
+func (i *Index) Add(id string, texts ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, token := range Tokenize(text) {
+			if seen[token.Term] {
+				continue
+			}
+			seen[token.Term] = true
+			if i.postings[token.Term] == nil {
+				i.postings[token.Term] = make(map[string]bool)
+			}
+			i.postings[token.Term][id] = true
+			i.words[id] = append(i.words[id], token.Term)
+		}
+	}
+}
+
+// Remove ...
+func (i *Index) Remove(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+}
+
+func (i *Index) remove(id string) {
+	for _, word := range i.words[id] {
+		delete(i.postings[word], id)
+		if len(i.postings[word]) == 0 {
+			delete(i.postings, word)
+		}
+	}
+	delete(i.words, id)
+}
+
+// Search scores the documents with a word matching any term of the query,
+// exactly, as a prefix or with typos. Every term adds the quality of its
+// best match in the document.
+func (i *Index) Search(query *Query) map[string]float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	scores := map[string]float64{}
+	for _, term := range query.Terms {
+		best := map[string]float64{}
+		for word, documents := range i.postings {
+			quality := match(term, word)
+			if quality == 0 {
+				continue
+			}
+			for id := range documents {
+				if quality > best[id] {
+					best[id] = quality
+				}
+			}
+		}
+		for id, quality := range best {
+			scores[id] += quality
+		}
+	}
+	return scores
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FuzzyPattern returns a regular expression matching words starting with
+// term or with term after a single typo, for backends without typo
+// tolerance. Every piece of the term is escaped.
+func FuzzyPattern(term string) string {
+	runes := []rune(term)
+	quote := func(rs []rune) string { return regexp.QuoteMeta(string(rs)) }
+
+	alternatives := []string{quote(runes)}
+	if MaxDistance(term) > 0 {
+		for i := range runes {
+			// deletion, substitution and insertion at i
+			alternatives = append(alternatives,
+				quote(runes[:i])+quote(runes[i+1:]),
+				quote(runes[:i])+"."+quote(runes[i+1:]),
+				quote(runes[:i])+"."+quote(runes[i:]),
+			)
+			// transposition of i and i+1
+			if i+1 < len(runes) {
+				swapped := append([]rune{}, runes...)
+				swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+				alternatives = append(alternatives, quote(swapped))
+			}
+		}
+	}
+	return `\b(?:` + strings.Join(alternatives, "|") + `)`
+}
@@ -0,0 +1,135 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+// Highlight marks
+const (
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// snippetTokens is the number of words kept around the first match of long fields
+const snippetTokens = 12
+
+// Field is a weighted piece of text of a document
+type Field struct {
+	Name   string
+	Text   string
+	Weight float64
+	// Snippet cuts the highlight to the words around the first match
+	Snippet bool
+}
+
+// Result is the relevance of a document and its highlighted fields
+type Result struct {
+	Score      float64
+	Highlights map[string]string
+}
+
+// Query is a parsed search text
+type Query struct {
+	Terms []string
+}
+
+// NewQuery keeps the unique words of text, input is never used as a pattern
+func NewQuery(text string) *Query {
+	query := &Query{}
+	seen := map[string]bool{}
+	for _, token := range Tokenize(text) {
+		if seen[token.Term] {
+			continue
+		}
+		seen[token.Term] = true
+		query.Terms = append(query.Terms, token.Term)
+		if len(query.Terms) == MaxTerms {
+			break
+		}
+	}
+	return query
+}
+
+// Empty ...
+func (q *Query) Empty() bool {
+	return len(q.Terms) == 0
+}
+
+// Match scores the fields of a document. Every term adds its best weighted
+// match among the fields, so documents matching more terms, in more
+// important fields and without typos rank first.
+func (q *Query) Match(fields ...Field) *Result {
+	result := &Result{Highlights: map[string]string{}}
+	best := make([]float64, len(q.Terms))
+	for _, field := range fields {
+		tokens := Tokenize(field.Text)
+		matched := make([]bool, len(tokens))
+		anyMatch := false
+		for i, term := range q.Terms {
+			for j, token := range tokens {
+				quality := match(term, token.Term)
+				if quality == 0 {
+					continue
+				}
+				matched[j] = true
+				anyMatch = true
+				if score := quality * field.Weight; score > best[i] {
+					best[i] = score
+				}
+			}
+		}
+		if anyMatch {
+			result.Highlights[field.Name] = highlight(field.Text, tokens, matched, field.Snippet)
+		}
+	}
+	for _, score := range best {
+		result.Score += score
+	}
+	return result
+}
+
+// highlight escapes text for HTML and wraps the matched tokens
+func highlight(text string, tokens []Token, matched []bool, snippet bool) string {
+	from, to := 0, len(tokens)
+	if snippet {
+		first := 0
+		for first < len(matched) && !matched[first] {
+			first++
+		}
+		from = first - snippetTokens/2
+		if from < 0 {
+			from = 0
+		}
+		to = from + snippetTokens
+		if to > len(tokens) {
+			to = len(tokens)
+		}
+	}
+
+	var b strings.Builder
+	start := 0
+	if from > 0 {
+		b.WriteString("…")
+		start = tokens[from].Start
+	}
+	for i := from; i < to; i++ {
+		if !matched[i] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[start:tokens[i].Start]))
+		b.WriteString(HighlightStart)
+		b.WriteString(html.EscapeString(text[tokens[i].Start:tokens[i].End]))
+		b.WriteString(HighlightEnd)
+		start = tokens[i].End
+	}
+	end := len(text)
+	if to < len(tokens) {
+		end = tokens[to-1].End
+	}
+	b.WriteString(html.EscapeString(text[start:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+// Package search implements the text matching shared by the search backends:
+// tokenizing, typo tolerant term matching, relevance scoring and highlighting.
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxTerms is the maximum number of terms of a query, the rest are ignored
+const MaxTerms = 10
+
+// Match quality of a query term against a word
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.75
+	fuzzyMatch  = 0.5
+)
+
+// minPrefixLength is the shortest term matched as a word prefix
+const minPrefixLength = 3
+
+// Token is a normalized word and its byte offsets in the original text
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits text in lower case words of letters and digits
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		}
+		if !isWord && start >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Term: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+// MaxDistance is the number of typos tolerated for a term, longer terms
+// tolerate more
+func MaxDistance(term string) int {
+	length := utf8.RuneCountInString(term)
+	switch {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance is the optimal string alignment distance between a and b: the
+// number of insertions, deletions, substitutions and adjacent transpositions
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// match returns the quality of term matching word, 0 if it doesn't
+func match(term, word string) float64 {
+	if term == word {
+		return exactMatch
+	}
+	if len(term) >= minPrefixLength && strings.HasPrefix(word, term) {
+		return prefixMatch
+	}
+	maxDistance := MaxDistance(term)
+	if maxDistance == 0 {
+		return 0
+	}
+	lengthDiff := utf8.RuneCountInString(term) - utf8.RuneCountInString(word)
+	if lengthDiff > maxDistance || -lengthDiff > maxDistance {
+		return 0
+	}
+	if Distance(term, word) <= maxDistance {
+		return fuzzyMatch
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestSession(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Session Suite")
+}
+
+var _ = Describe("Memory store", func() {
+	var store Store
+
+	BeforeEach(func() {
+		store = NewMemoryStore()
+		Expect(store.Set("live", "value", time.Minute)).Should(Succeed())
+		Expect(store.Set("expired", "value", time.Millisecond)).Should(Succeed())
+		time.Sleep(time.Millisecond * 5)
+	})
+
+	DescribeTable("Get",
+		func(key, value string, err error) {
+			got, gotErr := store.Get(key)
+			Expect(got).Should(Equal(value))
+			if err == nil {
+				Expect(gotErr).ShouldNot(HaveOccurred())
+			} else {
+				Expect(gotErr).Should(Equal(err))
+			}
+		},
+		Entry("returns the value of live keys", "live", "value", nil),
+		Entry("doesn't return expired keys", "expired", "", ErrNotFound),
+		Entry("doesn't return missing keys", "missing", "", ErrNotFound),
+	)
+
+	DescribeTable("Delete",
+		func(keys []string, first, second int64) {
+			deleted, err := store.Delete(keys...)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(deleted).Should(Equal(first))
+			deleted, err = store.Delete(keys...)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(deleted).Should(Equal(second))
+		},
+		Entry("counts a live key once", []string{"live"}, int64(1), int64(0)),
+		Entry("doesn't count expired keys", []string{"expired"}, int64(0), int64(0)),
+		Entry("doesn't count missing keys", []string{"missing"}, int64(0), int64(0)),
+		Entry("counts the live keys of the list", []string{"live", "expired", "missing"}, int64(1), int64(0)),
+	)
+
+	It("overwrites the value and the expiration of a key", func() {
+		Expect(store.Set("live", "new", time.Millisecond)).Should(Succeed())
+		time.Sleep(time.Millisecond * 5)
+		_, err := store.Get("live")
+		Expect(err).Should(Equal(ErrNotFound))
+	})
+
+	It("lets a single concurrent Delete win", func() {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var total int64
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				deleted, err := store.Delete("live")
+				Expect(err).ShouldNot(HaveOccurred())
+				mu.Lock()
+				total += deleted
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		Expect(total).Should(Equal(int64(1)))
+	})
+
+	It("supports concurrent Set and Get", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				key := fmt.Sprintf("key-%d", i)
+				for j := 0; j < 100; j++ {
+					Expect(store.Set(key, key, time.Minute)).Should(Succeed())
+					value, err := store.Get(key)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(value).Should(Equal(key))
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+})
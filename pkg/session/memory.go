@@ -0,0 +1,80 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	value   string
+	expires time.Time
+}
+
+// NewMemoryStore returns a goroutine safe Store kept in process memory
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries:   make(map[string]memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryStore) Set(key, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.entries[key] = memoryEntry{value: value, expires: now.Add(ttl)}
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+	return nil
+}
+
+func (s *memoryStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	if time.Now().After(entry.expires) {
+		delete(s.entries, key)
+		return "", ErrNotFound
+	}
+	return entry.value, nil
+}
+
+func (s *memoryStore) Delete(keys ...string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var deleted int64
+	for _, key := range keys {
+		entry, ok := s.entries[key]
+		if !ok {
+			continue
+		}
+		delete(s.entries, key)
+		if !now.After(entry.expires) {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// sweep drops expired entries, the caller must hold the lock
+func (s *memoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
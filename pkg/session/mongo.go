@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoStore struct {
+	con *mongo.Collection
+}
+
+type mongoEntry struct {
+	Key       string
+	Value     string
+	ExpiresAt time.Time
+}
+
+// NewMongoStore returns a Store backed by the "sessions" collection.
+// Expired documents are removed by a TTL index, which Mongo only runs
+// every minute, so reads also check the expiration date.
+func NewMongoStore(m *mongo.Database) Store {
+	con := m.Collection("sessions")
+	_, err := con.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{primitive.E{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{primitive.E{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return &mongoStore{con: con}
+}
+
+func (s *mongoStore) Set(key, value string, ttl time.Duration) error {
+	entry := &mongoEntry{Key: key, Value: value, ExpiresAt: time.Now().UTC().Add(ttl)}
+	_, err := s.con.ReplaceOne(
+		context.TODO(),
+		bson.D{primitive.E{Key: "key", Value: key}},
+		entry,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (s *mongoStore) Get(key string) (string, error) {
+	var entry *mongoEntry
+	err := s.con.FindOne(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "key", Value: key},
+			primitive.E{Key: "expiresat", Value: bson.M{"$gt": time.Now().UTC()}},
+		}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+	return entry.Value, nil
+}
+
+// Delete only removes and counts the keys that haven't expired, the
+// expired documents are left to the TTL index, so deleting an expired key
+// returns 0 like the other stores
+func (s *mongoStore) Delete(keys ...string) (int64, error) {
+	result, err := s.con.DeleteMany(
+		context.TODO(),
+		bson.D{
+			primitive.E{Key: "key", Value: bson.M{"$in": keys}},
+			primitive.E{Key: "expiresat", Value: bson.M{"$gt": time.Now().UTC()}},
+		})
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore ...
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Set(key, value string, ttl time.Duration) error {
+	return s.client.Set(key, value, ttl).Err()
+}
+
+func (s *redisStore) Get(key string) (string, error) {
+	value, err := s.client.Get(key).Result()
+	if err == redis.Nil {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func (s *redisStore) Delete(keys ...string) (int64, error) {
+	return s.client.Del(keys...).Result()
+}
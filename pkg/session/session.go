@@ -0,0 +1,19 @@
+package session
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a key doesn't exist or has expired
+var ErrNotFound = errors.New("session not found")
+
+// Store keeps short lived key/value pairs (token UUIDs, one time codes ...)
+type Store interface {
+	// Set stores value under key, it expires after ttl
+	Set(key, value string, ttl time.Duration) error
+	// Get returns the value of a non expired key or ErrNotFound
+	Get(key string) (string, error)
+	// Delete removes the given keys and returns how many existed
+	Delete(keys ...string) (int64, error)
+}